@@ -1,21 +1,39 @@
 package apiserver
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/rs/cors"
 	httpSwagger "github.com/swaggo/http-swagger/v2" // http-swagger middleware
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/blobstore"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/idempotency"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/search"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/retentionservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/webhookservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/workerservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/graphql/graphqlhandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/workflow"
 	pkg "github.com/yigithankarabulut/ConcurrentTaskService/pkg/mysql"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	_ "github.com/yigithankarabulut/ConcurrentTaskService/docs" // docs is generated by Swag CLI, you have to import it.
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
 
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -35,19 +53,44 @@ import (
 // @Param ServerReadTimeout The timeout for the HTTP server read function.
 // @Param ServerWriteTimeout The timeout for the HTTP server write function.
 // @Param ServerIdleTimeout The timeout for the HTTP server idle function.
+// @Param TrashRetention How long deleted tasks are kept before they are purged.
+// @Param TrashPurgeInterval How often the trash is checked for expired tasks.
+// @Param WorkflowFile The JSON file defining the task status workflow.
+// @Param AttachmentDir The directory keeping the contents of task attachments.
+// @Param GRPCAddr The address of the gRPC server serving the tasks next to the HTTP one.
+// @Param GRPCCertFile The certificate the gRPC server serves TLS with.
+// @Param GRPCKeyFile The private key of GRPCCertFile.
 
 // @Return error     Returns an error if the server fails to start.
 func New(opts ...Option) error {
 	apiServer := &apiServer{
-		logLevel: slog.LevelInfo,
+		logLevel:           slog.LevelInfo,
+		trashRetention:     TrashRetention,
+		trashPurgeInterval: TrashPurgeInterval,
+		attachmentDir:      AttachmentDir,
+		searchBackend:      SearchMySQL,
 	}
 	for _, opt := range opts {
 		opt(apiServer)
 	}
+	if err := errors.Join(apiServer.errs...); err != nil {
+		return err
+	}
+	flow := workflow.Default()
+	if apiServer.workflowFile != "" {
+		w, err := workflow.Load(apiServer.workflowFile)
+		if err != nil {
+			return err
+		}
+		flow = w
+	}
 	db, err := pkg.ConnectDB()
 	if err != nil {
 		return err
 	}
+	if err := pkg.Migrate(context.Background(), db); err != nil {
+		return err
+	}
 
 	if apiServer.logger == nil {
 		logHandlerOpts := &slog.HandlerOptions{Level: apiServer.logLevel}
@@ -55,8 +98,9 @@ func New(opts ...Option) error {
 		apiServer.logger = slog.New(logHandler)
 	}
 
-	slog.SetDefault(apiServer.logger)
-	logger := apiServer.logger
+	// Records logged with the context of a request tell its ID and caller.
+	logger := slog.New(util.NewContextHandler(apiServer.logger.Handler()))
+	slog.SetDefault(logger)
 
 	reqCh := make(chan models.TaskJobModel, WorkerCount)
 	resCh := make(chan any, WorkerCount)
@@ -64,67 +108,202 @@ func New(opts ...Option) error {
 	doneCh := make(chan struct{})
 	wg := &sync.WaitGroup{}
 
+	broker := events.NewBroker()
 	taskStorage := taskstorage.NewTaskStorage(taskstorage.WithTaskDB(db))
-	taskService := taskservice.NewTaskService(taskservice.WithTaskStorage(taskStorage))
+	if apiServer.searchBackend == SearchMemory {
+		if taskStorage, err = taskstorage.NewIndexedStorage(context.Background(), taskStorage, search.NewIndex()); err != nil {
+			return err
+		}
+	}
+	taskService := taskservice.NewTaskService(
+		taskservice.WithTaskStorage(taskStorage),
+		taskservice.WithWorkflow(flow),
+		taskservice.WithBlobStore(blobstore.NewLocal(blobstore.WithRoot(apiServer.attachmentDir))),
+		taskservice.WithEvents(broker),
+		taskservice.WithLogger(logger),
+	)
 	workerService := workerservice.StartTaskWorker(
 		workerservice.WithWorkerCount(WorkerCount),
 		workerservice.WithWaitGroup(wg),
 		workerservice.WithChannel(reqCh, resCh, errCh, doneCh),
 		workerservice.WithService(taskService),
+		workerservice.WithLogger(logger),
+	)
+	retentionservice.StartTrashRetention(
+		retentionservice.WithMaxAge(apiServer.trashRetention),
+		retentionservice.WithInterval(apiServer.trashPurgeInterval),
+		retentionservice.WithTimeout(ContextCancelTimeout),
+		retentionservice.WithLogger(logger),
+		retentionservice.WithService(taskService),
+		retentionservice.WithWaitGroup(wg),
+		retentionservice.WithDone(doneCh),
+	)
+	webhookservice.StartWebhookDispatcher(
+		webhookservice.WithBroker(broker),
+		webhookservice.WithService(taskService),
+		webhookservice.WithLogger(logger),
+		webhookservice.WithWaitGroup(wg),
+		webhookservice.WithDone(doneCh),
 	)
 	httpService := httphandler.New(
 		httphandler.WithPool(workerService),
 		httphandler.WithService(taskService),
+		httphandler.WithEvents(broker),
+		httphandler.WithAllowedOrigins(apiServer.allowedOrigins...),
 		httphandler.WithContextTimeout(ContextCancelTimeout),
 		httphandler.WithLogger(logger),
 	)
+	graphqlService := graphqlhandler.New(
+		graphqlhandler.WithPool(workerService),
+		graphqlhandler.WithService(taskService),
+		graphqlhandler.WithContextTimeout(ContextCancelTimeout),
+		graphqlhandler.WithLogger(logger),
+	)
+	idempotencyStore := idempotency.NewStore(
+		idempotency.WithTTL(IdempotencyTTL),
+		idempotency.WithWait(ContextCancelTimeout),
+	)
 
 	mux := http.NewServeMux()
 
-	mux.HandleFunc(apiPrefix+"/set", httpService.Set)
-	mux.HandleFunc(apiPrefix+"/get", httpService.Get)
-	mux.HandleFunc(apiPrefix+"/update", httpService.Update)
-	mux.HandleFunc(apiPrefix+"/delete", httpService.Delete)
-	mux.HandleFunc(apiPrefix+"/list", httpService.List)
+	idempotent := func(h http.HandlerFunc, methods ...string) http.HandlerFunc {
+		return idempotency.Middleware(idempotencyStore, dto.MaxBulkSize, h, methods...)
+	}
+	mux.HandleFunc(httphandler.TasksPath, idempotent(httpService.Tasks, http.MethodPost))
+	mux.HandleFunc(httphandler.TasksPath+"/", idempotent(httpService.Tasks, http.MethodPut, http.MethodDelete))
+	mux.HandleFunc(apiPrefix+"/set", deprecatedMiddleware(httphandler.TasksPath, idempotent(httpService.Set, http.MethodPost)))
+	mux.HandleFunc(apiPrefix+"/get", deprecatedMiddleware(httphandler.TasksPath, httpService.Get))
+	mux.HandleFunc(apiPrefix+"/update", deprecatedMiddleware(httphandler.TasksPath, idempotent(httpService.Update, http.MethodPut)))
+	mux.HandleFunc(apiPrefix+"/delete", deprecatedMiddleware(httphandler.TasksPath, idempotent(httpService.Delete, http.MethodDelete)))
+	mux.HandleFunc(apiPrefix+"/list", deprecatedMiddleware(httphandler.TasksPath, httpService.List))
+	mux.HandleFunc(apiPrefix+"/search", httpService.Search)
+	mux.HandleFunc(apiPrefix+"/trash", httpService.Trash)
+	mux.HandleFunc(apiPrefix+"/restore", httpService.Restore)
+	mux.HandleFunc(apiPrefix+"/purge", httpService.Purge)
+	mux.HandleFunc(apiPrefix+"/history", httpService.History)
+	mux.HandleFunc(apiPrefix+"/snapshot", httpService.Snapshot)
+	mux.HandleFunc(apiPrefix+"/workflow", httpService.Workflow)
+	mux.HandleFunc(apiPrefix+"/tag", httpService.Tag)
+	mux.HandleFunc(apiPrefix+"/untag", httpService.Untag)
+	mux.HandleFunc(apiPrefix+"/tags", httpService.Tags)
+	mux.HandleFunc(apiPrefix+"/parent", httpService.SetParent)
+	mux.HandleFunc(apiPrefix+"/block", httpService.Block)
+	mux.HandleFunc(apiPrefix+"/unblock", httpService.Unblock)
+	mux.HandleFunc(apiPrefix+"/graph", httpService.Graph)
+	mux.HandleFunc(apiPrefix+"/comment", httpService.AddComment)
+	mux.HandleFunc(apiPrefix+"/comment/edit", httpService.EditComment)
+	mux.HandleFunc(apiPrefix+"/comment/delete", httpService.DeleteComment)
+	mux.HandleFunc(apiPrefix+"/comments", httpService.Comments)
+	mux.HandleFunc(apiPrefix+"/attachment", httpService.Attach)
+	mux.HandleFunc(apiPrefix+"/attachment/download", httpService.Download)
+	mux.HandleFunc(apiPrefix+"/attachment/delete", httpService.Detach)
+	mux.HandleFunc(apiPrefix+"/attachments", httpService.Attachments)
+	mux.HandleFunc(apiPrefix+"/bulk", idempotent(httpService.Bulk, http.MethodPost))
+	mux.HandleFunc(apiPrefix+"/export", httpService.Export)
+	mux.HandleFunc(apiPrefix+"/import", httpService.Import)
+	mux.HandleFunc(apiPrefix+"/patch", deprecatedMiddleware(httphandler.TasksPath, httpService.Patch))
+	mux.HandleFunc(apiPrefix+"/events", httpService.Events)
+	mux.HandleFunc(apiPrefix+"/ws", httpService.Socket)
+	mux.HandleFunc(apiPrefix+"/webhook", httpService.AddWebhook)
+	mux.HandleFunc(apiPrefix+"/webhook/delete", httpService.DeleteWebhook)
+	mux.HandleFunc(apiPrefix+"/webhook/enable", httpService.EnableWebhook)
+	mux.HandleFunc(apiPrefix+"/webhook/deliveries", httpService.WebhookDeliveries)
+	mux.HandleFunc(apiPrefix+"/webhooks", httpService.Webhooks)
+	mux.HandleFunc(graphqlhandler.Path, graphqlService.GraphQL)
 	mux.HandleFunc(apiPrefix+"/generate-jwt", generateJWT)
+	mux.HandleFunc(apiPrefix+"/ticket", generateTicket)
 	mux.HandleFunc("/swagger/", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"), //The url pointing to API definition
 	))
 
 	corsOptions := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"Allow", "Location", "Deprecation", "Sunset", "Link", idempotency.ReplayedHeader, util.RequestIDHeader},
 		AllowCredentials: true,
 	})
 
 	handler := corsOptions.Handler(mux)
+	// The calls of the HTTP and gRPC APIs share one limit.
+	limiter := rate.NewLimiter(5, 10)
 	api := &http.Server{
 		Addr:         ":8080",
-		Handler:      jwtAuthMiddleware(rateLimiterMiddleware(httpLoggingMiddleware(logger, handler))),
+		Handler:      requestIDMiddleware(httpLoggingMiddleware(logger, jwtAuthMiddleware(rateLimiterMiddleware(limiter, handler)))),
 		ReadTimeout:  ServerReadTimeout,
 		WriteTimeout: ServerWriteTimeout,
 		IdleTimeout:  ServerIdleTimeout,
 	}
 
+	grpcOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(grpchandler.UnaryRequestIDInterceptor(), grpchandler.UnaryAuthInterceptor(verifyToken), grpchandler.UnaryRateLimitInterceptor(limiter)),
+		grpc.ChainStreamInterceptor(grpchandler.StreamRequestIDInterceptor(), grpchandler.StreamAuthInterceptor(verifyToken), grpchandler.StreamRateLimitInterceptor(limiter)),
+	}
+	if apiServer.grpcCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(apiServer.grpcCertFile, apiServer.grpcKeyFile)
+		if err != nil {
+			close(doneCh)
+			return fmt.Errorf("grpc tls: %w", err)
+		}
+		grpcOpts = append(grpcOpts, grpc.Creds(creds))
+	} else {
+		logger.Warn("grpc server listens without TLS, so tokens are sent in the clear; set GRPC_TLS_CERT and GRPC_TLS_KEY unless it is behind a TLS terminating proxy")
+	}
+	grpcServer := grpc.NewServer(grpcOpts...)
+	taskpb.RegisterTaskServiceServer(grpcServer, grpchandler.New(
+		grpchandler.WithPool(workerService),
+		grpchandler.WithService(taskService),
+		grpchandler.WithEvents(broker),
+		grpchandler.WithContextTimeout(ContextCancelTimeout),
+		grpchandler.WithLogger(logger),
+	))
+	grpcListener, err := net.Listen("tcp", GRPCAddr)
+	if err != nil {
+		close(doneCh)
+		return fmt.Errorf("grpc listen err: %w", err)
+	}
+
 	shutdown := make(chan os.Signal, 1)
-	apiErr := make(chan error, 1)
+	apiErr := make(chan error, 2)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 
 	go func() {
 		logger.Info("starting api server", "listening", api.Addr)
 		apiErr <- api.ListenAndServe()
 	}()
+	go func() {
+		logger.Info("starting grpc server", "listening", GRPCAddr)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			apiErr <- fmt.Errorf("grpc: %w", err)
+		}
+	}()
 
 	select {
 	case err := <-apiErr:
+		grpcServer.Stop()
 		close(doneCh)
 		return fmt.Errorf("listen and serve err: %w", err)
 	case <-shutdown:
 		logger.Info("shutting down", "pid", os.Getpid())
 		defer logger.Info("shutdown complete", "pid", os.Getpid())
+		stopGRPC(grpcServer, ShutdownTimeout)
 		close(doneCh)
 		time.Sleep(ShutdownTimeout)
 	}
 	return nil
 }
+
+// stopGRPC lets the calls in flight finish for at most timeout, then
+// cancels the ones left, like the streams of Watch.
+func stopGRPC(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		s.Stop()
+	}
+}
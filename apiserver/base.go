@@ -1,7 +1,9 @@
 package apiserver
 
 import (
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 )
 
@@ -11,14 +13,45 @@ const (
 	ServerReadTimeout    = 10 * time.Second
 	ServerWriteTimeout   = 10 * time.Second
 	ServerIdleTimeout    = 60 * time.Second
+	TrashRetention       = 30 * 24 * time.Hour
+	TrashPurgeInterval   = time.Hour
+	AttachmentDir        = "attachments"
+	// IdempotencyTTL is how long the response to a request made with an
+	// Idempotency-Key is replayed to its retries.
+	IdempotencyTTL = 24 * time.Hour
+	// TicketTTL is how long a ticket may be used to open an event
+	// stream after it is made.
+	TicketTTL = time.Minute
+
+	// GRPCAddr is the address of the gRPC server, served next to the
+	// HTTP one.
+	GRPCAddr = ":9090"
 
 	WorkerCount = 100
 	apiPrefix   = "/task"
 )
 
+// The legacy routes having a successor below httphandler.TasksPath are
+// deprecated since LegacyDeprecation and are removed at LegacySunset.
+var (
+	LegacyDeprecation = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	LegacySunset      = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+)
+
 type apiServer struct {
-	logLevel slog.Level
-	logger   *slog.Logger
+	logLevel           slog.Level
+	logger             *slog.Logger
+	trashRetention     time.Duration
+	trashPurgeInterval time.Duration
+	workflowFile       string
+	attachmentDir      string
+	allowedOrigins     []string
+	searchBackend      string
+	grpcCertFile       string
+	grpcKeyFile        string
+	// errs are the errors of the options given malformed values,
+	// returned by New.
+	errs []error
 }
 
 type Option func(*apiServer)
@@ -48,3 +81,104 @@ func WithLogLevel(level string) Option {
 		s.logLevel = logLevel
 	}
 }
+
+// WithTrashRetention sets how long deleted tasks stay in the trash before
+// they are purged, e.g. "720h". A value of "0" keeps them forever, and
+// TrashRetention is used when it is empty.
+func WithTrashRetention(d string) Option {
+	return func(s *apiServer) {
+		if d == "" {
+			return
+		}
+		v, err := time.ParseDuration(d)
+		if err != nil {
+			s.errs = append(s.errs, fmt.Errorf("trash retention: %w", err))
+			return
+		}
+		s.trashRetention = v
+	}
+}
+
+// WithTrashPurgeInterval sets how often the trash is checked for tasks past
+// their retention, e.g. "1h". TrashPurgeInterval is used when it is empty.
+func WithTrashPurgeInterval(d string) Option {
+	return func(s *apiServer) {
+		if d == "" {
+			return
+		}
+		v, err := time.ParseDuration(d)
+		if err != nil {
+			s.errs = append(s.errs, fmt.Errorf("trash purge interval: %w", err))
+			return
+		}
+		s.trashPurgeInterval = v
+	}
+}
+
+// WithWorkflowFile sets the JSON file defining the status workflow. The
+// default workflow is used when it is empty.
+func WithWorkflowFile(path string) Option {
+	return func(s *apiServer) {
+		s.workflowFile = path
+	}
+}
+
+// WithAttachmentDir sets the directory keeping the contents of attachments.
+// AttachmentDir is used when it is empty.
+func WithAttachmentDir(dir string) Option {
+	return func(s *apiServer) {
+		if dir != "" {
+			s.attachmentDir = dir
+		}
+	}
+}
+
+// Search backends: SearchMySQL searches with the FULLTEXT index of the
+// tasks table, SearchMemory with an inverted index kept by the server.
+const (
+	SearchMySQL  = "mysql"
+	SearchMemory = "memory"
+)
+
+// WithSearchBackend sets how tasks are searched, SearchMySQL or
+// SearchMemory. SearchMySQL is used when it is empty. The memory index is
+// meant for backends without full-text search of their own and for a
+// single server, since it sees only the changes made through it.
+func WithSearchBackend(backend string) Option {
+	return func(s *apiServer) {
+		switch backend {
+		case "":
+		case SearchMySQL, SearchMemory:
+			s.searchBackend = backend
+		default:
+			s.errs = append(s.errs, fmt.Errorf("search backend: unknown backend %q", backend))
+		}
+	}
+}
+
+// WithGRPCTLS sets the PEM files of the certificate and private key the
+// gRPC server serves TLS with. Both or neither must be given; without them
+// the server listens in the clear.
+func WithGRPCTLS(certFile, keyFile string) Option {
+	return func(s *apiServer) {
+		if (certFile == "") != (keyFile == "") {
+			s.errs = append(s.errs, fmt.Errorf("grpc tls: both a certificate and a key file are needed"))
+			return
+		}
+		s.grpcCertFile = certFile
+		s.grpcKeyFile = keyFile
+	}
+}
+
+// WithAllowedOrigins sets the comma separated origins of the pages allowed
+// to open a socket besides the one of the server, e.g.
+// "https://app.example.com,https://admin.example.com". "*" allows any.
+func WithAllowedOrigins(origins string) Option {
+	return func(s *apiServer) {
+		for _, origin := range strings.Split(origins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				s.allowedOrigins = append(s.allowedOrigins, origin)
+			}
+		}
+	}
+}
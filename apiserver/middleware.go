@@ -1,38 +1,161 @@
 package apiserver
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/time/rate"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+	"golang.org/x/time/rate"
 )
 
-func rateLimiterMiddleware(h http.Handler) http.Handler {
-	limiter := rate.NewLimiter(5, 10)
+func rateLimiterMiddleware(limiter *rate.Limiter, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !limiter.Allow() {
-			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			problem(w, r, http.StatusTooManyRequests, "")
 			return
 		}
 		h.ServeHTTP(w, r)
 	})
 }
 
+// problem answers r with status and a problem telling detail.
+func problem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	basehttphandler.WriteProblem(w, basehttphandler.NewProblem(r, status, "", detail))
+}
+
+// httpLoggingMiddleware logs every request once it is served, with the
+// status, size and latency of its response and the caller it was served
+// for.
 func httpLoggingMiddleware(l *slog.Logger, h http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
-		h.ServeHTTP(w, r)
+		start := time.Now()
+		lw := &loggingWriter{ResponseWriter: w, status: http.StatusOK}
+		entry := &requestLog{}
+		r = r.WithContext(context.WithValue(r.Context(), requestLogKey{}, entry))
+		h.ServeHTTP(lw, r)
+
+		ctx := r.Context()
+		if entry.actor != "" {
+			ctx = util.WithActor(ctx, entry.actor)
+		}
+		l.InfoContext(ctx, "http request",
+			"method", r.Method,
+			"uri", r.URL.String(),
+			"status", lw.status,
+			"size", lw.size,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"remote_addr", r.RemoteAddr,
+		)
+	}
+	return http.HandlerFunc(fn)
+}
 
-		uri := r.URL.String()
-		method := r.Method
+type requestLogKey struct{}
 
-		l.Info("http request", "method", method, "uri", uri)
+// requestLog collects what the handlers wrapped by httpLoggingMiddleware
+// learn about a request.
+type requestLog struct {
+	actor string
+}
 
+// logActor records the caller of the request of ctx for its log.
+func logActor(ctx context.Context, actor string) {
+	if entry, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		entry.actor = actor
+	}
+}
+
+// loggingWriter counts the response written through it. Streams and
+// sockets flush and hijack the connection through it.
+type loggingWriter struct {
+	http.ResponseWriter
+	status      int
+	size        int64
+	wroteHeader bool
+}
+
+func (lw *loggingWriter) WriteHeader(status int) {
+	if !lw.wroteHeader {
+		lw.wroteHeader = true
+		lw.status = status
+	}
+	lw.ResponseWriter.WriteHeader(status)
+}
+
+func (lw *loggingWriter) Write(b []byte) (int, error) {
+	if !lw.wroteHeader {
+		lw.WriteHeader(http.StatusOK)
+	}
+	n, err := lw.ResponseWriter.Write(b)
+	lw.size += int64(n)
+	return n, err
+}
+
+func (lw *loggingWriter) Flush() {
+	_ = lw.FlushError()
+}
+
+func (lw *loggingWriter) FlushError() error {
+	if !lw.wroteHeader {
+		lw.WriteHeader(http.StatusOK)
+	}
+	return http.NewResponseController(lw.ResponseWriter).Flush()
+}
+
+func (lw *loggingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(lw.ResponseWriter).Hijack()
+	if err == nil {
+		lw.wroteHeader = true
+		lw.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+func (lw *loggingWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
+
+// requestIDMiddleware gives every request an ID: the X-Request-ID of the
+// caller when it is a valid one, a new one otherwise. The ID is carried in
+// the request context, so it is logged and recorded with the changes made
+// by the request, and echoed in the response.
+func requestIDMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(util.RequestIDHeader)
+		if !util.ValidRequestID(id) {
+			id = util.NewRequestID()
+		}
+		w.Header().Set(util.RequestIDHeader, id)
+		h.ServeHTTP(w, r.WithContext(util.WithRequestID(r.Context(), id)))
+	})
+}
+
+// deprecatedMiddleware announces on every response of a legacy route that
+// it is deprecated in favour of successor and goes away at LegacySunset.
+func deprecatedMiddleware(successor string, h http.HandlerFunc) http.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", LegacyDeprecation.Unix())
+	sunset := LegacySunset.UTC().Format(http.TimeFormat)
+	link := "<" + successor + `>; rel="successor-version"`
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", deprecation)
+		w.Header().Set("Sunset", sunset)
+		w.Header().Add("Link", link)
+		h(w, r)
 	}
-	return http.HandlerFunc(fn)
 }
 
 func jwtAuthMiddleware(h http.Handler) http.Handler {
@@ -46,45 +169,131 @@ func jwtAuthMiddleware(h http.Handler) http.Handler {
 			return
 		}
 		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" && r.URL.Path == apiPrefix+"/ws" {
+			authHeader = socketAuthorization(r)
+		}
+		if authHeader == "" && r.URL.Path == apiPrefix+"/events" && r.URL.Query().Has("ticket") {
+			subject, err := verifyTicket(r.URL.Query().Get("ticket"))
+			if err != nil {
+				problem(w, r, http.StatusUnauthorized, "Invalid ticket")
+				return
+			}
+			logActor(r.Context(), subject)
+			h.ServeHTTP(w, r.WithContext(util.WithActor(r.Context(), subject)))
+			return
+		}
 		if authHeader == "" {
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
+			problem(w, r, http.StatusUnauthorized, "Authorization header required")
 			return
 		}
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, "Authorization header format must be Bearer {token}", http.StatusUnauthorized)
+			problem(w, r, http.StatusUnauthorized, "Authorization header format must be Bearer {token}")
 			return
 		}
-		tokenString := parts[1]
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-
+		subject, err := verifyToken(parts[1])
 		if err != nil {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			problem(w, r, http.StatusUnauthorized, "Invalid token")
 			return
 		}
+		logActor(r.Context(), subject)
+		h.ServeHTTP(w, r.WithContext(util.WithActor(r.Context(), subject)))
+	})
+}
 
-		if _, ok := token.Claims.(jwt.MapClaims); ok && !token.Valid {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
-			return
-		}
-		h.ServeHTTP(w, r)
+// socketAuthorization returns the token a browser offered as the second
+// of the subprotocols of a WebSocket handshake, after
+// httphandler.SocketProtocol, as an Authorization header.
+func socketAuthorization(r *http.Request) string {
+	protocols := websocket.Subprotocols(r)
+	if len(protocols) != 2 || protocols[0] != httphandler.SocketProtocol {
+		return ""
+	}
+	return "Bearer " + protocols[1]
+}
+
+// ticketAudience is the audience of tickets, which open event streams
+// only.
+const ticketAudience = "events"
+
+func signingKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+	}
+	return []byte(os.Getenv("JWT_SECRET")), nil
+}
+
+// verifyToken checks a JWT signed with JWT_SECRET and returns its subject.
+// It authenticates the calls of both the HTTP and the gRPC server. Tickets
+// are refused.
+func verifyToken(tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, signingKey)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := token.Claims.(jwt.MapClaims); ok && !token.Valid {
+		return "", errors.New("invalid token")
+	}
+	if audience, _ := token.Claims.GetAudience(); slices.Contains(audience, ticketAudience) {
+		return "", errors.New("tickets only open event streams")
+	}
+	subject, _ := token.Claims.GetSubject()
+	return subject, nil
+}
+
+// verifyTicket checks a ticket made by generateTicket and returns its
+// subject.
+func verifyTicket(ticket string) (string, error) {
+	token, err := jwt.Parse(ticket, signingKey, jwt.WithAudience(ticketAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", err
+	}
+	subject, _ := token.Claims.GetSubject()
+	return subject, nil
+}
+
+// @Summary Generate Ticket
+// @Description Generating a ticket opening /task/events for the caller, for clients like EventSource that cannot set the Authorization header. The ticket is passed as the ticket query parameter and expires a minute after it is made; it is checked when the stream is opened, which outlives it.
+// @Tags JWT
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} string "Ticket Generating Successfully."
+// @Failure 401 {object} basehttphandler.Problem "Error Unauthorized Response."
+// @Router /task/ticket [get]
+func generateTicket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		problem(w, r, http.StatusMethodNotAllowed, "")
+		return
+	}
+	expiresAt := time.Now().Add(TicketTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": util.ActorFromContext(r.Context()),
+		"aud": ticketAudience,
+		"iat": time.Now().Unix(),
+		"exp": expiresAt.Unix(),
 	})
+	ticket, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	if err != nil {
+		problem(w, r, http.StatusInternalServerError, "Error while signing the ticket")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]any{"ticket": ticket, "expires_at": expiresAt.UTC().Format(time.RFC3339)})
 }
 
 // @Summary Generate JWT
-// @Description Generating JWT Token for API Authorization.
+// @Description Generating JWT Token for API Authorization. Anyone may generate one, so its subject is always "anonymous": it is recorded as the actor of the changes made with the token but owns nothing, e.g. no comment. Tokens naming a caller are issued by an identity provider signing them with the same secret.
 // @Tags JWT
 // @Accept json
 // @Produce json
 // @Success 200 {object} string "Token Generating Successfully."
-// @Router /generate-jwt [get]
+// @Router /task/generate-jwt [get]
 func generateJWT(w http.ResponseWriter, r *http.Request) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": util.AnonymousActor,
 		"nbf": time.Now().Unix(),
 		"iat": time.Now().Unix(),
 		"exp": time.Now().Add(time.Hour * 24).Unix(),
@@ -92,7 +301,7 @@ func generateJWT(w http.ResponseWriter, r *http.Request) {
 
 	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
 	if err != nil {
-		http.Error(w, "Error while signing the token", http.StatusInternalServerError)
+		problem(w, r, http.StatusInternalServerError, "Error while signing the token")
 		return
 	}
 
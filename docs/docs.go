@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/delete": {
-            "delete": {
+        "/graphql": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint is used for deleting a task based on its ID.",
+                "description": "This endpoint runs a GraphQL query or mutation over the tasks. Queries read a task by ID or a filtered page of tasks; mutations create, update and delete tasks. Queries longer than 64 KiB, nesting deeper than the depth limit or costing more than the complexity limit are rejected before they are validated. A query may also be sent with GET in the query, operationName and variables parameters. Errors of fields carry the code of their problem in extensions.code.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,49 +30,132 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task"
+                    "GraphQL"
                 ],
-                "summary": "Delete Task by ID.",
+                "summary": "Query and change tasks with GraphQL.",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/graphqlhandler.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The data asked for, with the errors of the fields that failed.",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. The query is invalid or exceeds a limit.",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body or the query is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Error Unsupported Media Type Response. The body is not JSON.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/attachment": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for uploading a file as multipart/form-data in the \"file\" field. Files are limited to 10 MiB and their type is detected from the contents; images, plain text, PDF, zip and gzip files are accepted.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachment"
+                ],
+                "summary": "Attach File to Task.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID required to delete",
-                        "name": "id",
+                        "description": "Task ID to attach the file to",
+                        "name": "task_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Response Body Delete Successfully.",
+                        "description": "Success Response Body. The stored attachment.",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.AttachmentResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request Response. Invalid request parameters.",
+                        "description": "Error Bad Request Response. Invalid request parameters or body.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "404": {
-                        "description": "Not Found Response. No task found with the specified ID.",
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The file is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Error Unsupported Media Type Response. The file type is not accepted.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error. Server encountered an error.",
+                        "description": "Error Internal Server. Server encountered an error.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     }
                 }
             }
         },
-        "/generate-jwt": {
-            "get": {
-                "description": "Generating JWT Token for API Authorization.",
+        "/task/attachment/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for removing an attachment from a task along with its contents.",
                 "consumes": [
                     "application/json"
                 ],
@@ -80,41 +163,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "JWT"
+                    "Attachment"
+                ],
+                "summary": "Delete Attachment by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID required to delete",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Generate JWT",
                 "responses": {
                     "200": {
-                        "description": "Token Generating Successfully.",
+                        "description": "Success Response Body Delete Successfully.",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found Response. No attachment found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
                     }
                 }
             }
         },
-        "/get": {
+        "/task/attachment/download": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint is used for retrieving a task based on its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "This endpoint is used for streaming the contents of an attachment with its detected content type.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Task"
+                    "Attachment"
                 ],
-                "summary": "Get Task by ID.",
+                "summary": "Download Attachment by ID.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID to retrieve",
+                        "description": "Attachment ID to download",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -122,40 +229,40 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Response Body. Task details with the specified ID.",
+                        "description": "Success Response Body. The contents of the attachment.",
                         "schema": {
-                            "$ref": "#/definitions/dto.TaskResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Error Bad Request Response. Invalid request parameters.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "404": {
-                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "description": "Error Not Found Response. No attachment found with the specified ID.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "500": {
                         "description": "Error Internal Server. Server encountered an error.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     }
                 }
             }
         },
-        "/list": {
+        "/task/attachments": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint is used for retrieving a list of tasks based on their status.",
+                "description": "This endpoint is used for listing the files attached to a task, oldest first. Attachments of a task in the trash are not listed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -163,57 +270,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Task"
+                    "Attachment"
                 ],
-                "summary": "List Tasks by Status.",
+                "summary": "List Attachments of Task.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Task Status to retrieve",
-                        "name": "status",
+                        "type": "integer",
+                        "description": "Task ID to list the attachments of",
+                        "name": "task_id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Response Body. List of tasks matching the specified status.",
+                        "description": "Success Response Body. Files attached to the task.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.TaskResponse"
+                                "$ref": "#/definitions/dto.AttachmentResponse"
                             }
                         }
                     },
                     "400": {
                         "description": "Error Bad Request Response. Invalid request parameters.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "404": {
-                        "description": "Error Not Found Response. No tasks found with the specified status.",
+                        "description": "Error Not Found Response. No task found with the specified ID.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "500": {
                         "description": "Error Internal Server. Server encountered an error.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     }
                 }
             }
         },
-        "/set": {
+        "/task/block": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint is used for creating a new task.",
+                "description": "This endpoint is used for recording that a task is blocked by another one. A task cannot move to a terminal status while any of its blockers is open.",
                 "consumes": [
                     "application/json"
                 ],
@@ -223,54 +330,60 @@ const docTemplate = `{
                 "tags": [
                     "Task"
                 ],
-                "summary": "Task Create.",
+                "summary": "Add Task Dependency.",
                 "parameters": [
                     {
-                        "description": "Task Set Request Body",
+                        "description": "Block Request Body. Take ID and the ID of the blocking task",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SetTaskRequest"
+                            "$ref": "#/definitions/dto.TaskDependencyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Response Body",
+                        "description": "Success Response Body. The dependency graph of the task.",
                         "schema": {
-                            "$ref": "#/definitions/dto.TaskResponse"
+                            "$ref": "#/definitions/dto.TaskGraphResponse"
                         }
                     },
                     "400": {
-                        "description": "Error Bad Request Response",
+                        "description": "Error Bad Request Response. Invalid request body.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "404": {
-                        "description": "Error Not Found Response",
+                        "description": "Error Not Found Response. One of the tasks does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. The link would create a cycle.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "500": {
-                        "description": "Error Internal Server",
+                        "description": "Error Internal Server. Server encountered an error.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     }
                 }
             }
         },
-        "/update": {
-            "put": {
+        "/task/bulk": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint is used for updating an existing task.",
+                "description": "This endpoint is used for applying up to 500 set, update and delete operations in order. Each operation takes the body of its single task endpoint plus \"op\". In atomic mode, the default, either every operation is applied or none is; in best_effort mode each operation that succeeds is kept. Every operation reports its own status; 207 is returned when any of them failed. Bodies are limited to 1 MiB.",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,125 +393,3616 @@ const docTemplate = `{
                 "tags": [
                     "Task"
                 ],
-                "summary": "Task Update.",
+                "summary": "Bulk Set, Update and Delete.",
                 "parameters": [
                     {
-                        "description": "Task Update Request Body. Take ID and Update Fields",
+                        "type": "string",
+                        "description": "Key making retries safe. A retry with the same key and body replays the first response.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Bulk Request Body. Mode and operations to apply",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateTaskRequest"
+                            "$ref": "#/definitions/dto.BulkRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Response Body",
+                        "description": "Success Response Body. Every operation was applied.",
                         "schema": {
-                            "$ref": "#/definitions/dto.TaskResponse"
+                            "$ref": "#/definitions/dto.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status Response Body. Some operations failed; an atomic request applied none of them.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkResponse"
                         }
                     },
                     "400": {
-                        "description": "Error Bad Request Response",
+                        "description": "Error Bad Request Response. Invalid request body or operation.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Error Not Found Response",
+                    "409": {
+                        "description": "Error Conflict Response. A request with the same Idempotency-Key is in progress.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The Idempotency-Key was used for another request.",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     },
                     "500": {
                         "description": "Error Internal Server Response",
                         "schema": {
-                            "$ref": "#/definitions/util.ErrorResponse"
+                            "$ref": "#/definitions/basehttphandler.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.SetTaskRequest": {
-            "type": "object",
-            "required": [
-                "description",
-                "id",
-                "status",
-                "title"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
-                }
-            }
         },
-        "dto.TaskResponse": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
+        "/task/comment": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for commenting on a task. The author is the subject of the caller's token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Add Comment to Task.",
+                "parameters": [
+                    {
+                        "description": "Comment Request Body. Take the task ID and the comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The stored comment.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request body.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
                 }
             }
         },
-        "dto.UpdateTaskRequest": {
-            "type": "object",
-            "required": [
-                "description",
-                "id",
-                "status",
-                "title"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
+        "/task/comment/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for deleting a comment. Only the author of a comment may delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Delete Comment by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID required to delete",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body Delete Successfully.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden Response. The comment was written by someone else.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found Response. No comment found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/comment/edit": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for replacing the body of a comment. Only the author of a comment may edit it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Edit Comment.",
+                "parameters": [
+                    {
+                        "description": "Edit Comment Request Body. Take the comment ID and the new comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EditCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The edited comment.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request body.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Error Forbidden Response. The comment was written by someone else.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No comment found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for listing the comments of a task, oldest first. Comments of a task in the trash are not listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "List Comments of Task.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID to list the comments of",
+                        "name": "task_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. One page of the comments.",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/util.PageResponseData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CommentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for deleting a task based on its ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Delete Task by ID.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key making retries safe. A retry with the same key and body replays the first response.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID required to delete",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body Delete Successfully.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. A request with the same Idempotency-Key is in progress.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The Idempotency-Key was used for another request.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for streaming the tasks created, updated and deleted as Server-Sent Events. Each event is named after its type, carries its number as the id and the event with the task as the data. The status filter matches the status of the task after the change. A client reconnecting with the Last-Event-ID header is sent the events it missed first; when they are no longer kept, a reset event tells it to list the tasks again. A client falling too far behind is disconnected and may resume the same way. Browsers, whose EventSource cannot set the Authorization header, pass a ticket from /task/ticket as the ticket query parameter instead; since a ticket expires in a minute, a stream is resumed with a fresh ticket and the last_event_id query parameter.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Task Events.",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only events of tasks having one of these statuses after the change, or before it for deletions, repeated or comma separated. A task moving out of these statuses is not matched.",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only events of these tasks, repeated or comma separated",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received, when the Last-Event-ID header is not sent",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket from /task/ticket, when the Authorization header is not sent",
+                        "name": "ticket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. A stream of events.",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "405": {
+                        "description": "Error Method Not Allowed Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Error Service Unavailable Response. Events are not configured.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for streaming every task matching the filters of /list, as newline delimited JSON or as CSV with a header row. Tasks are read a page at a time, so exports of any size are not held in memory. The X-Export-Status trailer tells whether the export is complete, and X-Export-Count how many tasks were sent; an export interrupted by an error ends early with X-Export-Status failed.",
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Export Tasks.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "ndjson",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having one of these statuses, repeated or comma separated",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks whose title contains this text",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks whose description contains this text",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tasks with an ID greater than or equal to this",
+                        "name": "id_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tasks with an ID less than or equal to this",
+                        "name": "id_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having one of these priorities from 1 to 5, repeated or comma separated",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks assigned to this subject",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having these tags, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether tasks need any or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks due at or after this RFC3339 time",
+                        "name": "due_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks due before this RFC3339 time",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created by this subject",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks last updated by this subject",
+                        "name": "updated_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "updated_at",
+                            "created_by",
+                            "updated_by",
+                            "priority",
+                            "due_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. One task per line.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TaskResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/generate-jwt": {
+            "get": {
+                "description": "Generating JWT Token for API Authorization. Anyone may generate one, so its subject is always \"anonymous\": it is recorded as the actor of the changes made with the token but owns nothing, e.g. no comment. Tokens naming a caller are issued by an identity provider signing them with the same secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "JWT"
+                ],
+                "summary": "Generate JWT",
+                "responses": {
+                    "200": {
+                        "description": "Token Generating Successfully.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving a task based on its ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get Task by ID.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID to retrieve",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. Task details with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/graph": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving the task with its parent and subtasks, every task it transitively blocks or is blocked by, and the dependencies between them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get Task Dependency Graph.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The dependency graph of the task.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskGraphResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving every recorded change of a task, oldest first. History is kept after the task is purged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Get Task History by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID to retrieve the history of",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. Changes recorded for the task.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TaskEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No history found for the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for creating tasks from a newline delimited JSON body, or from a CSV body with a header row when sent as text/csv. Every line is validated like the body of /set and lines are applied in batches of 100; a failed line does not stop the others. Lines are the tasks of /export: a task may be in any status of the workflow, the initial one when none is given, and keeps its tags, parent_id, created_at and updated_at. Parents must exist already or be imported on an earlier line. created_by and updated_by are not kept, the tasks being recorded as created by the caller. CSV columns are matched by name, tags being comma separated, so exports can be imported again. Bodies are limited to 32 MiB and each batch to the request timeout; when reading the body or applying a batch fails, the batches before it are kept.",
+                "consumes": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Import Tasks.",
+                "parameters": [
+                    {
+                        "description": "Tasks, one per line",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The number of imported lines and the errors of the failed ones.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. The body could not be read.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Error Unsupported Media Type Response. The body is neither NDJSON nor CSV.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving a list of tasks matching all of the given filters, every task when none is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "List Tasks.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having one of these statuses, repeated or comma separated",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks whose title contains this text",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks whose description contains this text",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tasks with an ID greater than or equal to this",
+                        "name": "id_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tasks with an ID less than or equal to this",
+                        "name": "id_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having one of these priorities from 1 to 5, repeated or comma separated",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks assigned to this subject",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having these tags, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether tasks need any or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks due at or after this RFC3339 time",
+                        "name": "due_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks due before this RFC3339 time",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created by this subject",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks last updated by this subject",
+                        "name": "updated_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created at or after this RFC3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created before this RFC3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks updated at or after this RFC3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks updated before this RFC3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "updated_at",
+                            "created_by",
+                            "updated_by",
+                            "priority",
+                            "due_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. One page of the tasks matching the filters.",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/util.PageResponseData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TaskResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/parent": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for making a task a subtask of another one, or a top level task again when parent_id is 0.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Set Parent Task.",
+                "parameters": [
+                    {
+                        "description": "Set Parent Request Body. Take ID and the ID of the parent task",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetParentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The task with its new parent.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request body.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. One of the tasks does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. The link would create a cycle.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/patch": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for changing some fields of a task without sending the others. The body is a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902), told apart by the Content-Type, applied to the title, description, status, priority, due_at and assignee of the task. The result is validated like the body of /update. The task is locked while the patch is applied, so concurrent patches do not overwrite each other. Bodies are limited to 64 KiB.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Task Patch.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID to patch",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch Request Body. A merge patch object or an array of patch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The patched task.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters or patch.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. A test operation failed, the status change is not allowed by the workflow, or the task is blocked by open tasks.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Error Unsupported Media Type Response. The body is not a patch.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The patched task is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for permanently removing a deleted task from the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Purge Deleted Task by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID required to purge",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body Purged Successfully.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found Response. No deleted task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/restore": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for restoring a deleted task from the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore Deleted Task by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID required to restore",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. Restored task details.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found Response. No deleted task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for full-text searching the titles and descriptions of tasks. Hits are ranked by relevance and the matched words are highlighted with \u003cmark\u003e tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Search Tasks.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Words to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. One page of the hits, best first.",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/util.PageResponseData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TaskSearchHitResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/set": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for creating a new task.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Task Create.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key making retries safe. A retry with the same key and body replays the first response.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Task Set Request Body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. New tasks must start in the initial status of the workflow, or a request with the same Idempotency-Key is in progress.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is larger than 64 KiB.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The Idempotency-Key was used for another request.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/snapshot": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving a task as it was at the given time, rebuilt from its history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "History"
+                ],
+                "summary": "Get Task as of a Point in Time.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID to retrieve",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time (RFC3339)",
+                        "name": "at",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. Task details at the specified time.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. The task did not exist at the specified time.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/tag": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for attaching tags to a task. Tags are case insensitive and tags the task already has are kept. A task carries 20 tags at most.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Add Tags to Task.",
+                "parameters": [
+                    {
+                        "description": "Tag Request Body. Take ID and the tags to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The task with its tags.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request body or tag, or too many tags.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving the tags in use and how many tasks carry each of them, most used first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "List Tags.",
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The tag usage counts.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TagCountResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/ticket": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generating a ticket opening /task/events for the caller, for clients like EventSource that cannot set the Authorization header. The ticket is passed as the ticket query parameter and expires a minute after it is made; it is checked when the stream is opened, which outlives it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "JWT"
+                ],
+                "summary": "Generate Ticket",
+                "responses": {
+                    "200": {
+                        "description": "Ticket Generating Successfully.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Error Unauthorized Response.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving the tasks that were deleted and can still be restored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List Deleted Tasks.",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having one of these statuses, repeated or comma separated",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks whose title contains this text",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks whose description contains this text",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tasks with an ID greater than or equal to this",
+                        "name": "id_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tasks with an ID less than or equal to this",
+                        "name": "id_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having one of these priorities from 1 to 5, repeated or comma separated",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks assigned to this subject",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having these tags, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether tasks need any or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created by this subject",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks last updated by this subject",
+                        "name": "updated_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "updated_at",
+                            "created_by",
+                            "updated_by",
+                            "deleted_at",
+                            "priority",
+                            "due_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. One page of the deleted tasks.",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/util.PageResponseData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TaskResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/unblock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for removing the link between a task and a task blocking it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Remove Task Dependency.",
+                "parameters": [
+                    {
+                        "description": "Unblock Request Body. Take ID and the ID of the blocking task",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskDependencyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The dependency graph of the task.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskGraphResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request body.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. One of the tasks does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. The link would create a cycle.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/untag": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for detaching tags from a task. Tags the task does not have are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Remove Tags from Task.",
+                "parameters": [
+                    {
+                        "description": "Untag Request Body. Take ID and the tags to remove",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The task with its tags.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request body or tag.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for updating an existing task.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Task Update.",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key making retries safe. A retry with the same key and body replays the first response.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Task Update Request Body. Take ID and Update Fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. The status change is not allowed by the workflow, or the task is blocked by open tasks, or a request with the same Idempotency-Key is in progress.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is larger than 64 KiB.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The Idempotency-Key was used for another request.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/webhook": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for subscribing a URL to the events of tasks: created, updated and deleted, every event when none is given. Every delivery is a POST of the event signed in the X-Webhook-Signature header as \"sha256=\" and the hex HMAC-SHA256, keyed with the secret, of the X-Webhook-Timestamp header, a dot and the body. The secret is generated when not given and only told in this response. Failed deliveries are retried with exponential backoff, and a webhook failing too many times in a row is disabled. URLs on loopback, private or link-local addresses are rejected, and redirects are not followed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Add Webhook.",
+                "parameters": [
+                    {
+                        "description": "Webhook Request Body. Take the URL, the event types and the secret",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The stored webhook and its secret.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request body.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The URL targets an internal address.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/webhook/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for deleting a webhook and its delivery logs. Deliveries already under way are not sent again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Delete Webhook by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID required to delete",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body Delete Successfully.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found Response. No webhook found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/webhook/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for listing the latest delivery attempts of a webhook, newest first, with the status code of the response or the error that failed them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "List Deliveries of Webhook.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID to list the deliveries of",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of deliveries, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The latest deliveries.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No webhook found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/webhook/enable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for enabling a webhook again after it was disabled for failing too many times in a row. Its failures are cleared; the events told while it was disabled are not sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Enable Webhook by ID.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID required to enable",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The enabled webhook.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found Response. No webhook found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for listing the webhooks, oldest first. Their secrets are not told.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "List Webhooks.",
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The webhooks.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/workflow": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving the task statuses, the status new tasks start in and the statuses each one may move to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get Status Workflow.",
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. The status workflow.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WorkflowResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint upgrades to a WebSocket carrying JSON messages. Clients send {\"id\", \"op\", \"data\"} requests with op set, get, update, delete or list, answered by {\"id\", \"op\", \"status\", \"body\"} with the status and body of the matching HTTP endpoint; requests are served concurrently and replies may come in any order. The op subscribe with data {\"topic\", \"last_event_id\"} pushes the events of /task/events on the topic as {\"op\": \"event\", \"topic\", \"body\"} messages, until unsubscribe or a {\"op\": \"reset\", \"topic\"} message. Topics are \"tasks\" for every task, \"tasks/{id}\" for one task and \"status/{status}\" for the tasks in a status. A connection is limited to 20 requests per second. Browsers, which cannot set the Authorization header, send the token as the subprotocols \"bearer\" and the token, e.g. new WebSocket(url, [\"bearer\", token]). Pages of origins other than the server and the ones of ALLOWED_ORIGINS are refused.",
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Task Socket.",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Not a WebSocket handshake.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Error Forbidden Response. The origin of the page is not allowed.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "405": {
+                        "description": "Error Method Not Allowed Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for listing tasks. It takes the query parameters of /task/list, none of which are required.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "List Tasks.",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only tasks having one of these statuses, repeated or comma separated",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to return, the next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. One page of tasks.",
+                        "schema": {
+                            "$ref": "#/definitions/util.PageResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid request parameters.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "405": {
+                        "description": "Error Method Not Allowed Response. The Allow header names the allowed methods.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for creating a new task. The Location header of the response is the path of the task.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Create Task.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key making retries safe. A retry with the same key and body replays the first response.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Task Set Request Body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created Response Body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "405": {
+                        "description": "Error Method Not Allowed Response. The Allow header names the allowed methods.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. New tasks must start in the initial status of the workflow, or a request with the same Idempotency-Key is in progress.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is larger than 64 KiB.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The Idempotency-Key was used for another request.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for retrieving a task based on its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Get Task.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Body. Task details with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "405": {
+                        "description": "Error Method Not Allowed Response. The Allow header names the allowed methods.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for updating every field of a task. The body is the one of /task/update; its id may be left out and must otherwise be the one of the path.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Replace Task.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key making retries safe. A retry with the same key and body replays the first response.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Task Update Request Body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content. The task was updated."
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. The status change is not allowed by the workflow, or the task is blocked by open tasks, or a request with the same Idempotency-Key is in progress.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is larger than 64 KiB.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The Idempotency-Key was used for another request.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for deleting a task based on its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Delete Task.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key making retries safe. A retry with the same key and body replays the first response.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content. The task was deleted."
+                    },
+                    "404": {
+                        "description": "Not Found Response. No task found with the specified ID.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. A request with the same Idempotency-Key is in progress.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The Idempotency-Key was used for another request.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error. Server encountered an error.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint is used for changing some fields of a task with a JSON Merge Patch or a JSON Patch, like /task/patch.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Task"
+                ],
+                "summary": "Patch Task.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch Request Body. A merge patch object or an array of patch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content. The task was patched."
+                    },
+                    "400": {
+                        "description": "Error Bad Request Response. Invalid patch.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Error Not Found Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Error Conflict Response. A test operation failed, the status change is not allowed by the workflow, or the task is blocked by open tasks.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Error Request Entity Too Large Response. The body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Error Unsupported Media Type Response. The body is not a patch.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Error Unprocessable Entity Response. The patched task is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error Internal Server Response",
+                        "schema": {
+                            "$ref": "#/definitions/basehttphandler.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "basehttphandler.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AddCommentRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "task_id"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 4096
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AddWebhookRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "dto.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BulkOperation": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "assignee": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "set",
+                        "update",
+                        "delete"
+                    ]
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BulkRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkOperation"
+                    }
+                }
+            }
+        },
+        "dto.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BulkResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "task": {
+                    "$ref": "#/definitions/dto.TaskResponse"
+                }
+            }
+        },
+        "dto.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EditCommentRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "id"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 4096
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportLineError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportLineError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SetParentRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SetTaskRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "title"
+            ],
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "status": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.TagCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TagTaskRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "tags"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.TaskDependencyRequest": {
+            "type": "object",
+            "required": [
+                "blocker_id",
+                "id"
+            ],
+            "properties": {
+                "blocker_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TaskDependencyResponse": {
+            "type": "object",
+            "properties": {
+                "blocker_id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TaskEventResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_value": {
+                    "$ref": "#/definitions/dto.TaskResponse"
+                },
+                "old_value": {
+                    "$ref": "#/definitions/dto.TaskResponse"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TaskGraphResponse": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "type": "boolean"
+                },
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TaskDependencyResponse"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TaskResponse"
+                    }
+                }
+            }
+        },
+        "dto.TaskHighlights": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TaskResponse": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TaskSearchHitResponse": {
+            "type": "object",
+            "properties": {
+                "highlights": {
+                    "$ref": "#/definitions/dto.TaskHighlights"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "task": {
+                    "$ref": "#/definitions/dto.TaskResponse"
+                }
+            }
+        },
+        "dto.UpdateTaskRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "status",
+                "title"
+            ],
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "util.ErrorResponse": {
+        "dto.WebhookDeliveryResponse": {
             "type": "object",
             "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
                 "error": {
                     "type": "string"
                 },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WorkflowResponse": {
+            "type": "object",
+            "properties": {
+                "initial": {
+                    "type": "string"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "terminal": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transitions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "task": {
+                    "$ref": "#/definitions/dto.TaskResponse"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "graphqlhandler.Request": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "util.PageResponseData": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "next_cursor": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         }
@@ -421,7 +4025,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
-	BasePath:         "/task",
+	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Task API",
 	Description:      "This is a basic server for managing tasks concurrently. It provides endpoints for creating, updating, deleting, and listing tasks. The server also supports JWT authentication for secure access to the API.",
@@ -3,9 +3,5 @@ USE konzekdb;
 CREATE USER IF NOT EXISTS 'konzek'@'%' IDENTIFIED BY 'case123';
 GRANT ALL PRIVILEGES ON konzekdb.* TO 'konzek'@'%';
 FLUSH PRIVILEGES;
-CREATE TABLE IF NOT EXISTS tasks (
-id SERIAL PRIMARY KEY,
-title VARCHAR(255) NOT NULL,
-description VARCHAR(255) NOT NULL,
-status VARCHAR(255) NOT NULL
-);
\ No newline at end of file
+-- The tables are made and kept up to date by the migrations of
+-- pkg/mysql/migrations, applied when the server starts.
@@ -0,0 +1,47 @@
+// Package blobstore keeps the contents of task attachments. Blobs are
+// addressed by slash separated keys such as "tasks/7/3f2a".
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned for keys without a blob.
+	ErrNotFound = errors.New("blobstore: blob not found")
+	// ErrInvalidKey is returned for keys that are empty, absolute or leave
+	// the store with "..".
+	ErrInvalidKey = errors.New("blobstore: invalid key")
+)
+
+// Store is a place to keep blobs. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Put stores the contents of r under key, replacing any blob already
+	// there, and returns the number of bytes written.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Get opens the blob stored under key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Missing blobs are ignored.
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix removes every blob whose key starts with prefix, which
+	// must end with a slash.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// validKey reports whether key is a clean relative key inside the store.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	return path.Clean(key) == key && key != "." && !strings.HasPrefix(key, "../") && key != ".."
+}
+
+// validPrefix reports whether prefix names a directory-like group of keys.
+func validPrefix(prefix string) bool {
+	return strings.HasSuffix(prefix, "/") && validKey(strings.TrimSuffix(prefix, "/"))
+}
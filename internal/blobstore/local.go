@@ -0,0 +1,125 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// local keeps blobs as files below a root directory, one file per key.
+type local struct {
+	root string
+}
+
+type LocalOption func(*local)
+
+// WithRoot sets the directory holding the blobs. It is created on first
+// use.
+func WithRoot(dir string) LocalOption {
+	return func(l *local) {
+		l.root = dir
+	}
+}
+
+// NewLocal returns a Store on the local filesystem, rooted at
+// "attachments" unless WithRoot is given.
+func NewLocal(opts ...LocalOption) Store {
+	l := &local{
+		root: "attachments",
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+func (l *local) path(key string) string {
+	return filepath.Join(l.root, filepath.FromSlash(key))
+}
+
+// Put writes the blob to a temporary file next to its final place and
+// renames it, so readers never see a partial blob.
+func (l *local) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	if !validKey(key) {
+		return 0, ErrInvalidKey
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	dst := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+		return 0, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(tmp, contextReader{ctx: ctx, r: r})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dst)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return 0, err
+	}
+	return n, nil
+}
+
+func (l *local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !validKey(key) {
+		return nil, ErrInvalidKey
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (l *local) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.Remove(l.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *local) DeletePrefix(ctx context.Context, prefix string) error {
+	if !validPrefix(prefix) {
+		return ErrInvalidKey
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return os.RemoveAll(l.path(prefix))
+}
+
+// contextReader stops a copy once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,78 @@
+package blobstore_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/blobstore"
+)
+
+func TestLocalPutGetDelete(t *testing.T) {
+	root := t.TempDir()
+	store := blobstore.NewLocal(blobstore.WithRoot(root))
+	ctx := context.Background()
+
+	n, err := store.Put(ctx, "tasks/1/a", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if n != 5 {
+		t.Errorf("Put() = %v, want %v", n, 5)
+	}
+	rc, err := store.Get(ctx, "tasks/1/a")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(b) != "hello" {
+		t.Errorf("Get() = %q, want %q", b, "hello")
+	}
+	if err := store.Delete(ctx, "tasks/1/a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "tasks/1/a"); !errors.Is(err, blobstore.ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, blobstore.ErrNotFound)
+	}
+	if err := store.Delete(ctx, "tasks/1/a"); err != nil {
+		t.Errorf("Delete() of a missing blob error = %v", err)
+	}
+}
+
+func TestLocalDeletePrefix(t *testing.T) {
+	root := t.TempDir()
+	store := blobstore.NewLocal(blobstore.WithRoot(root))
+	ctx := context.Background()
+
+	for _, key := range []string{"tasks/1/a", "tasks/1/b", "tasks/12/a"} {
+		if _, err := store.Put(ctx, key, strings.NewReader(key)); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+	if err := store.DeletePrefix(ctx, "tasks/1/"); err != nil {
+		t.Fatalf("DeletePrefix() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "tasks", "1")); !os.IsNotExist(err) {
+		t.Errorf("blobs of tasks/1/ are still there: %v", err)
+	}
+	if _, err := store.Get(ctx, "tasks/12/a"); err != nil {
+		t.Errorf("Get() of a blob outside the prefix error = %v", err)
+	}
+	if err := store.DeletePrefix(ctx, "tasks/1"); !errors.Is(err, blobstore.ErrInvalidKey) {
+		t.Errorf("DeletePrefix() without a trailing slash error = %v, want %v", err, blobstore.ErrInvalidKey)
+	}
+}
+
+func TestLocalInvalidKeys(t *testing.T) {
+	store := blobstore.NewLocal(blobstore.WithRoot(t.TempDir()))
+	for _, key := range []string{"", "/etc/passwd", "../secret", "tasks/../../secret", "tasks//a", `tasks\a`} {
+		if _, err := store.Put(context.Background(), key, strings.NewReader("x")); !errors.Is(err, blobstore.ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want %v", key, err, blobstore.ErrInvalidKey)
+		}
+	}
+}
@@ -1,15 +1,45 @@
 package customerror
 
 var (
-	ErrIDNotFound = New("ID not found", false)
-	ErrIDExists   = New("ID exists", false)
-	ErrUnknown    = New("Unknown error", true)
-	ErrDelete     = New("Error while deleting", true)
-	ErrSet        = New("Error while setting", true)
-	ErrUpdate     = New("Error while updating", true)
-	ErrGetAll     = New("Error while getting all", true)
+	ErrIDNotFound = New("task.not_found", "ID not found", false)
+	ErrIDExists   = New("task.id_exists", "ID exists", false)
+	ErrUnknown    = New("internal.unknown", "Unknown error", true)
+	ErrDelete     = New("task.delete_failed", "Error while deleting", true)
+	ErrSet        = New("task.set_failed", "Error while setting", true)
+	ErrUpdate     = New("task.update_failed", "Error while updating", true)
+	ErrGet        = New("task.get_failed", "Error while getting", true)
+	ErrGetAll     = New("task.list_failed", "Error while getting all", true)
+	ErrRestore    = New("task.restore_failed", "Error while restoring", true)
+	ErrPurge      = New("task.purge_failed", "Error while purging", true)
+	ErrHistory    = New("task.history_failed", "Error while getting history", true)
+	ErrCursor     = New("list.invalid_cursor", "Invalid cursor", false)
+	ErrSearch     = New("task.search_failed", "Error while searching", true)
+	ErrStatus     = New("task.unknown_status", "Unknown status", false)
+	ErrTransition = New("task.illegal_transition", "Illegal status transition", false)
+	ErrTag        = New("tag.invalid", "Invalid tag", false)
+	ErrTagging    = New("tag.failed", "Error while tagging", true)
+	ErrCycle      = New("dependency.cycle", "Dependency cycle", false)
+	ErrBlocked    = New("task.blocked", "Task is blocked", false)
+	ErrLink       = New("dependency.failed", "Error while linking tasks", true)
+	ErrComment    = New("comment.failed", "Error while commenting", true)
+	ErrNotAuthor  = New("comment.not_author", "Not the comment author", false)
+	ErrAttachment = New("attachment.failed", "Error while handling attachment", true)
+	ErrFileType   = New("attachment.unsupported_type", "Unsupported file type", false)
+	ErrFileSize   = New("attachment.too_large", "File too large", false)
+	ErrRolledBack = New("bulk.rolled_back", "Rolled back, another operation of the request failed", false)
+	ErrPatch      = New("patch.invalid", "Invalid patch", false)
+	ErrPatchTest  = New("patch.test_failed", "Patch test failed", false)
+	ErrPatched    = New("patch.invalid_result", "Patched task is invalid", false)
+	ErrWebhook    = New("webhook.failed", "Error while handling webhook", true)
+	ErrDisabled   = New("webhook.disabled", "Webhook is disabled", false)
+	ErrDelivery   = New("webhook.delivery_failed", "Webhook delivery failed", false)
+	ErrTarget     = New("webhook.forbidden_target", "Webhook target is not allowed", false)
 )
 
+// CustomError is an error a client is told about. The variables above are
+// sentinels shared by every request and are never modified: Wrap and
+// AddData return a new error carrying the details of one occurrence, which
+// still matches its sentinel with errors.Is.
 type CustomError interface {
 	Wrap(err error) CustomError
 	Unwrap() error
@@ -18,30 +48,43 @@ type CustomError interface {
 	Error() string
 }
 
+// Error is an error a client is told about. Code is stable and machine
+// readable, e.g. "task.not_found"; Message is the human readable summary
+// going with it.
 type Error struct {
 	Err      error
+	Code     string
 	Message  string
 	Data     any `json:"-"`
 	Loggable bool
+
+	// sentinel is the error this one was derived from, nil for sentinels.
+	sentinel *Error
 }
 
+// Wrap returns a copy of e wrapping err.
 func (e *Error) Wrap(err error) CustomError {
-	e.Err = err
-	return e
+	c := e.derive()
+	c.Err = err
+	return c
 }
 
 func (e *Error) Unwrap() error {
 	return e.Err
 }
 
+// AddData returns a copy of e carrying d.
 func (e *Error) AddData(d any) CustomError {
-	e.Data = d
-	return e
+	c := e.derive()
+	c.Data = d
+	return c
 }
 
+// DestroyData returns a copy of e carrying no data.
 func (e *Error) DestroyData() CustomError {
-	e.Data = nil
-	return e
+	c := e.derive()
+	c.Data = nil
+	return c
 }
 
 func (e *Error) Error() string {
@@ -51,8 +94,29 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
-func New(message string, l bool) CustomError {
+// Is reports whether target is the sentinel e was derived from, or another
+// error derived from it.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.root() == e.root()
+}
+
+func (e *Error) derive() *Error {
+	c := *e
+	c.sentinel = e.root()
+	return &c
+}
+
+func (e *Error) root() *Error {
+	if e.sentinel != nil {
+		return e.sentinel
+	}
+	return e
+}
+
+func New(code, message string, l bool) CustomError {
 	return &Error{
+		Code:     code,
 		Message:  message,
 		Loggable: l,
 	}
@@ -2,13 +2,16 @@ package customerror_test
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 )
 
 func TestError(t *testing.T) {
-	err := customerror.New("some error", true)
+	err := customerror.New("some.error", "some error", true)
 	var customErr *customerror.Error
 
 	if !errors.As(err, &customErr) {
@@ -22,10 +25,14 @@ func TestError(t *testing.T) {
 	if customErr.Loggable != shouldLoggable {
 		t.Errorf("error should be loggable, want: %t, got: %t", shouldLoggable, customErr.Loggable)
 	}
+	shouldCode := "some.error"
+	if customErr.Code != shouldCode {
+		t.Errorf("error code does not match, want: %s, got: %s", shouldCode, customErr.Code)
+	}
 }
 
 func TestErrorWrap(t *testing.T) {
-	err := customerror.New("some error", true)
+	err := customerror.New("some.error", "some error", true)
 	err = err.Wrap(errors.New("wrapped error"))
 	var customErr *customerror.Error
 
@@ -47,7 +54,7 @@ func TestErrorWrap(t *testing.T) {
 }
 
 func TestUnwrap(t *testing.T) {
-	err := customerror.New("some error", false)
+	err := customerror.New("some.error", "some error", false)
 	wrappedErr := err.Wrap(errors.New("inner")) // nolint
 
 	var customErr *customerror.Error
@@ -64,7 +71,7 @@ func TestUnwrap(t *testing.T) {
 }
 
 func TestAddDataDestroyData(t *testing.T) {
-	err := customerror.New("some error", false).AddData("hello")
+	err := customerror.New("some.error", "some error", false).AddData("hello")
 
 	var customErr *customerror.Error
 
@@ -100,3 +107,58 @@ func TestAddDataDestroyData(t *testing.T) {
 		t.Errorf("data should be nil, want: nil, got: %v", customErr.Data)
 	}
 }
+
+func TestSentinelIsNotModified(t *testing.T) {
+	sentinel := customerror.New("some.error", "some error", false)
+
+	withData := sentinel.AddData("hello")
+	wrapped := sentinel.Wrap(errors.New("inner"))
+
+	var customErr *customerror.Error
+	if !errors.As(sentinel, &customErr) {
+		t.Fatalf("error does not match the target type, want: %T, got: %v", customErr, sentinel)
+	}
+	if customErr.Data != nil || customErr.Err != nil {
+		t.Errorf("sentinel should not be modified, got data: %v, err: %v", customErr.Data, customErr.Err)
+	}
+	for _, err := range []error{withData, wrapped, withData.DestroyData(), fmt.Errorf("ctx: %w", withData)} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("error should match its sentinel: %v", err)
+		}
+	}
+	if errors.Is(withData, customerror.New("some.error", "some error", false)) {
+		t.Error("error should not match another sentinel with the same code")
+	}
+	if !errors.Is(sentinel, withData) {
+		t.Error("sentinel should match an error derived from it")
+	}
+}
+
+func TestAddDataConcurrently(t *testing.T) {
+	sentinel := customerror.New("some.error", "some error", false)
+
+	const n = 100
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fmt.Errorf("%w", sentinel.AddData(strconv.Itoa(i)))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		var customErr *customerror.Error
+		if !errors.As(err, &customErr) {
+			t.Fatalf("error does not match the target type, want: %T, got: %v", customErr, err)
+		}
+		if data := customErr.Data; data != strconv.Itoa(i) {
+			t.Errorf("data of another occurrence leaked, want: %d, got: %v", i, data)
+		}
+		if !errors.Is(err, sentinel) {
+			t.Errorf("error should match its sentinel: %v", err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package events tells subscribers about the changes made to tasks as they
+// happen. Events are numbered in the order they are published, and the
+// latest ones are kept so that a subscriber coming back after a disconnect
+// can be sent the events it missed.
+package events
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// Types of events.
+const (
+	Created = "created"
+	Updated = "updated"
+	Deleted = "deleted"
+)
+
+// DefaultReplaySize is the number of events a Broker keeps for replay.
+const DefaultReplaySize = 1024
+
+// DefaultBufferSize is the number of events a subscriber may fall behind
+// before it is dropped.
+const DefaultBufferSize = 64
+
+// Event is a change made to a task. Task is the task after the change, or
+// before it for deletions.
+type Event struct {
+	ID   uint64           `json:"id"`
+	Type string           `json:"type"`
+	At   time.Time        `json:"at"`
+	Task dto.TaskResponse `json:"task"`
+}
+
+// Publisher is told about the changes made to tasks.
+type Publisher interface {
+	Publish(typ string, task dto.TaskResponse)
+}
+
+// Filter selects events by the task they are about, as carried by the
+// event: Statuses match the status of the task after the change, or before
+// it for deletions, so a task leaving a status is not matched by it. Empty
+// fields match every task.
+type Filter struct {
+	IDs      []uint
+	Statuses []string
+}
+
+// Match reports whether e is selected by f.
+func (f Filter) Match(e Event) bool {
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, e.Task.ID) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, e.Task.Status) {
+		return false
+	}
+	return true
+}
+
+// Broker hands published events to its subscribers. It is safe for
+// concurrent use.
+type Broker struct {
+	mu         sync.Mutex
+	last       uint64
+	replay     []Event
+	replaySize int
+	bufferSize int
+	subs       map[*Subscription]struct{}
+}
+
+type BrokerOption func(*Broker)
+
+// WithReplaySize sets the number of events kept for replay.
+func WithReplaySize(n int) BrokerOption {
+	return func(b *Broker) {
+		b.replaySize = n
+	}
+}
+
+// WithBufferSize sets the number of events a subscriber may fall behind
+// before it is dropped.
+func WithBufferSize(n int) BrokerOption {
+	return func(b *Broker) {
+		b.bufferSize = n
+	}
+}
+
+func NewBroker(opts ...BrokerOption) *Broker {
+	b := &Broker{
+		replaySize: DefaultReplaySize,
+		bufferSize: DefaultBufferSize,
+		subs:       make(map[*Subscription]struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Publish numbers an event of typ about task, keeps it for replay and
+// hands it to the subscribers it matches. Subscribers too far behind to
+// take it are dropped instead of holding up the publisher.
+func (b *Broker) Publish(typ string, task dto.TaskResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.last++
+	e := Event{ID: b.last, Type: typ, At: time.Now().UTC(), Task: task}
+	if b.replaySize > 0 {
+		if len(b.replay) == b.replaySize {
+			b.replay = append(b.replay[:0], b.replay[1:]...)
+		}
+		b.replay = append(b.replay, e)
+	}
+	for sub := range b.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			b.drop(sub)
+		}
+	}
+}
+
+// Subscribe returns a subscription to the events matching filter. With a
+// lastID other than 0 the subscription starts with the kept events after
+// it; when some of them are no longer kept, or lastID is not one this
+// broker published, Missed is set instead.
+func (b *Broker) Subscribe(filter Filter, lastID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan Event, b.bufferSize),
+		broker: b,
+	}
+	if lastID != 0 {
+		oldest := b.last + 1
+		if len(b.replay) > 0 {
+			oldest = b.replay[0].ID
+		}
+		if lastID > b.last || lastID+1 < oldest {
+			sub.Missed = true
+		} else {
+			for _, e := range b.replay {
+				if e.ID > lastID && filter.Match(e) {
+					sub.Replay = append(sub.Replay, e)
+				}
+			}
+		}
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *Broker) drop(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Subscription is a subscriber of a Broker. Replay holds the events it
+// missed, to be handled before the ones of Events.
+type Subscription struct {
+	Replay []Event
+	Missed bool
+
+	filter Filter
+	events chan Event
+	broker *Broker
+}
+
+// Events returns the channel of the events published since the
+// subscription was made. It is closed when the subscription is closed or
+// dropped for falling behind.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close ends the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.drop(s)
+}
+
+// Batch keeps the events published to it until they are flushed, so that
+// the events of a transaction are only told once it is committed.
+type Batch struct {
+	events []Event
+}
+
+// Publish keeps an event of typ about task.
+func (b *Batch) Publish(typ string, task dto.TaskResponse) {
+	b.events = append(b.events, Event{Type: typ, Task: task})
+}
+
+// Flush publishes the kept events to p, in the order they were kept.
+func (b *Batch) Flush(p Publisher) {
+	for _, e := range b.events {
+		p.Publish(e.Type, e.Task)
+	}
+	b.events = nil
+}
@@ -0,0 +1,125 @@
+package events_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestSubscribe(t *testing.T) {
+	broker := events.NewBroker()
+	sub := broker.Subscribe(events.Filter{Statuses: []string{"done"}}, 0)
+	defer sub.Close()
+
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 1, Status: "todo"})
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 2, Status: "done"})
+
+	e := <-sub.Events()
+	if e.ID != 2 || e.Type != events.Updated || e.Task.ID != 2 {
+		t.Errorf("wrong event, want the second one, got: %+v", e)
+	}
+	select {
+	case e := <-sub.Events():
+		t.Errorf("expected no more events, got: %+v", e)
+	default:
+	}
+}
+
+func TestSubscribeReplay(t *testing.T) {
+	broker := events.NewBroker(events.WithReplaySize(3))
+	for id := uint(1); id <= 5; id++ {
+		broker.Publish(events.Created, dto.TaskResponse{ID: id})
+	}
+
+	tests := []struct {
+		name   string
+		lastID uint64
+		filter events.Filter
+		replay []uint64
+		missed bool
+	}{
+		{"new", 0, events.Filter{}, nil, false},
+		{"up to date", 5, events.Filter{}, nil, false},
+		{"kept", 2, events.Filter{}, []uint64{3, 4, 5}, false},
+		{"kept filtered", 2, events.Filter{IDs: []uint{4}}, []uint64{4}, false},
+		{"no longer kept", 1, events.Filter{}, nil, true},
+		{"unknown", 9, events.Filter{}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := broker.Subscribe(tt.filter, tt.lastID)
+			defer sub.Close()
+
+			if sub.Missed != tt.missed {
+				t.Errorf("wrong missed, want: %v, got: %v", tt.missed, sub.Missed)
+			}
+			if len(sub.Replay) != len(tt.replay) {
+				t.Fatalf("wrong replay, want: %v, got: %+v", tt.replay, sub.Replay)
+			}
+			for i, id := range tt.replay {
+				if sub.Replay[i].ID != id {
+					t.Errorf("wrong replay, want: %v, got: %+v", tt.replay, sub.Replay)
+				}
+			}
+		})
+	}
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	broker := events.NewBroker(events.WithBufferSize(1))
+	sub := broker.Subscribe(events.Filter{}, 0)
+
+	broker.Publish(events.Created, dto.TaskResponse{ID: 1})
+	broker.Publish(events.Created, dto.TaskResponse{ID: 2})
+
+	if e, ok := <-sub.Events(); !ok || e.ID != 1 {
+		t.Errorf("expected the buffered event, got: %+v", e)
+	}
+	if _, ok := <-sub.Events(); ok {
+		t.Errorf("expected the subscription to be dropped")
+	}
+	sub.Close()
+}
+
+func TestPublishConcurrently(t *testing.T) {
+	broker := events.NewBroker()
+	sub := broker.Subscribe(events.Filter{}, 0)
+	defer sub.Close()
+
+	var wg sync.WaitGroup
+	for id := uint(1); id <= 50; id++ {
+		wg.Add(1)
+		go func(id uint) {
+			defer wg.Done()
+			broker.Publish(events.Updated, dto.TaskResponse{ID: id})
+		}(id)
+	}
+	wg.Wait()
+
+	for want := uint64(1); want <= 50; want++ {
+		if e := <-sub.Events(); e.ID != want {
+			t.Fatalf("events out of order, want: %v, got: %v", want, e.ID)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	broker := events.NewBroker()
+	sub := broker.Subscribe(events.Filter{}, 0)
+	defer sub.Close()
+
+	var batch events.Batch
+	batch.Publish(events.Created, dto.TaskResponse{ID: 1})
+	select {
+	case e := <-sub.Events():
+		t.Fatalf("expected no event before the flush, got: %+v", e)
+	default:
+	}
+	batch.Flush(broker)
+
+	if e := <-sub.Events(); e.ID != 1 || e.Task.ID != 1 {
+		t.Errorf("wrong event, got: %+v", e)
+	}
+}
@@ -0,0 +1,182 @@
+// Package idempotency keeps the responses of requests made with an
+// idempotency key, so that a client retrying a request it is not sure went
+// through gets the response of the first attempt instead of applying the
+// request twice.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a response is kept for replay.
+const DefaultTTL = 24 * time.Hour
+
+// DefaultWait is how long a request waits for another one made with the
+// same key to finish.
+const DefaultWait = 5 * time.Second
+
+var (
+	// ErrInProgress is returned when a request made with the same key is
+	// still being served.
+	ErrInProgress = errors.New("a request with this idempotency key is in progress")
+	// ErrMismatch is returned when a key is reused for a different
+	// request.
+	ErrMismatch = errors.New("idempotency key was used for a different request")
+)
+
+// Response is a response kept for replay.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+type entry struct {
+	fingerprint string
+	// done is closed once the request is served or abandoned.
+	done     chan struct{}
+	response *Response
+	expires  time.Time
+}
+
+// Store keeps the responses of requests by key. It is safe for concurrent
+// use.
+type Store struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	ttl       time.Duration
+	wait      time.Duration
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type StoreOption func(*Store)
+
+// WithTTL sets how long a response is kept for replay.
+func WithTTL(d time.Duration) StoreOption {
+	return func(s *Store) {
+		s.ttl = d
+	}
+}
+
+// WithWait sets how long a request waits for another one made with the
+// same key before ErrInProgress is returned.
+func WithWait(d time.Duration) StoreOption {
+	return func(s *Store) {
+		s.wait = d
+	}
+}
+
+// WithClock sets the clock telling when responses expire.
+func WithClock(now func() time.Time) StoreOption {
+	return func(s *Store) {
+		s.now = now
+	}
+}
+
+func NewStore(opts ...StoreOption) *Store {
+	s := &Store{
+		entries: make(map[string]*entry),
+		ttl:     DefaultTTL,
+		wait:    DefaultWait,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Call is a request claiming a key. It must be ended with Finish or Abort.
+type Call struct {
+	store *Store
+	key   string
+	entry *entry
+}
+
+// Begin claims key for a request whose method, target and body hash to
+// fingerprint. When the key was used before for the same request, its
+// response is returned instead of a Call; for another request ErrMismatch
+// is returned. When the request made with the key is still being served,
+// Begin waits for it to finish, at most until ctx is done or the wait of
+// the store elapses, and returns ErrInProgress if it has not.
+func (s *Store) Begin(ctx context.Context, key, fingerprint string) (*Response, *Call, error) {
+	var timeout <-chan time.Time
+	for {
+		s.mu.Lock()
+		now := s.now()
+		s.sweep(now)
+		e, ok := s.entries[key]
+		if ok && e.response != nil && !now.Before(e.expires) {
+			delete(s.entries, key)
+			ok = false
+		}
+		if !ok {
+			e = &entry{fingerprint: fingerprint, done: make(chan struct{}), expires: now.Add(s.ttl)}
+			s.entries[key] = e
+			s.mu.Unlock()
+			return nil, &Call{store: s, key: key, entry: e}, nil
+		}
+		s.mu.Unlock()
+		if e.fingerprint != fingerprint {
+			return nil, nil, ErrMismatch
+		}
+		select {
+		case <-e.done:
+		default:
+			if timeout == nil {
+				timer := time.NewTimer(s.wait)
+				defer timer.Stop()
+				timeout = timer.C
+			}
+			select {
+			case <-e.done:
+			case <-timeout:
+				return nil, nil, ErrInProgress
+			case <-ctx.Done():
+				return nil, nil, ErrInProgress
+			}
+		}
+		// An abandoned request leaves the key to the next one.
+		if e.response != nil {
+			return e.response, nil, nil
+		}
+	}
+}
+
+// Finish keeps res as the response of the call.
+func (c *Call) Finish(res Response) {
+	c.store.mu.Lock()
+	c.entry.response = &res
+	c.entry.expires = c.store.now().Add(c.store.ttl)
+	c.store.mu.Unlock()
+	close(c.entry.done)
+}
+
+// Abort releases the key of the call without keeping a response, so that
+// the request may be made again.
+func (c *Call) Abort() {
+	c.store.mu.Lock()
+	if c.store.entries[c.key] == c.entry {
+		delete(c.store.entries, c.key)
+	}
+	c.store.mu.Unlock()
+	close(c.entry.done)
+}
+
+// sweep drops the expired responses, at most once a minute.
+func (s *Store) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, e := range s.entries {
+		if e.response != nil && !now.Before(e.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
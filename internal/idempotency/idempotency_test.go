@@ -0,0 +1,133 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/idempotency"
+)
+
+func TestBeginReplay(t *testing.T) {
+	store := idempotency.NewStore()
+	ctx := context.Background()
+
+	res, call, err := store.Begin(ctx, "key", "a")
+	if err != nil || res != nil || call == nil {
+		t.Fatalf("expected the key to be claimed, got: %v %v %v", res, call, err)
+	}
+	call.Finish(idempotency.Response{Status: http.StatusCreated, Body: []byte("body")})
+
+	res, call, err = store.Begin(ctx, "key", "a")
+	if err != nil || call != nil {
+		t.Fatalf("expected a replay, got: %v %v", call, err)
+	}
+	if res.Status != http.StatusCreated || string(res.Body) != "body" {
+		t.Errorf("wrong response, got: %+v", res)
+	}
+
+	if _, _, err = store.Begin(ctx, "key", "b"); !errors.Is(err, idempotency.ErrMismatch) {
+		t.Errorf("expected ErrMismatch, got: %v", err)
+	}
+	if _, call, err = store.Begin(ctx, "other", "b"); err != nil || call == nil {
+		t.Errorf("expected another key to be claimed, got: %v %v", call, err)
+	}
+}
+
+func TestBeginInProgress(t *testing.T) {
+	store := idempotency.NewStore(idempotency.WithWait(20 * time.Millisecond))
+	ctx := context.Background()
+
+	_, call, _ := store.Begin(ctx, "key", "a")
+	if _, _, err := store.Begin(ctx, "key", "a"); !errors.Is(err, idempotency.ErrInProgress) {
+		t.Errorf("expected ErrInProgress, got: %v", err)
+	}
+	if _, _, err := store.Begin(ctx, "key", "b"); !errors.Is(err, idempotency.ErrMismatch) {
+		t.Errorf("expected ErrMismatch, got: %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	store = idempotency.NewStore(idempotency.WithWait(time.Minute))
+	_, call, _ = store.Begin(ctx, "key", "a")
+	if _, _, err := store.Begin(canceled, "key", "a"); !errors.Is(err, idempotency.ErrInProgress) {
+		t.Errorf("expected ErrInProgress once the context is done, got: %v", err)
+	}
+	call.Abort()
+}
+
+func TestBeginWaits(t *testing.T) {
+	store := idempotency.NewStore(idempotency.WithWait(time.Minute))
+	ctx := context.Background()
+
+	_, call, _ := store.Begin(ctx, "key", "a")
+	var (
+		wg  sync.WaitGroup
+		res *idempotency.Response
+		err error
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		res, _, err = store.Begin(ctx, "key", "a")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	call.Finish(idempotency.Response{Status: http.StatusOK})
+	wg.Wait()
+
+	if err != nil || res == nil || res.Status != http.StatusOK {
+		t.Errorf("expected the response of the first request, got: %v %v", res, err)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	store := idempotency.NewStore(idempotency.WithWait(time.Minute))
+	ctx := context.Background()
+
+	_, call, _ := store.Begin(ctx, "key", "a")
+	var (
+		wg   sync.WaitGroup
+		next *idempotency.Call
+		err  error
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, next, err = store.Begin(ctx, "key", "a")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	call.Abort()
+	wg.Wait()
+
+	if err != nil || next == nil {
+		t.Fatalf("expected the waiting request to claim the key, got: %v %v", next, err)
+	}
+	next.Abort()
+	if _, call, err = store.Begin(ctx, "key", "b"); err != nil || call == nil {
+		t.Errorf("expected an aborted key to be claimed again, got: %v %v", call, err)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := idempotency.NewStore(
+		idempotency.WithTTL(time.Hour),
+		idempotency.WithClock(func() time.Time { return now }),
+	)
+	ctx := context.Background()
+
+	_, call, _ := store.Begin(ctx, "key", "a")
+	call.Finish(idempotency.Response{Status: http.StatusOK})
+
+	now = now.Add(59 * time.Minute)
+	if res, _, _ := store.Begin(ctx, "key", "a"); res == nil {
+		t.Errorf("expected a replay before the TTL")
+	}
+	now = now.Add(time.Minute)
+	if res, call, err := store.Begin(ctx, "key", "b"); res != nil || call == nil || err != nil {
+		t.Errorf("expected an expired key to be claimed again, got: %v %v %v", res, call, err)
+	}
+}
@@ -0,0 +1,138 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// KeyHeader names the key of a request that may be retried safely, and
+// ReplayedHeader marks the responses replayed for such a retry.
+const (
+	KeyHeader      = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// MaxKeyLength is the length of the longest key accepted.
+const MaxKeyLength = 255
+
+// Middleware serves the requests of methods carrying an Idempotency-Key
+// once per key and caller: a retry gets the response of the first request,
+// a retry made while the first is served waits for it or gets 409, and a
+// key reused for another request gets 422. Responses with a 5xx status are
+// not kept, so that a request failing for a transient reason may be
+// retried, but for 504: the request timed out while its job may still be
+// done by a worker, so its retries get the 504 and the client has to make
+// sure of the outcome before it tries again with a new key. Bodies larger
+// than maxBody are rejected with 413.
+func Middleware(store *Store, maxBody int64, h http.HandlerFunc, methods ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(KeyHeader)
+		if key == "" || !slices.Contains(methods, r.Method) {
+			h(w, r)
+			return
+		}
+		if len(key) > MaxKeyLength {
+			problem(w, r, http.StatusBadRequest, "", fmt.Sprintf("%s must be at most %d characters", KeyHeader, MaxKeyLength))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				problem(w, r, http.StatusRequestEntityTooLarge, "", "request body too large")
+				return
+			}
+			problem(w, r, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		fmt.Fprintf(hash, "%s %s\n%s\n", r.Method, r.URL.RequestURI(), r.Header.Get("Content-Type"))
+		hash.Write(body)
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+		res, call, err := store.Begin(r.Context(), util.ActorFromContext(r.Context())+"\x00"+key, fingerprint)
+		switch {
+		case errors.Is(err, ErrInProgress):
+			problem(w, r, http.StatusConflict, basehttphandler.CodeIdempotencyConflict, err.Error())
+			return
+		case errors.Is(err, ErrMismatch):
+			problem(w, r, http.StatusUnprocessableEntity, basehttphandler.CodeIdempotencyMismatch, err.Error())
+			return
+		case res != nil:
+			for name, values := range res.Header {
+				w.Header()[name] = values
+			}
+			// The response tells the ID of the retry, not the one of the
+			// request it replays.
+			if id := util.RequestIDFromContext(r.Context()); id != "" {
+				w.Header().Set(util.RequestIDHeader, id)
+			}
+			w.Header().Set(ReplayedHeader, "true")
+			w.WriteHeader(res.Status)
+			_, _ = w.Write(res.Body)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			if p := recover(); p != nil {
+				call.Abort()
+				panic(p)
+			}
+			if rec.status >= http.StatusInternalServerError && rec.status != http.StatusGatewayTimeout {
+				call.Abort()
+				return
+			}
+			if !rec.wroteHeader {
+				rec.header = rec.Header().Clone()
+			}
+			call.Finish(Response{Status: rec.status, Header: rec.header, Body: rec.body.Bytes()})
+		}()
+		h(rec, r)
+	}
+}
+
+func problem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	basehttphandler.WriteProblem(w, basehttphandler.NewProblem(r, status, code, detail))
+}
+
+// responseRecorder copies the response written through it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	header      http.Header
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.wroteHeader = true
+		rec.status = status
+		rec.header = rec.Header().Clone()
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
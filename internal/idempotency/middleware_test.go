@@ -0,0 +1,116 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/idempotency"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+)
+
+// serve sends a POST with body and key to h and returns the response.
+func serve(h http.HandlerFunc, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(idempotency.KeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	h(w, req)
+	return w
+}
+
+func TestMiddlewareReplay(t *testing.T) {
+	calls := 0
+	h := idempotency.Middleware(idempotency.NewStore(), 1<<20, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Location", "/tasks/1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}, http.MethodPost)
+
+	first := serve(h, "key", `{"id":1}`)
+	retry := serve(h, "key", `{"id":1}`)
+
+	if calls != 1 {
+		t.Errorf("want the request served once, got %v", calls)
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() || retry.Header().Get("Location") != "/tasks/1" {
+		t.Errorf("wrong replay, got %v %v %v", retry.Code, retry.Header(), retry.Body)
+	}
+	if retry.Header().Get(idempotency.ReplayedHeader) != "true" || first.Header().Get(idempotency.ReplayedHeader) != "" {
+		t.Errorf("want only the retry marked as replayed")
+	}
+
+	if w := serve(h, "key", `{"id":2}`); w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), basehttphandler.CodeIdempotencyMismatch) {
+		t.Errorf("want a reused key rejected, got %v %v", w.Code, w.Body)
+	}
+	serve(h, "", `{"id":1}`)
+	if calls != 2 {
+		t.Errorf("want requests without a key served, got %v calls", calls)
+	}
+}
+
+func TestMiddlewareRetryAfterStorageError(t *testing.T) {
+	handler := &basehttphandler.Handler{}
+	calls := 0
+	h := idempotency.Middleware(idempotency.NewStore(), 1<<20, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			handler.Error(w, r, "set", customerror.ErrSet.AddData("'1' could not be set."))
+			return
+		}
+		handler.JSON(w, http.StatusOK, map[string]uint{"id": 1})
+	}, http.MethodPost)
+
+	if w := serve(h, "key", `{"id":1}`); w.Code != http.StatusInternalServerError {
+		t.Fatalf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
+	}
+	w := serve(h, "key", `{"id":1}`)
+	if calls != 2 || w.Code != http.StatusOK || w.Header().Get(idempotency.ReplayedHeader) != "" {
+		t.Errorf("want the retry served again, got %v calls, %v %v", calls, w.Code, w.Header())
+	}
+	if w := serve(h, "key", `{"id":1}`); calls != 2 || w.Code != http.StatusOK || w.Header().Get(idempotency.ReplayedHeader) != "true" {
+		t.Errorf("want the success replayed, got %v calls, %v", calls, w.Code)
+	}
+}
+
+func TestMiddlewareRetryAfterTimeout(t *testing.T) {
+	handler := &basehttphandler.Handler{}
+	calls := 0
+	h := idempotency.Middleware(idempotency.NewStore(), 1<<20, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			handler.Error(w, r, "set", context.DeadlineExceeded)
+			return
+		}
+		handler.JSON(w, http.StatusOK, map[string]uint{"id": 1})
+	}, http.MethodPost)
+
+	if w := serve(h, "key", `{"id":1}`); w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("wrong status code, want %v got %v", http.StatusGatewayTimeout, w.Code)
+	}
+	// The job of the first request may still be done: the retry must not
+	// run it again.
+	w := serve(h, "key", `{"id":1}`)
+	if calls != 1 || w.Code != http.StatusGatewayTimeout || w.Header().Get(idempotency.ReplayedHeader) != "true" {
+		t.Errorf("want the timeout replayed, got %v calls, %v %v", calls, w.Code, w.Header())
+	}
+	if w := serve(h, "other", `{"id":1}`); calls != 2 || w.Code != http.StatusOK {
+		t.Errorf("want a new key served, got %v calls, %v", calls, w.Code)
+	}
+}
+
+func TestMiddlewareKeyTooLong(t *testing.T) {
+	h := idempotency.Middleware(idempotency.NewStore(), 1<<20, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler called")
+	}, http.MethodPost)
+
+	if w := serve(h, strings.Repeat("k", idempotency.MaxKeyLength+1), `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
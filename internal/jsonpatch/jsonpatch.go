@@ -0,0 +1,345 @@
+// Package jsonpatch applies JSON Merge Patches (RFC 7396) and JSON Patches
+// (RFC 6902) to JSON documents.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalid is returned for patches that are malformed or do not fit
+	// the document, such as a path that does not exist.
+	ErrInvalid = errors.New("invalid patch")
+	// ErrTestFailed is returned when a test operation does not hold.
+	ErrTestFailed = errors.New("test failed")
+)
+
+// MergePatch applies the merge patch to doc. Members of the patch replace
+// the members of doc, objects are merged recursively and null removes a
+// member.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalid, err.Error())
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// operation is one operation of a JSON Patch. Value is nil when the
+// operation has no value member, and the JSON null literal when it is null.
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply applies the operations of the JSON Patch to doc in order. The
+// patch is applied as a whole or not at all.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var ops []operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: must be an array of operations", ErrInvalid)
+	}
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		if target, err = apply(target, op); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func apply(doc any, op operation) (any, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("%w: %s requires a path", ErrInvalid, op.Op)
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s requires a value", ErrInvalid, op.Op)
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalid, err.Error())
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			if doc, _, err = remove(doc, path); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(current, value) {
+			return nil, fmt.Errorf("%w: %s is not %s", ErrTestFailed, *op.Path, string(op.Value))
+		}
+		return doc, nil
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		if op.From == nil {
+			return nil, fmt.Errorf("%w: %s requires from", ErrInvalid, op.Op)
+		}
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			value, err := get(doc, from)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, path, deepCopy(value))
+		}
+		if strings.HasPrefix(*op.Path, *op.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move %s into itself", ErrInvalid, *op.From)
+		}
+		doc, value, err := remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	}
+	return nil, fmt.Errorf("%w: unknown operation '%s'", ErrInvalid, op.Op)
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path '%s' must start with /", ErrInvalid, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch c := doc.(type) {
+		case map[string]any:
+			value, ok := c[token]
+			if !ok {
+				return nil, missing(token)
+			}
+			doc = value
+		case []any:
+			i, err := index(token, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = c[i]
+		default:
+			return nil, missing(token)
+		}
+	}
+	return doc, nil
+}
+
+// add sets the member named by path, or inserts into the array holding it,
+// and returns the document. Its parent has to exist.
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token := path[0]
+	switch c := doc.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			c[token] = value
+			return c, nil
+		}
+		child, ok := c[token]
+		if !ok {
+			return nil, missing(token)
+		}
+		child, err := add(child, path[1:], value)
+		c[token] = child
+		return c, err
+	case []any:
+		if len(path) == 1 {
+			i := len(c)
+			if token != "-" {
+				var err error
+				if i, err = index(token, len(c)); err != nil {
+					return nil, err
+				}
+			}
+			c = append(c, nil)
+			copy(c[i+1:], c[i:])
+			c[i] = value
+			return c, nil
+		}
+		i, err := index(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		child, err := add(c[i], path[1:], value)
+		c[i] = child
+		return c, err
+	}
+	return nil, missing(token)
+}
+
+// remove removes the member named by path and returns the document and the
+// removed value.
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	token := path[0]
+	switch c := doc.(type) {
+	case map[string]any:
+		child, ok := c[token]
+		if !ok {
+			return nil, nil, missing(token)
+		}
+		if len(path) == 1 {
+			delete(c, token)
+			return c, child, nil
+		}
+		child, removed, err := remove(child, path[1:])
+		c[token] = child
+		return c, removed, err
+	case []any:
+		i, err := index(token, len(c)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(path) == 1 {
+			removed := c[i]
+			return append(c[:i], c[i+1:]...), removed, nil
+		}
+		child, removed, err := remove(c[i], path[1:])
+		c[i] = child
+		return c, removed, err
+	}
+	return nil, nil, missing(token)
+}
+
+// index parses an array index token that may be at most max.
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: index '%s' is out of range", ErrInvalid, token)
+	}
+	return i, nil
+}
+
+func missing(token string) error {
+	return fmt.Errorf("%w: '%s' does not exist", ErrInvalid, token)
+}
+
+func decode(data []byte) (any, error) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("trailing data after JSON value")
+	}
+	return v, nil
+}
+
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	}
+	return a == b
+}
+
+func deepCopy(v any) any {
+	switch c := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(c))
+		for key, value := range c {
+			m[key] = deepCopy(value)
+		}
+		return m
+	case []any:
+		s := make([]any, len(c))
+		for i, value := range c {
+			s[i] = deepCopy(value)
+		}
+		return s
+	}
+	return v
+}
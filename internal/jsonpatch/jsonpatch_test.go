@@ -0,0 +1,129 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/jsonpatch"
+)
+
+// assertJSON fails when got and want are not the same JSON value.
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid result %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid expectation %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("expected: %s, got: %s", want, got)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"replaces a member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"adds a member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null removes a member", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"arrays are replaced", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"objects are merged", `{"a":{"b":"c","d":"e"}}`, `{"a":{"d":null,"f":1}}`, `{"a":{"b":"c","f":1}}`},
+		{"a non-object patch replaces the document", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"large numbers are kept", `{"a":1}`, `{"a":12345678901234567890}`, `{"a":12345678901234567890}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("expected error: %v, got: %v", nil, err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestMergePatchInvalid(t *testing.T) {
+	if _, err := MergePatch([]byte(`{}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected error: %v, got: %v", ErrInvalid, err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"adds a member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+		{"inserts into an array", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"appends to an array", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{"removes a member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"removes from an array", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replaces a member", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"replaces with null", `{"baz":"qux"}`, `[{"op":"replace","path":"/baz","value":null}]`, `{"baz":null}`},
+		{"moves a member", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"moves an array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"copies a member", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"add","path":"/baz/qux","value":2}]`, `{"foo":{"bar":1},"baz":{"bar":1,"qux":2}}`},
+		{"passes a test", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"unescapes paths", `{"a/b":1,"m~n":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3}`},
+		{"replaces the document", `{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("expected error: %v, got: %v", nil, err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  error
+	}{
+		{"not an array", `{"op":"add","path":"/a","value":1}`, ErrInvalid},
+		{"unknown operation", `[{"op":"merge","path":"/a","value":1}]`, ErrInvalid},
+		{"missing path", `[{"op":"add","value":1}]`, ErrInvalid},
+		{"missing value", `[{"op":"add","path":"/a"}]`, ErrInvalid},
+		{"missing parent", `[{"op":"add","path":"/missing/a","value":1}]`, ErrInvalid},
+		{"replace of a missing member", `[{"op":"replace","path":"/missing","value":1}]`, ErrInvalid},
+		{"remove of a missing member", `[{"op":"remove","path":"/missing"}]`, ErrInvalid},
+		{"index out of range", `[{"op":"add","path":"/list/5","value":1}]`, ErrInvalid},
+		{"leading zero index", `[{"op":"remove","path":"/list/01"}]`, ErrInvalid},
+		{"relative path", `[{"op":"remove","path":"a"}]`, ErrInvalid},
+		{"move into itself", `[{"op":"move","from":"/obj","path":"/obj/child"}]`, ErrInvalid},
+		{"failed test", `[{"op":"test","path":"/a","value":"other"}]`, ErrTestFailed},
+	}
+	doc := []byte(`{"a":"b","list":[1,2],"obj":{"c":1}}`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Apply(doc, []byte(tt.patch)); !errors.Is(err, tt.want) {
+				t.Errorf("expected error: %v, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestApplyIsAllOrNothing(t *testing.T) {
+	doc := []byte(`{"a":"b"}`)
+	patch := []byte(`[{"op":"replace","path":"/a","value":"c"},{"op":"test","path":"/a","value":"b"}]`)
+	if got, err := Apply(doc, patch); !errors.Is(err, ErrTestFailed) || got != nil {
+		t.Errorf("expected error: %v and no document, got: %v, %s", ErrTestFailed, err, got)
+	}
+	if string(doc) != `{"a":"b"}` {
+		t.Errorf("expected the document to be left alone, got: %s", doc)
+	}
+}
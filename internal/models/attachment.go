@@ -0,0 +1,26 @@
+package models
+
+import (
+	"io"
+	"time"
+)
+
+// Attachment describes a file attached to a task. Its contents are kept in
+// the blob store under Key; like comments, attachments are hidden while
+// their task is in the trash and removed with it when it is purged.
+type Attachment struct {
+	ID          uint      `json:"id"`
+	TaskID      uint      `json:"task_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Key         string    `json:"-"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Upload is the file being attached by an ATTACH job.
+type Upload struct {
+	Name    string
+	Content io.Reader
+}
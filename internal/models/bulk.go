@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// BulkOperation is one set, update or delete of a bulk request. Op names
+// the operation and the other fields carry its request.
+type BulkOperation struct {
+	Op          string     `json:"op"`
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+	Assignee    string     `json:"assignee"`
+	Tags        []string   `json:"tags"`
+	ParentID    uint       `json:"parent_id"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
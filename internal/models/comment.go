@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Comment is a note left on a task by Author, the subject of the caller's
+// token. Comments are hidden while their task is in the trash and removed
+// with it when it is purged.
+type Comment struct {
+	ID        uint      `json:"id"`
+	TaskID    uint      `json:"task_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CommentFilter selects a page of the comments of a task, oldest first.
+type CommentFilter struct {
+	TaskID uint `json:"task_id"`
+	// AfterID skips the comments up to and including it.
+	AfterID uint `json:"after_id"`
+	// Limit caps the number of comments returned, 0 means no limit.
+	Limit int `json:"limit"`
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// TaskCursor marks the last task of a page. The next page starts right
+// after it in the order the page was listed in, so pages stay stable while
+// new tasks are inserted.
+type TaskCursor struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v,omitempty"`
+	ID     uint   `json:"id"`
+}
+
+// NewTaskCursor returns the cursor pointing right after task in a list
+// sorted by sortBy in the given order.
+func NewTaskCursor(task Task, sortBy, order string) TaskCursor {
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	if order == "" {
+		order = "asc"
+	}
+	c := TaskCursor{
+		SortBy: sortBy,
+		Order:  order,
+		ID:     task.ID,
+	}
+	switch sortBy {
+	case "created_at":
+		c.Value = task.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case "updated_at":
+		c.Value = task.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case "deleted_at":
+		if task.DeletedAt != nil {
+			c.Value = task.DeletedAt.UTC().Format(time.RFC3339Nano)
+		}
+	case "due_at":
+		c.Value = NoDueAt.Format(time.RFC3339Nano)
+		if task.DueAt != nil {
+			c.Value = task.DueAt.UTC().Format(time.RFC3339Nano)
+		}
+	case "priority":
+		c.Value = strconv.Itoa(task.Priority)
+	case "created_by":
+		c.Value = task.CreatedBy
+	case "updated_by":
+		c.Value = task.UpdatedBy
+	}
+	return c
+}
+
+// NoDueAt stands in for the due date of tasks without one when sorting by
+// due date, so they are listed after all tasks that have one.
+var NoDueAt = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+// searchSortKey marks cursors of relevance ranked search results, which
+// page by offset since scores shift as tasks change.
+const searchSortKey = "relevance"
+
+// NewSearchCursor returns the cursor of the search results starting at
+// offset.
+func NewSearchCursor(offset int) TaskCursor {
+	return TaskCursor{
+		SortBy: searchSortKey,
+		Order:  "desc",
+		Value:  strconv.Itoa(offset),
+	}
+}
+
+// SearchOffset returns the offset of a cursor made by NewSearchCursor.
+func (c TaskCursor) SearchOffset() (int, bool) {
+	if c.SortBy != searchSortKey {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(c.Value)
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
+
+// commentSortKey marks cursors of comment listings, which page by comment
+// ID.
+const commentSortKey = "comment"
+
+// NewCommentCursor returns the cursor pointing right after the comment.
+func NewCommentCursor(comment Comment) TaskCursor {
+	return TaskCursor{
+		SortBy: commentSortKey,
+		Order:  "asc",
+		ID:     comment.ID,
+	}
+}
+
+// CommentAfter returns the comment ID of a cursor made by NewCommentCursor.
+func (c TaskCursor) CommentAfter() (uint, bool) {
+	if c.SortBy != commentSortKey {
+		return 0, false
+	}
+	return c.ID, true
+}
+
+// Encode returns the opaque form of c handed out to clients.
+func (c TaskCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeTaskCursor parses a cursor produced by TaskCursor.Encode.
+func DecodeTaskCursor(s string) (TaskCursor, error) {
+	var c TaskCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return TaskCursor{}, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return TaskCursor{}, err
+	}
+	return c, nil
+}
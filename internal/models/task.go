@@ -1,12 +1,74 @@
 package models
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
+// Priorities range from MinPriority to MaxPriority, the most urgent. A zero
+// priority means none was given.
+const (
+	MinPriority = 1
+	MaxPriority = 5
+)
+
+// MaxTags is the number of tags a task may carry.
+const MaxTags = 20
+
+// Task is a unit of work. ParentID is the task it is a subtask of, 0 for
+// top level tasks.
 type Task struct {
-	ID          uint   `json:"id"`
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Assignee    string     `json:"assignee,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	ParentID    uint       `json:"parent_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CreatedBy   string     `json:"created_by"`
+	UpdatedBy   string     `json:"updated_by"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy   string     `json:"deleted_by,omitempty"`
+}
+
+// TaskFilter narrows and orders the tasks returned by a list query.
+// Zero values are ignored.
+type TaskFilter struct {
+	// Statuses matches tasks having any of the given statuses.
+	Statuses []string `json:"statuses"`
+	// Title and Description match tasks containing them as substrings.
 	Title       string `json:"title"`
 	Description string `json:"description"`
-	Status      string `json:"status"`
+	// IDFrom and IDTo bound the task IDs, both inclusive.
+	IDFrom uint `json:"id_from"`
+	IDTo   uint `json:"id_to"`
+	// Priorities matches tasks having any of the given priorities.
+	Priorities []int     `json:"priorities"`
+	Assignee   string    `json:"assignee"`
+	DueAfter   time.Time `json:"due_after"`
+	DueBefore  time.Time `json:"due_before"`
+	// Tags matches tasks having any of the given tags, or all of them when
+	// TagMode is TagModeAll.
+	Tags          []string  `json:"tags"`
+	TagMode       string    `json:"tag_mode"`
+	CreatedBy     string    `json:"created_by"`
+	UpdatedBy     string    `json:"updated_by"`
+	CreatedAfter  time.Time `json:"created_after"`
+	CreatedBefore time.Time `json:"created_before"`
+	UpdatedAfter  time.Time `json:"updated_after"`
+	UpdatedBefore time.Time `json:"updated_before"`
+	SortBy        string    `json:"sort"`
+	SortOrder     string    `json:"order"`
+	// Deleted selects tasks in the trash instead of live ones.
+	Deleted bool `json:"deleted"`
+	// Limit caps the number of tasks returned, 0 means no limit.
+	Limit int `json:"limit"`
+	// Cursor is an encoded TaskCursor; only tasks after it are returned.
+	Cursor string `json:"cursor"`
 }
 
 type TaskJobModel struct {
@@ -14,6 +76,88 @@ type TaskJobModel struct {
 	Title       string          `json:"title"`
 	Description string          `json:"description"`
 	Status      string          `json:"status"`
+	Priority    int             `json:"priority"`
+	DueAt       *time.Time      `json:"due_at"`
+	Assignee    string          `json:"assignee"`
+	Tags        []string        `json:"tags"`
+	ParentID    uint            `json:"parent_id"`
+	BlockerID   uint            `json:"blocker_id"`
+	CommentID   uint            `json:"comment_id"`
+	Body        string          `json:"body"`
+	Upload      *Upload         `json:"-"`
+	Filter      TaskFilter      `json:"filter"`
+	At          time.Time       `json:"at"`
+	Query       string          `json:"query"`
+	Mode        string          `json:"mode"`
+	Operations  []BulkOperation `json:"operations"`
+	Emit        func(any) error `json:"-"`
+	PatchType   string          `json:"patch_type"`
+	Patch       []byte          `json:"patch"`
+	URL         string          `json:"url"`
+	EventTypes  []string        `json:"event_types"`
+	Secret      string          `json:"-"`
 	JOB         string          `json:"-"`
 	Context     context.Context `json:"-"`
 }
+
+const (
+	TaskEventCreated  = "created"
+	TaskEventUpdated  = "updated"
+	TaskEventDeleted  = "deleted"
+	TaskEventRestored = "restored"
+	TaskEventPurged   = "purged"
+)
+
+// TaskEvent is one entry of a task's append-only change history. OldValue
+// is nil for creations and NewValue is nil for purges.
+type TaskEvent struct {
+	ID        uint64    `json:"id"`
+	TaskID    uint      `json:"task_id"`
+	Type      string    `json:"type"`
+	OldValue  *Task     `json:"old_value"`
+	NewValue  *Task     `json:"new_value"`
+	Actor     string    `json:"actor"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TaskSearch is a full-text query over the titles and descriptions of live
+// tasks. Offset and Limit select the page of hits, ranked by relevance.
+type TaskSearch struct {
+	Query  string `json:"query"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+// TaskSearchHit is a task matching a TaskSearch and its relevance score.
+type TaskSearchHit struct {
+	Task  Task    `json:"task"`
+	Score float64 `json:"score"`
+}
+
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
+// TagCount is a tag and the number of live tasks carrying it.
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// TaskDependency links a task to a task blocking it. A task cannot be
+// finished while any of its blockers is open.
+type TaskDependency struct {
+	TaskID    uint `json:"task_id"`
+	BlockerID uint `json:"blocker_id"`
+}
+
+// TaskGraph is the neighbourhood of the Root task. Tasks holds the root,
+// its parent and subtasks, and every task it transitively blocks or is
+// blocked by. Dependencies are the links between them.
+type TaskGraph struct {
+	Root         Task             `json:"root"`
+	Tasks        []Task           `json:"tasks"`
+	Dependencies []TaskDependency `json:"dependencies"`
+}
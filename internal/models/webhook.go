@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Webhook is an endpoint told about the changes of tasks. Events are the
+// types of events it is sent, every type when empty. Failures counts the
+// delivery attempts that failed in a row; an endpoint failing too often is
+// disabled until it is enabled again.
+type Webhook struct {
+	ID         uint       `json:"id"`
+	URL        string     `json:"url"`
+	Events     []string   `json:"events"`
+	Secret     string     `json:"-"`
+	Enabled    bool       `json:"enabled"`
+	Failures   int        `json:"failures"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+// WebhookDelivery is one attempt at sending an event to a webhook. Payload
+// is the body that was sent, signed with the secret of the webhook.
+type WebhookDelivery struct {
+	ID         uint      `json:"id"`
+	WebhookID  uint      `json:"webhook_id"`
+	EventID    uint64    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	TaskID     uint      `json:"task_id"`
+	Payload    []byte    `json:"payload"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error"`
+	Success    bool      `json:"success"`
+	Duration   int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package taskstorage
+
+import "context"
+
+// Atomic calls fn with a storage whose operations all run in one
+// transaction. The transaction is committed when fn returns nil and rolled
+// back otherwise, so either every change made through it is kept or none is.
+func (s *taskStorage) Atomic(ctx context.Context, fn func(TaskStorer) error) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(&taskStorage{db: s.db, tx: tx}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,100 @@
+package taskstorage_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+func Test_taskStorage_Atomic(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+	task := models.Task{ID: 1, Title: "title", Description: "description", Status: "status"}
+	now := time.Now()
+
+	t.Run("Every operation runs in one transaction that is committed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tasks").WithArgs(anyArgs(11)...).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").WithArgs(anyArgs(7)...).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(taskRows(1, nil))
+		mock.ExpectExec("UPDATE tasks SET deleted_at").WithArgs(anyArgs(3)...).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO task_events").WithArgs(anyArgs(7)...).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		err := mockStorage.Atomic(context.Background(), func(tx TaskStorer) error {
+			if err := tx.Set(context.Background(), task); err != nil {
+				return err
+			}
+			deleted := task
+			deleted.DeletedAt = &now
+			return tx.Delete(context.Background(), deleted)
+		})
+		if err != nil {
+			t.Errorf("taskStorage.Atomic() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+	t.Run("Lock reads the task for update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+			WithArgs(1).
+			WillReturnRows(taskRows(1, nil))
+		mock.ExpectCommit()
+
+		err := mockStorage.Atomic(context.Background(), func(tx TaskStorer) error {
+			_, err := tx.Lock(context.Background(), 1)
+			return err
+		})
+		if err != nil {
+			t.Errorf("taskStorage.Lock() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+	t.Run("An error rolls back every operation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO tasks").WithArgs(anyArgs(11)...).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").WithArgs(anyArgs(7)...).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+			WithArgs(2).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		err := mockStorage.Atomic(context.Background(), func(tx TaskStorer) error {
+			if err := tx.Set(context.Background(), task); err != nil {
+				return err
+			}
+			return tx.Delete(context.Background(), models.Task{ID: 2, DeletedAt: &now})
+		})
+		if err == nil {
+			t.Errorf("taskStorage.Atomic() expected an error")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+// anyArgs matches n arguments of any value.
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	return args
+}
@@ -0,0 +1,110 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+const attachmentColumns = "task_attachments.id, task_attachments.task_id, task_attachments.name, task_attachments.content_type, " +
+	"task_attachments.size, task_attachments.blob_key, task_attachments.created_by, task_attachments.created_at"
+
+func scanAttachment(row scanner) (Attachment, error) {
+	var a Attachment
+	err := row.Scan(&a.ID, &a.TaskID, &a.Name, &a.ContentType, &a.Size, &a.Key, &a.CreatedBy, &a.CreatedAt)
+	return a, err
+}
+
+// AddAttachment stores the metadata of a file attached to a live task and
+// returns it with its ID.
+func (s *taskStorage) AddAttachment(ctx context.Context, a Attachment) (Attachment, error) {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		if _, err := lockTask(ctx, tx, a.TaskID, false); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "INSERT INTO task_attachments (task_id, name, content_type, size, blob_key, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			a.TaskID, a.Name, a.ContentType, a.Size, a.Key, a.CreatedBy, a.CreatedAt)
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		a.ID = uint(id)
+		return nil
+	})
+	_id := strconv.Itoa(int(a.TaskID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Attachment{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return Attachment{}, fmt.Errorf("%w", customerror.ErrAttachment.AddData("file could not be attached to '"+_id+"'."))
+	}
+	return a, nil
+}
+
+// GetAttachment returns an attachment of a live task.
+func (s *taskStorage) GetAttachment(ctx context.Context, id uint) (Attachment, error) {
+	row := s.conn().QueryRowContext(ctx, "SELECT "+attachmentColumns+" FROM task_attachments JOIN tasks ON tasks.id = task_attachments.task_id "+
+		"WHERE task_attachments.id = ? AND tasks.deleted_at IS NULL", id)
+	a, err := scanAttachment(row)
+	_id := strconv.Itoa(int(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Attachment{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("attachment '"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return Attachment{}, fmt.Errorf("%w", customerror.ErrAttachment.AddData("attachment '"+_id+"' could not be read."))
+	}
+	return a, nil
+}
+
+// ListAttachments returns the attachments of a live task, oldest first.
+func (s *taskStorage) ListAttachments(ctx context.Context, taskID uint) ([]Attachment, error) {
+	_id := strconv.Itoa(int(taskID))
+	err := liveTaskExists(ctx, s.conn(), taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrAttachment.AddData("attachments of '"+_id+"' could not be listed."))
+	}
+	rows, err := s.conn().QueryContext(ctx, "SELECT "+attachmentColumns+" FROM task_attachments WHERE task_id = ? ORDER BY id ASC", taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrAttachment.AddData("attachments of '"+_id+"' could not be listed."))
+	}
+	defer rows.Close()
+	attachments := make([]Attachment, 0)
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrAttachment.AddData("attachments of '"+_id+"' could not be listed."))
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, nil
+}
+
+// DeleteAttachment removes the metadata of an attachment of a live task.
+// Its blob is left to the caller.
+func (s *taskStorage) DeleteAttachment(ctx context.Context, id uint) error {
+	_id := strconv.Itoa(int(id))
+	res, err := s.conn().ExecContext(ctx, "DELETE task_attachments FROM task_attachments JOIN tasks ON tasks.id = task_attachments.task_id "+
+		"WHERE task_attachments.id = ? AND tasks.deleted_at IS NULL", id)
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrAttachment.AddData("attachment '"+_id+"' could not be deleted."))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrAttachment.AddData("attachment '"+_id+"' could not be deleted."))
+	}
+	if n == 0 {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("attachment '"+_id+"' does not exist in the database."))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+var attachmentColumns = []string{"id", "task_id", "name", "content_type", "size", "blob_key", "created_by", "created_at"}
+
+func Test_taskStorage_AddAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	a := models.Attachment{TaskID: 1, Name: "log.txt", ContentType: "text/plain", Size: 5, Key: "tasks/1/ab", CreatedBy: "alice", CreatedAt: now}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, nil))
+	mock.ExpectExec("INSERT INTO task_attachments \\(task_id, name, content_type, size, blob_key, created_by, created_at\\) VALUES \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?\\)").
+		WithArgs(1, "log.txt", "text/plain", 5, "tasks/1/ab", "alice", now).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(taskColumns))
+	mock.ExpectRollback()
+
+	got, err := mockStorage.AddAttachment(context.Background(), a)
+	if err != nil {
+		t.Fatalf("taskStorage.AddAttachment() error = %v", err)
+	}
+	if got.ID != 3 {
+		t.Errorf("taskStorage.AddAttachment() id = %v, want %v", got.ID, 3)
+	}
+	a.TaskID = 2
+	if _, err := mockStorage.AddAttachment(context.Background(), a); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.AddAttachment() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_GetAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	want := models.Attachment{ID: 3, TaskID: 1, Name: "log.txt", ContentType: "text/plain", Size: 5, Key: "tasks/1/ab", CreatedBy: "alice", CreatedAt: now}
+	mock.ExpectQuery("SELECT (.+) FROM task_attachments JOIN tasks ON tasks.id = task_attachments.task_id WHERE task_attachments.id = \\? AND tasks.deleted_at IS NULL").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(attachmentColumns).AddRow(3, 1, "log.txt", "text/plain", 5, "tasks/1/ab", "alice", now))
+	mock.ExpectQuery("SELECT (.+) FROM task_attachments JOIN tasks").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(attachmentColumns))
+
+	got, err := mockStorage.GetAttachment(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("taskStorage.GetAttachment() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("taskStorage.GetAttachment() = %v, want %v", got, want)
+	}
+	if _, err := mockStorage.GetAttachment(context.Background(), 4); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.GetAttachment() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+}
+
+func Test_taskStorage_ListAttachments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM task_attachments WHERE task_id = \\? ORDER BY id ASC").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(attachmentColumns).
+			AddRow(3, 1, "log.txt", "text/plain", 5, "tasks/1/ab", "alice", now).
+			AddRow(4, 1, "shot.png", "image/png", 9, "tasks/1/cd", "bob", now))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	attachments, err := mockStorage.ListAttachments(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("taskStorage.ListAttachments() error = %v", err)
+	}
+	if len(attachments) != 2 || attachments[1].Name != "shot.png" {
+		t.Errorf("taskStorage.ListAttachments() = %v, want attachments 3 and 4", attachments)
+	}
+	if _, err := mockStorage.ListAttachments(context.Background(), 2); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.ListAttachments() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+}
+
+func Test_taskStorage_DeleteAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	mock.ExpectExec("DELETE task_attachments FROM task_attachments JOIN tasks ON tasks.id = task_attachments.task_id " +
+		"WHERE task_attachments.id = \\? AND tasks.deleted_at IS NULL").
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE task_attachments").
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := mockStorage.DeleteAttachment(context.Background(), 3); err != nil {
+		t.Errorf("taskStorage.DeleteAttachment() error = %v, wantErr %v", err, nil)
+	}
+	if err := mockStorage.DeleteAttachment(context.Background(), 4); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.DeleteAttachment() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+}
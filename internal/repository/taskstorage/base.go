@@ -1,21 +1,75 @@
 package taskstorage
 
 import (
+	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 )
 
+// taskColumns selects a task row of the tasks table, its tags joined by
+// commas as the last column. Tag names never contain commas. GROUP_CONCAT
+// cuts its result at group_concat_max_len bytes, 1024 by default, shorter
+// than MaxTags tags of 64 characters: connections must raise it, as the
+// DSN of pkg/mysql does.
+const taskColumns = "id, title, description, status, priority, due_at, assignee, parent_id, created_at, updated_at, created_by, updated_by, deleted_at, deleted_by, " +
+	"(SELECT GROUP_CONCAT(tags.name ORDER BY tags.name SEPARATOR ',') FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id) AS tags"
+
 type TaskStorer interface {
-	Set(Task) error
-	Get(uint) (Task, error)
-	Update(Task) error
-	Delete(uint) error
-	List(string) ([]Task, error)
+	Set(context.Context, Task) error
+	Get(context.Context, uint) (Task, error)
+	Lock(context.Context, uint) (Task, error)
+	Update(context.Context, Task) error
+	Delete(context.Context, Task) error
+	List(context.Context, TaskFilter) ([]Task, error)
+	Count(context.Context, TaskFilter) (int64, error)
+	Search(context.Context, TaskSearch) ([]TaskSearchHit, int64, error)
+	Restore(context.Context, Task) error
+	Purge(context.Context, uint) error
+	PurgeDeletedBefore(context.Context, time.Time) ([]uint, error)
+	ListEvents(context.Context, uint) ([]TaskEvent, error)
+	GetEventAt(context.Context, uint, time.Time) (TaskEvent, error)
+	AddTags(context.Context, Task, []string) error
+	RemoveTags(context.Context, Task, []string) error
+	TagCounts(context.Context) ([]TagCount, error)
+	SetParent(context.Context, Task) error
+	AddDependency(context.Context, TaskDependency) error
+	RemoveDependency(context.Context, TaskDependency) error
+	ListBlockers(context.Context, uint) ([]Task, error)
+	DependencyGraph(context.Context, uint) (TaskGraph, error)
+	AddComment(context.Context, Comment) (Comment, error)
+	GetComment(context.Context, uint) (Comment, error)
+	UpdateComment(context.Context, Comment) error
+	DeleteComment(context.Context, Comment) error
+	ListComments(context.Context, CommentFilter) ([]Comment, int64, error)
+	AddAttachment(context.Context, Attachment) (Attachment, error)
+	GetAttachment(context.Context, uint) (Attachment, error)
+	ListAttachments(context.Context, uint) ([]Attachment, error)
+	DeleteAttachment(context.Context, uint) error
+	AddWebhook(context.Context, Webhook) (Webhook, error)
+	GetWebhook(context.Context, uint) (Webhook, error)
+	ListWebhooks(context.Context) ([]Webhook, error)
+	DeleteWebhook(context.Context, uint) error
+	EnableWebhook(context.Context, uint, time.Time) error
+	AddWebhookDelivery(context.Context, WebhookDelivery, int) (Webhook, error)
+	ListWebhookDeliveries(context.Context, uint, int) ([]WebhookDelivery, error)
+	Atomic(context.Context, func(TaskStorer) error) error
+}
+
+// dbtx is the subset of *sql.DB and *sql.Tx used by the storage.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type taskStorage struct {
 	db *sql.DB
+	// tx is set on the storage handed to an Atomic callback; every query
+	// then runs in that transaction.
+	tx *sql.Tx
 }
 
 type TaskStorageOption func(*taskStorage)
@@ -33,3 +87,62 @@ func NewTaskStorage(opts ...TaskStorageOption) TaskStorer {
 	}
 	return s
 }
+
+// conn returns the transaction the storage is bound to, or the database.
+func (s *taskStorage) conn() dbtx {
+	if s.tx != nil {
+		return s.tx
+	}
+	return s.db
+}
+
+// inTx runs fn in a transaction that is committed when fn returns nil and
+// rolled back otherwise. A storage bound to a transaction runs fn in it and
+// leaves committing to Atomic.
+func (s *taskStorage) inTx(ctx context.Context, fn func(dbtx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(row scanner) (Task, error) {
+	var (
+		task      Task
+		dueAt     sql.NullTime
+		parentID  sql.NullInt64
+		deletedAt sql.NullTime
+		tags      sql.NullString
+	)
+	err := row.Scan(
+		&task.ID, &task.Title, &task.Description, &task.Status,
+		&task.Priority, &dueAt, &task.Assignee, &parentID,
+		&task.CreatedAt, &task.UpdatedAt, &task.CreatedBy, &task.UpdatedBy,
+		&deletedAt, &task.DeletedBy, &tags,
+	)
+	if dueAt.Valid {
+		task.DueAt = &dueAt.Time
+	}
+	if parentID.Valid {
+		task.ParentID = uint(parentID.Int64)
+	}
+	if deletedAt.Valid {
+		task.DeletedAt = &deletedAt.Time
+	}
+	if tags.String != "" {
+		task.Tags = strings.Split(tags.String, ",")
+	}
+	return task, err
+}
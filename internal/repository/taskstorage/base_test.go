@@ -0,0 +1,20 @@
+package taskstorage_test
+
+import (
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var taskColumns = []string{"id", "title", "description", "status", "priority", "due_at", "assignee", "parent_id", "created_at", "updated_at", "created_by", "updated_by", "deleted_at", "deleted_by", "tags"}
+
+// taskRows returns a single task row as the storage reads it back.
+func taskRows(id uint, deletedAt *time.Time) *sqlmock.Rows {
+	now := time.Now()
+	deletedBy := ""
+	if deletedAt != nil {
+		deletedBy = "alice"
+	}
+	return sqlmock.NewRows(taskColumns).
+		AddRow(id, "title", "description", "status", 0, nil, "", nil, now, now, "alice", "alice", deletedAt, deletedBy, nil)
+}
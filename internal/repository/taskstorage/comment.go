@@ -0,0 +1,134 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+const commentColumns = "task_comments.id, task_comments.task_id, task_comments.author, task_comments.body, task_comments.created_at, task_comments.updated_at"
+
+func scanComment(row scanner) (Comment, error) {
+	var comment Comment
+	err := row.Scan(&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &comment.CreatedAt, &comment.UpdatedAt)
+	return comment, err
+}
+
+// AddComment stores a comment on a live task and returns it with its ID.
+func (s *taskStorage) AddComment(ctx context.Context, comment Comment) (Comment, error) {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		if _, err := lockTask(ctx, tx, comment.TaskID, false); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "INSERT INTO task_comments (task_id, author, body, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
+			comment.TaskID, comment.Author, comment.Body, comment.CreatedAt, comment.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		comment.ID = uint(id)
+		return nil
+	})
+	_id := strconv.Itoa(int(comment.TaskID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Comment{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return Comment{}, fmt.Errorf("%w", customerror.ErrComment.AddData("'"+_id+"' could not be commented on."))
+	}
+	return comment, nil
+}
+
+// GetComment returns a comment of a live task.
+func (s *taskStorage) GetComment(ctx context.Context, id uint) (Comment, error) {
+	row := s.conn().QueryRowContext(ctx, "SELECT "+commentColumns+" FROM task_comments JOIN tasks ON tasks.id = task_comments.task_id "+
+		"WHERE task_comments.id = ? AND tasks.deleted_at IS NULL", id)
+	comment, err := scanComment(row)
+	_id := strconv.Itoa(int(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Comment{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("comment '"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return Comment{}, fmt.Errorf("%w", customerror.ErrComment.AddData("comment '"+_id+"' could not be read."))
+	}
+	return comment, nil
+}
+
+// UpdateComment replaces the body of a comment written by comment.Author
+// on a live task.
+func (s *taskStorage) UpdateComment(ctx context.Context, comment Comment) error {
+	res, err := s.conn().ExecContext(ctx, "UPDATE task_comments JOIN tasks ON tasks.id = task_comments.task_id "+
+		"SET task_comments.body = ?, task_comments.updated_at = ? "+
+		"WHERE task_comments.id = ? AND task_comments.author = ? AND tasks.deleted_at IS NULL",
+		comment.Body, comment.UpdatedAt, comment.ID, comment.Author)
+	return commentChanged(res, err, comment.ID, "updated")
+}
+
+// DeleteComment removes a comment written by comment.Author on a live
+// task.
+func (s *taskStorage) DeleteComment(ctx context.Context, comment Comment) error {
+	res, err := s.conn().ExecContext(ctx, "DELETE task_comments FROM task_comments JOIN tasks ON tasks.id = task_comments.task_id "+
+		"WHERE task_comments.id = ? AND task_comments.author = ? AND tasks.deleted_at IS NULL",
+		comment.ID, comment.Author)
+	return commentChanged(res, err, comment.ID, "deleted")
+}
+
+func commentChanged(res sql.Result, err error, id uint, action string) error {
+	_id := strconv.Itoa(int(id))
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrComment.AddData("comment '"+_id+"' could not be "+action+"."))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrComment.AddData("comment '"+_id+"' could not be "+action+"."))
+	}
+	if n == 0 {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("comment '"+_id+"' does not exist in the database."))
+	}
+	return nil
+}
+
+// ListComments returns a page of the comments of a live task, oldest
+// first, and the number of comments the task has.
+func (s *taskStorage) ListComments(ctx context.Context, filter CommentFilter) ([]Comment, int64, error) {
+	_id := strconv.Itoa(int(filter.TaskID))
+	err := liveTaskExists(ctx, s.conn(), filter.TaskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w", customerror.ErrComment.AddData("comments of '"+_id+"' could not be listed."))
+	}
+	var total int64
+	if err := s.conn().QueryRowContext(ctx, "SELECT COUNT(*) FROM task_comments WHERE task_id = ?", filter.TaskID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("%w", customerror.ErrComment.AddData("comments of '"+_id+"' could not be counted."))
+	}
+	query := "SELECT " + commentColumns + " FROM task_comments WHERE task_id = ? AND id > ? ORDER BY id ASC"
+	args := []any{filter.TaskID, filter.AfterID}
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	rows, err := s.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w", customerror.ErrComment.AddData("comments of '"+_id+"' could not be listed."))
+	}
+	defer rows.Close()
+	comments := make([]Comment, 0)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w", customerror.ErrComment.AddData("comments of '"+_id+"' could not be listed."))
+		}
+		comments = append(comments, comment)
+	}
+	return comments, total, nil
+}
@@ -0,0 +1,176 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+var commentColumns = []string{"id", "task_id", "author", "body", "created_at", "updated_at"}
+
+func Test_taskStorage_AddComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, nil))
+	mock.ExpectExec("INSERT INTO task_comments \\(task_id, author, body, created_at, updated_at\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\)").
+		WithArgs(1, "alice", "looks good", now, now).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(taskColumns))
+	mock.ExpectRollback()
+
+	comment, err := mockStorage.AddComment(context.Background(), models.Comment{TaskID: 1, Author: "alice", Body: "looks good", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatalf("taskStorage.AddComment() error = %v", err)
+	}
+	if comment.ID != 7 {
+		t.Errorf("taskStorage.AddComment() id = %v, want %v", comment.ID, 7)
+	}
+	_, err = mockStorage.AddComment(context.Background(), models.Comment{TaskID: 2, Author: "alice", Body: "looks good", CreatedAt: now, UpdatedAt: now})
+	if !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.AddComment() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_GetComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	want := models.Comment{ID: 7, TaskID: 1, Author: "alice", Body: "looks good", CreatedAt: now, UpdatedAt: now}
+	mock.ExpectQuery("SELECT (.+) FROM task_comments JOIN tasks ON tasks.id = task_comments.task_id WHERE task_comments.id = \\? AND tasks.deleted_at IS NULL").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(commentColumns).AddRow(7, 1, "alice", "looks good", now, now))
+	mock.ExpectQuery("SELECT (.+) FROM task_comments JOIN tasks").
+		WithArgs(8).
+		WillReturnRows(sqlmock.NewRows(commentColumns))
+
+	got, err := mockStorage.GetComment(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("taskStorage.GetComment() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("taskStorage.GetComment() = %v, want %v", got, want)
+	}
+	_, err = mockStorage.GetComment(context.Background(), 8)
+	if !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.GetComment() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+}
+
+func Test_taskStorage_UpdateComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectExec("UPDATE task_comments JOIN tasks ON tasks.id = task_comments.task_id SET task_comments.body = \\?, task_comments.updated_at = \\? "+
+		"WHERE task_comments.id = \\? AND task_comments.author = \\? AND tasks.deleted_at IS NULL").
+		WithArgs("edited", now, 7, "alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE task_comments").
+		WithArgs("edited", now, 8, "alice").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := mockStorage.UpdateComment(context.Background(), models.Comment{ID: 7, Author: "alice", Body: "edited", UpdatedAt: now}); err != nil {
+		t.Errorf("taskStorage.UpdateComment() error = %v, wantErr %v", err, nil)
+	}
+	err = mockStorage.UpdateComment(context.Background(), models.Comment{ID: 8, Author: "alice", Body: "edited", UpdatedAt: now})
+	if !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.UpdateComment() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+}
+
+func Test_taskStorage_DeleteComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	mock.ExpectExec("DELETE task_comments FROM task_comments JOIN tasks ON tasks.id = task_comments.task_id "+
+		"WHERE task_comments.id = \\? AND task_comments.author = \\? AND tasks.deleted_at IS NULL").
+		WithArgs(7, "alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE task_comments").
+		WithArgs(7, "bob").
+		WillReturnError(errors.New("connection lost"))
+
+	if err := mockStorage.DeleteComment(context.Background(), models.Comment{ID: 7, Author: "alice"}); err != nil {
+		t.Errorf("taskStorage.DeleteComment() error = %v, wantErr %v", err, nil)
+	}
+	err = mockStorage.DeleteComment(context.Background(), models.Comment{ID: 7, Author: "bob"})
+	if !errors.Is(err, customerror.ErrComment) {
+		t.Errorf("taskStorage.DeleteComment() error = %v, wantErr %v", err, customerror.ErrComment)
+	}
+}
+
+func Test_taskStorage_ListComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM task_comments WHERE task_id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+) FROM task_comments WHERE task_id = \\? AND id > \\? ORDER BY id ASC LIMIT \\?").
+		WithArgs(1, 4, 2).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(5, 1, "alice", "first", now, now).
+			AddRow(6, 1, "bob", "second", now, now))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	comments, total, err := mockStorage.ListComments(context.Background(), models.CommentFilter{TaskID: 1, AfterID: 4, Limit: 2})
+	if err != nil {
+		t.Fatalf("taskStorage.ListComments() error = %v", err)
+	}
+	if total != 3 || len(comments) != 2 || comments[0].ID != 5 || comments[1].Author != "bob" {
+		t.Errorf("taskStorage.ListComments() = %v, %v, want comments 5 and 6 of 3", comments, total)
+	}
+	_, _, err = mockStorage.ListComments(context.Background(), models.CommentFilter{TaskID: 2})
+	if !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.ListComments() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -1,17 +1,39 @@
 package taskstorage
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 )
 
-func (s *taskStorage) Delete(id uint) error {
-	_, err := s.db.Exec("DELETE FROM tasks WHERE id = ?", id)
-	_id := strconv.Itoa(int(id))
+// Delete moves the task to the trash. The row is kept until it is purged.
+func (s *taskStorage) Delete(ctx context.Context, task Task) error {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		old, err := lockTask(ctx, tx, task.ID, false)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET deleted_at = ?, deleted_by = ? WHERE id = ? AND deleted_at IS NULL",
+			task.DeletedAt, task.DeletedBy, task.ID)
+		if err != nil {
+			return err
+		}
+		deleted := old
+		deleted.DeletedAt = task.DeletedAt
+		deleted.DeletedBy = task.DeletedBy
+		return recordEvent(ctx, tx, task.ID, TaskEventDeleted, &old, &deleted)
+	})
+	_id := strconv.Itoa(int(task.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
 	if err != nil {
 		return fmt.Errorf("%w", customerror.ErrDelete.AddData("'"+_id+"' could not be deleted."))
 	}
-	return err
+	return nil
 }
@@ -1,7 +1,9 @@
 package taskstorage_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
@@ -16,15 +18,26 @@ func Test_taskStorage_Delete(t *testing.T) {
 	defer db.Close()
 	mockStorage := NewTaskStorage(WithTaskDB(db))
 
-	mock.ExpectExec("DELETE FROM tasks WHERE id = ?").
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
 		WithArgs(1).
+		WillReturnRows(taskRows(1, nil))
+	mock.ExpectExec("UPDATE tasks SET deleted_at = \\?, deleted_by = \\? WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(&now, "alice", 1).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(1, models.TaskEventDeleted, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	task := models.Task{
 		ID:          1,
 		Title:       "title",
 		Description: "description",
 		Status:      "status",
+		DeletedAt:   &now,
+		DeletedBy:   "alice",
 	}
 	tests := []struct {
 		name    string
@@ -44,7 +57,7 @@ func Test_taskStorage_Delete(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := mockStorage.Delete(tt.args.ID); (err != nil) != tt.wantErr {
+			if err := mockStorage.Delete(context.Background(), tt.args); (err != nil) != tt.wantErr {
 				t.Errorf("taskStorage.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
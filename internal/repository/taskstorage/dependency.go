@@ -0,0 +1,246 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+// errCycle reports a link that would make a task its own ancestor or
+// blocker.
+var errCycle = errors.New("cycle")
+
+// linksLock names the row of the locks table serializing the changes of
+// the parents and blockers of tasks.
+const linksLock = "task_links"
+
+// lockLinks makes the other changes of links wait for the end of the
+// transaction. Locking the linked tasks alone is not enough: a cycle may
+// be closed by changes of disjoint tasks, each checking the links before
+// the other one made its own.
+func lockLinks(ctx context.Context, tx dbtx) error {
+	var name string
+	err := tx.QueryRowContext(ctx, "SELECT name FROM locks WHERE name = ? FOR UPDATE", linksLock).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("lock '" + linksLock + "' is missing")
+	}
+	return err
+}
+
+// SetParent makes the task a subtask of task.ParentID, or a top level task
+// when it is 0. The parent must be a live task other than the task itself
+// and its subtasks.
+func (s *taskStorage) SetParent(ctx context.Context, task Task) error {
+	missing := task.ID
+	err := s.inTx(ctx, func(tx dbtx) error {
+		if err := lockLinks(ctx, tx); err != nil {
+			return err
+		}
+		old, err := lockTask(ctx, tx, task.ID, false)
+		if err != nil {
+			return err
+		}
+		var parentID any
+		if task.ParentID != 0 {
+			missing = task.ParentID
+			if err := liveTaskExists(ctx, tx, task.ParentID); err != nil {
+				return err
+			}
+			var cycles int
+			err := tx.QueryRowContext(ctx, "WITH RECURSIVE ancestors (id, parent_id) AS ("+
+				"SELECT id, parent_id FROM tasks WHERE id = ? "+
+				"UNION SELECT tasks.id, tasks.parent_id FROM tasks JOIN ancestors ON tasks.id = ancestors.parent_id"+
+				") SELECT COUNT(*) FROM ancestors WHERE id = ?", task.ParentID, task.ID).Scan(&cycles)
+			if err != nil {
+				return err
+			}
+			if cycles > 0 {
+				return errCycle
+			}
+			parentID = task.ParentID
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET parent_id = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+			parentID, task.UpdatedAt, task.UpdatedBy, task.ID)
+		if err != nil {
+			return err
+		}
+		updated := old
+		updated.ParentID = task.ParentID
+		updated.UpdatedAt = task.UpdatedAt
+		updated.UpdatedBy = task.UpdatedBy
+		return recordEvent(ctx, tx, task.ID, TaskEventUpdated, &old, &updated)
+	})
+	_id := strconv.Itoa(int(task.ID))
+	if errors.Is(err, errCycle) {
+		return fmt.Errorf("%w", customerror.ErrCycle.AddData("'"+strconv.Itoa(int(task.ParentID))+"' is '"+_id+"' or one of its subtasks."))
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+strconv.Itoa(int(missing))+"' does not exist in the database."))
+	}
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrLink.AddData("the parent of '"+_id+"' could not be set."))
+	}
+	return nil
+}
+
+// AddDependency records that the task is blocked by the blocker. Both must
+// be live tasks and the blocker must not already be blocked by the task,
+// directly or through other tasks.
+func (s *taskStorage) AddDependency(ctx context.Context, dep TaskDependency) error {
+	missing := dep.TaskID
+	err := s.inTx(ctx, func(tx dbtx) error {
+		if err := lockLinks(ctx, tx); err != nil {
+			return err
+		}
+		if _, err := lockTask(ctx, tx, dep.TaskID, false); err != nil {
+			return err
+		}
+		missing = dep.BlockerID
+		if err := liveTaskExists(ctx, tx, dep.BlockerID); err != nil {
+			return err
+		}
+		if dep.TaskID == dep.BlockerID {
+			return errCycle
+		}
+		var cycles int
+		err := tx.QueryRowContext(ctx, "WITH RECURSIVE blockers (blocker_id) AS ("+
+			"SELECT blocker_id FROM task_dependencies WHERE task_id = ? "+
+			"UNION SELECT task_dependencies.blocker_id FROM task_dependencies JOIN blockers ON task_dependencies.task_id = blockers.blocker_id"+
+			") SELECT COUNT(*) FROM blockers WHERE blocker_id = ?", dep.BlockerID, dep.TaskID).Scan(&cycles)
+		if err != nil {
+			return err
+		}
+		if cycles > 0 {
+			return errCycle
+		}
+		_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO task_dependencies (task_id, blocker_id) VALUES (?, ?)", dep.TaskID, dep.BlockerID)
+		return err
+	})
+	_id := strconv.Itoa(int(dep.TaskID))
+	_blocker := strconv.Itoa(int(dep.BlockerID))
+	if errors.Is(err, errCycle) {
+		return fmt.Errorf("%w", customerror.ErrCycle.AddData("'"+_blocker+"' is '"+_id+"' or already blocked by it."))
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+strconv.Itoa(int(missing))+"' does not exist in the database."))
+	}
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrLink.AddData("'"+_id+"' could not be blocked by '"+_blocker+"'."))
+	}
+	return nil
+}
+
+// RemoveDependency deletes the link between the task and its blocker.
+func (s *taskStorage) RemoveDependency(ctx context.Context, dep TaskDependency) error {
+	res, err := s.conn().ExecContext(ctx, "DELETE FROM task_dependencies WHERE task_id = ? AND blocker_id = ?", dep.TaskID, dep.BlockerID)
+	_id := strconv.Itoa(int(dep.TaskID))
+	_blocker := strconv.Itoa(int(dep.BlockerID))
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrLink.AddData("'"+_id+"' could not be unblocked from '"+_blocker+"'."))
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' is not blocked by '"+_blocker+"'."))
+	}
+	return nil
+}
+
+// ListBlockers returns the live tasks directly blocking the task.
+func (s *taskStorage) ListBlockers(ctx context.Context, id uint) ([]Task, error) {
+	tasks := make([]Task, 0)
+	rows, err := s.conn().QueryContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE deleted_at IS NULL AND "+
+		"id IN (SELECT blocker_id FROM task_dependencies WHERE task_id = ?) ORDER BY id ASC", id)
+	_id := strconv.Itoa(int(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("blockers of '"+_id+"' could not be listed."))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("blockers of '"+_id+"' could not be listed."))
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// DependencyGraph returns the graph around a live task. Links to tasks in
+// the trash are left out.
+func (s *taskStorage) DependencyGraph(ctx context.Context, id uint) (TaskGraph, error) {
+	root, err := s.Get(ctx, id)
+	if err != nil {
+		return TaskGraph{}, err
+	}
+	_id := strconv.Itoa(int(id))
+	failed := func() error {
+		return fmt.Errorf("%w", customerror.ErrGetAll.AddData("the graph of '"+_id+"' could not be built."))
+	}
+
+	rows, err := s.conn().QueryContext(ctx, "WITH RECURSIVE "+
+		"up (task_id, blocker_id) AS (SELECT task_id, blocker_id FROM task_dependencies WHERE task_id = ? "+
+		"UNION SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN up ON d.task_id = up.blocker_id), "+
+		"down (task_id, blocker_id) AS (SELECT task_id, blocker_id FROM task_dependencies WHERE blocker_id = ? "+
+		"UNION SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN down ON d.blocker_id = down.task_id) "+
+		"SELECT task_id, blocker_id FROM up UNION SELECT task_id, blocker_id FROM down", id, id)
+	if err != nil {
+		return TaskGraph{}, failed()
+	}
+	defer rows.Close()
+	var (
+		deps []TaskDependency
+		ids  []any
+		seen = make(map[uint]bool)
+	)
+	addID := func(taskID uint) {
+		if taskID != 0 && !seen[taskID] {
+			seen[taskID] = true
+			ids = append(ids, taskID)
+		}
+	}
+	addID(id)
+	addID(root.ParentID)
+	for rows.Next() {
+		var dep TaskDependency
+		if err := rows.Scan(&dep.TaskID, &dep.BlockerID); err != nil {
+			return TaskGraph{}, failed()
+		}
+		deps = append(deps, dep)
+		addID(dep.TaskID)
+		addID(dep.BlockerID)
+	}
+	rows.Close()
+
+	taskRows, err := s.conn().QueryContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE deleted_at IS NULL AND "+
+		"(id IN ("+placeholders(len(ids))+") OR parent_id = ?) ORDER BY id ASC", append(ids, id)...)
+	if err != nil {
+		return TaskGraph{}, failed()
+	}
+	defer taskRows.Close()
+	graph := TaskGraph{Root: root, Tasks: make([]Task, 0), Dependencies: make([]TaskDependency, 0)}
+	live := make(map[uint]bool)
+	for taskRows.Next() {
+		task, err := scanTask(taskRows)
+		if err != nil {
+			return TaskGraph{}, failed()
+		}
+		live[task.ID] = true
+		graph.Tasks = append(graph.Tasks, task)
+	}
+	for _, dep := range deps {
+		if live[dep.TaskID] && live[dep.BlockerID] {
+			graph.Dependencies = append(graph.Dependencies, dep)
+		}
+	}
+	return graph, nil
+}
+
+// liveTaskExists returns sql.ErrNoRows unless the task is live.
+func liveTaskExists(ctx context.Context, db dbtx, id uint) error {
+	var found uint
+	return db.QueryRowContext(ctx, "SELECT id FROM tasks WHERE id = ? AND deleted_at IS NULL", id).Scan(&found)
+}
@@ -0,0 +1,290 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+func Test_taskStorage_SetParent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectBegin()
+	expectLinksLock(mock)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, nil))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("WITH RECURSIVE ancestors (.+) SELECT COUNT\\(\\*\\) FROM ancestors WHERE id = \\?").
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("UPDATE tasks SET parent_id = \\?, updated_at = \\?, updated_by = \\? WHERE id = \\?").
+		WithArgs(2, now, "alice", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(1, models.TaskEventUpdated, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	expectLinksLock(mock)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(taskRows(2, nil))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("WITH RECURSIVE ancestors").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	expectLinksLock(mock)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(3).
+		WillReturnRows(taskRows(3, nil))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	expectLinksLock(mock)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(4).
+		WillReturnRows(taskRows(4, nil))
+	mock.ExpectExec("UPDATE tasks SET parent_id = \\?, updated_at = \\?, updated_by = \\? WHERE id = \\?").
+		WithArgs(nil, now, "alice", 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(4, models.TaskEventUpdated, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	tests := []struct {
+		name    string
+		args    models.Task
+		wantErr error
+	}{
+		{
+			name:    "Parent exists and no error is expected",
+			args:    models.Task{ID: 1, ParentID: 2, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: nil,
+		},
+		{
+			name:    "Parent is a subtask and cycle error is expected",
+			args:    models.Task{ID: 2, ParentID: 1, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: customerror.ErrCycle,
+		},
+		{
+			name:    "Parent does not exist and not found error is expected",
+			args:    models.Task{ID: 3, ParentID: 9, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: customerror.ErrIDNotFound,
+		},
+		{
+			name:    "Parent is cleared and no error is expected",
+			args:    models.Task{ID: 4, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := mockStorage.SetParent(context.Background(), tt.args); !errors.Is(err, tt.wantErr) {
+				t.Errorf("taskStorage.SetParent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_AddDependency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	mock.ExpectBegin()
+	expectLinksLock(mock)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, nil))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("WITH RECURSIVE blockers (.+) SELECT COUNT\\(\\*\\) FROM blockers WHERE blocker_id = \\?").
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("INSERT IGNORE INTO task_dependencies \\(task_id, blocker_id\\) VALUES \\(\\?, \\?\\)").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	expectLinksLock(mock)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(taskRows(2, nil))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("WITH RECURSIVE blockers").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	expectLinksLock(mock)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(3).
+		WillReturnRows(taskRows(3, nil))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT name FROM locks WHERE name = \\? FOR UPDATE").
+		WithArgs("task_links").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		name    string
+		args    models.TaskDependency
+		wantErr error
+	}{
+		{
+			name:    "Blocker exists and no error is expected",
+			args:    models.TaskDependency{TaskID: 1, BlockerID: 2},
+			wantErr: nil,
+		},
+		{
+			name:    "Blocker is blocked by the task and cycle error is expected",
+			args:    models.TaskDependency{TaskID: 2, BlockerID: 1},
+			wantErr: customerror.ErrCycle,
+		},
+		{
+			name:    "Task blocks itself and cycle error is expected",
+			args:    models.TaskDependency{TaskID: 3, BlockerID: 3},
+			wantErr: customerror.ErrCycle,
+		},
+		{
+			name:    "Lock of the links is missing and link error is expected",
+			args:    models.TaskDependency{TaskID: 4, BlockerID: 5},
+			wantErr: customerror.ErrLink,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := mockStorage.AddDependency(context.Background(), tt.args); !errors.Is(err, tt.wantErr) {
+				t.Errorf("taskStorage.AddDependency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_taskStorage_RemoveDependency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	mock.ExpectExec("DELETE FROM task_dependencies WHERE task_id = \\? AND blocker_id = \\?").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM task_dependencies WHERE task_id = \\? AND blocker_id = \\?").
+		WithArgs(1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := mockStorage.RemoveDependency(context.Background(), models.TaskDependency{TaskID: 1, BlockerID: 2}); err != nil {
+		t.Errorf("taskStorage.RemoveDependency() error = %v, wantErr %v", err, nil)
+	}
+	err = mockStorage.RemoveDependency(context.Background(), models.TaskDependency{TaskID: 1, BlockerID: 3})
+	if !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.RemoveDependency() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+}
+
+func Test_taskStorage_ListBlockers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND id IN \\(SELECT blocker_id FROM task_dependencies WHERE task_id = \\?\\) ORDER BY id ASC").
+		WithArgs(1).
+		WillReturnRows(taskRows(2, nil))
+
+	blockers, err := mockStorage.ListBlockers(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("taskStorage.ListBlockers() error = %v", err)
+	}
+	if len(blockers) != 1 || blockers[0].ID != 2 {
+		t.Errorf("taskStorage.ListBlockers() = %v, want task 2", blockers)
+	}
+}
+
+func Test_taskStorage_DependencyGraph(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(taskColumns).
+			AddRow(2, "title", "description", "todo", 0, nil, "", 1, now, now, "alice", "alice", nil, "", nil))
+	mock.ExpectQuery("WITH RECURSIVE up (.+) SELECT task_id, blocker_id FROM up UNION SELECT task_id, blocker_id FROM down").
+		WithArgs(2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "blocker_id"}).AddRow(2, 3).AddRow(4, 2).AddRow(3, 5))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND \\(id IN \\(\\?, \\?, \\?, \\?, \\?\\) OR parent_id = \\?\\) ORDER BY id ASC").
+		WithArgs(2, 1, 3, 4, 5, 2).
+		WillReturnRows(sqlmock.NewRows(taskColumns).
+			AddRow(1, "title", "description", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil).
+			AddRow(2, "title", "description", "todo", 0, nil, "", 1, now, now, "alice", "alice", nil, "", nil).
+			AddRow(3, "title", "description", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil).
+			AddRow(4, "title", "description", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil).
+			AddRow(6, "title", "description", "todo", 0, nil, "", 2, now, now, "alice", "alice", nil, "", nil))
+
+	graph, err := mockStorage.DependencyGraph(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("taskStorage.DependencyGraph() error = %v", err)
+	}
+	if graph.Root.ID != 2 || graph.Root.ParentID != 1 {
+		t.Errorf("unexpected root: %+v", graph.Root)
+	}
+	if len(graph.Tasks) != 5 {
+		t.Errorf("expected 5 tasks, got %d", len(graph.Tasks))
+	}
+	want := []models.TaskDependency{{TaskID: 2, BlockerID: 3}, {TaskID: 4, BlockerID: 2}}
+	if !reflect.DeepEqual(graph.Dependencies, want) {
+		t.Errorf("taskStorage.DependencyGraph() dependencies = %v, want %v (links to trashed task 5 left out)", graph.Dependencies, want)
+	}
+}
+
+func expectLinksLock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT name FROM locks WHERE name = \\? FOR UPDATE").
+		WithArgs("task_links").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("task_links"))
+}
@@ -0,0 +1,112 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+const eventColumns = "id, task_id, type, old_value, new_value, actor, request_id, created_at"
+
+// recordEvent appends a change of the task to its history. It must run in
+// the same transaction as the change itself.
+func recordEvent(ctx context.Context, db dbtx, taskID uint, typ string, oldValue, newValue *Task) error {
+	oldJSON, err := marshalTask(oldValue)
+	if err != nil {
+		return err
+	}
+	newJSON, err := marshalTask(newValue)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, "INSERT INTO task_events (task_id, type, old_value, new_value, actor, request_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		taskID, typ, oldJSON, newJSON, util.ActorFromContext(ctx), util.RequestIDFromContext(ctx), time.Now().UTC())
+	return err
+}
+
+func marshalTask(task *Task) (any, error) {
+	if task == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func unmarshalTask(b []byte) (*Task, error) {
+	if b == nil {
+		return nil, nil
+	}
+	task := &Task{}
+	if err := json.Unmarshal(b, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func scanEvent(row scanner) (TaskEvent, error) {
+	var (
+		event              TaskEvent
+		oldValue, newValue []byte
+	)
+	err := row.Scan(&event.ID, &event.TaskID, &event.Type, &oldValue, &newValue, &event.Actor, &event.RequestID, &event.CreatedAt)
+	if err != nil {
+		return TaskEvent{}, err
+	}
+	if event.OldValue, err = unmarshalTask(oldValue); err != nil {
+		return TaskEvent{}, err
+	}
+	if event.NewValue, err = unmarshalTask(newValue); err != nil {
+		return TaskEvent{}, err
+	}
+	return event, nil
+}
+
+// ListEvents returns the history of the task, oldest first.
+func (s *taskStorage) ListEvents(ctx context.Context, taskID uint) ([]TaskEvent, error) {
+	events := make([]TaskEvent, 0)
+	_id := strconv.Itoa(int(taskID))
+	rows, err := s.conn().QueryContext(ctx, "SELECT "+eventColumns+" FROM task_events WHERE task_id = ? ORDER BY id ASC", taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrHistory.AddData("history of '"+_id+"' could not be listed."))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrHistory.AddData("history of '"+_id+"' could not be listed."))
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrHistory.AddData("history of '"+_id+"' could not be listed."))
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' has no history."))
+	}
+	return events, nil
+}
+
+// GetEventAt returns the last change of the task made at or before t.
+func (s *taskStorage) GetEventAt(ctx context.Context, taskID uint, t time.Time) (TaskEvent, error) {
+	row := s.conn().QueryRowContext(ctx, "SELECT "+eventColumns+" FROM task_events WHERE task_id = ? AND created_at <= ? ORDER BY id DESC LIMIT 1", taskID, t)
+	event, err := scanEvent(row)
+	_id := strconv.Itoa(int(taskID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return TaskEvent{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' did not exist at "+t.Format(time.RFC3339)+"."))
+	}
+	if err != nil {
+		return TaskEvent{}, fmt.Errorf("%w", customerror.ErrHistory.AddData("history of '"+_id+"' could not be read."))
+	}
+	return event, nil
+}
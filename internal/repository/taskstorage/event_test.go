@@ -0,0 +1,105 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+var eventColumns = []string{"id", "task_id", "type", "old_value", "new_value", "actor", "request_id", "created_at"}
+
+func Test_taskStorage_ListEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM task_events WHERE task_id = \\? ORDER BY id ASC").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(eventColumns).
+			AddRow(1, 1, models.TaskEventCreated, nil, []byte(`{"id":1,"title":"first"}`), "alice", "req-1", now).
+			AddRow(2, 1, models.TaskEventUpdated, []byte(`{"id":1,"title":"first"}`), []byte(`{"id":1,"title":"second"}`), "bob", "req-2", now))
+	mock.ExpectQuery("SELECT (.+) FROM task_events WHERE task_id = \\? ORDER BY id ASC").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(eventColumns))
+
+	events, err := mockStorage.ListEvents(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("taskStorage.ListEvents() error = %v, wantErr %v", err, nil)
+	}
+	if len(events) != 2 {
+		t.Fatalf("taskStorage.ListEvents() returned %v events, want %v", len(events), 2)
+	}
+	if events[0].OldValue != nil || events[0].NewValue.Title != "first" {
+		t.Errorf("taskStorage.ListEvents() first event = %+v", events[0])
+	}
+	if events[1].OldValue.Title != "first" || events[1].NewValue.Title != "second" || events[1].Actor != "bob" {
+		t.Errorf("taskStorage.ListEvents() second event = %+v", events[1])
+	}
+
+	if _, err := mockStorage.ListEvents(context.Background(), 2); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.ListEvents() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if _, err := mockStorage.ListEvents(context.Background(), 3); !errors.Is(err, customerror.ErrHistory) {
+		t.Errorf("taskStorage.ListEvents() error = %v, wantErr %v", err, customerror.ErrHistory)
+	}
+}
+
+func Test_taskStorage_GetEventAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	at := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM task_events WHERE task_id = \\? AND created_at <= \\? ORDER BY id DESC LIMIT 1").
+		WithArgs(1, at).
+		WillReturnRows(sqlmock.NewRows(eventColumns).
+			AddRow(2, 1, models.TaskEventUpdated, []byte(`{"id":1,"title":"first"}`), []byte(`{"id":1,"title":"second"}`), "bob", "", at))
+	mock.ExpectQuery("SELECT (.+) FROM task_events WHERE task_id = \\? AND created_at <= \\? ORDER BY id DESC LIMIT 1").
+		WithArgs(2, at).
+		WillReturnRows(sqlmock.NewRows(eventColumns))
+	mock.ExpectQuery("SELECT (.+) FROM task_events WHERE task_id = \\? AND created_at <= \\? ORDER BY id DESC LIMIT 1").
+		WithArgs(3, at).
+		WillReturnError(errors.New("lock wait timeout exceeded"))
+
+	tests := []struct {
+		name    string
+		args    uint
+		wantErr error
+	}{
+		{
+			name:    "Task has history before the time and no error is expected",
+			args:    1,
+			wantErr: nil,
+		},
+		{
+			name:    "Task has no history before the time and not found error is expected",
+			args:    2,
+			wantErr: customerror.ErrIDNotFound,
+		},
+		{
+			name:    "History cannot be read and history error is expected",
+			args:    3,
+			wantErr: customerror.ErrHistory,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mockStorage.GetEventAt(context.Background(), tt.args, at); !errors.Is(err, tt.wantErr) {
+				t.Errorf("taskStorage.GetEventAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
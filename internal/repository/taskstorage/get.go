@@ -1,6 +1,9 @@
 package taskstorage
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -8,12 +11,42 @@ import (
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 )
 
-func (s *taskStorage) Get(id uint) (Task, error) {
-	task := Task{}
-	err := s.db.QueryRow("SELECT id, title, description, status FROM tasks WHERE id = ?", id).Scan(&task.ID, &task.Title, &task.Description, &task.Status)
+func (s *taskStorage) Get(ctx context.Context, id uint) (Task, error) {
+	task, err := scanTask(s.conn().QueryRowContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = ? AND deleted_at IS NULL", id))
+	return task, getError(id, err)
+}
+
+// Lock reads a live task like Get and, in an Atomic transaction, locks it
+// until the transaction ends so that it can be changed based on what was
+// read.
+func (s *taskStorage) Lock(ctx context.Context, id uint) (Task, error) {
+	if s.tx == nil {
+		return s.Get(ctx, id)
+	}
+	task, err := lockTask(ctx, s.tx, id, false)
+	return task, getError(id, err)
+}
+
+// getError reports the error of reading the task id: ErrIDNotFound when
+// there is no such live task and ErrGet when it could not be read.
+func getError(id uint, err error) error {
 	_id := strconv.Itoa(int(id))
-	if err != nil {
-		return Task{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	default:
+		return fmt.Errorf("%w", customerror.ErrGet.AddData("'"+_id+"' could not be read."))
+	}
+}
+
+// lockTask reads and locks a live task, or a trashed one if deleted is set,
+// for the rest of the transaction.
+func lockTask(ctx context.Context, db dbtx, id uint, deleted bool) (Task, error) {
+	cond := "deleted_at IS NULL"
+	if deleted {
+		cond = "deleted_at IS NOT NULL"
 	}
-	return task, nil
+	return scanTask(db.QueryRowContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id = ? AND "+cond+" FOR UPDATE", id))
 }
@@ -1,9 +1,13 @@
 package taskstorage_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
 )
 
@@ -15,10 +19,11 @@ func Test_taskStorage_Get(t *testing.T) {
 	defer db.Close()
 
 	mockStorage := NewTaskStorage(WithTaskDB(db))
-	mock.ExpectQuery("SELECT id, title, description, status FROM tasks WHERE id = ?").
+	now := time.Now()
+	mock.ExpectQuery("SELECT id, title, description, status, priority, due_at, assignee, parent_id, created_at, updated_at, created_by, updated_by, deleted_at, deleted_by, \\(SELECT GROUP_CONCAT\\(tags.name ORDER BY tags.name SEPARATOR ','\\) FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id\\) AS tags FROM tasks WHERE id = \\? AND deleted_at IS NULL").
 		WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status"}).
-			AddRow(1, "title", "description", "status"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "priority", "due_at", "assignee", "parent_id", "created_at", "updated_at", "created_by", "updated_by", "deleted_at", "deleted_by", "tags"}).
+			AddRow(1, "title", "description", "status", 0, nil, "", nil, now, now, "alice", "bob", nil, "", nil))
 
 	tests := []struct {
 		name    string
@@ -38,9 +43,52 @@ func Test_taskStorage_Get(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if _, err := mockStorage.Get(tt.args); (err != nil) != tt.wantErr {
+			if _, err := mockStorage.Get(context.Background(), tt.args); (err != nil) != tt.wantErr {
 				t.Errorf("taskStorage.Get() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_taskStorage_GetErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(taskColumns))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL").
+		WithArgs(3).
+		WillReturnError(errors.New("driver: bad connection"))
+
+	tests := []struct {
+		name    string
+		args    uint
+		wantErr error
+	}{
+		{
+			name:    "Task does not exist and not found error is expected",
+			args:    2,
+			wantErr: customerror.ErrIDNotFound,
+		},
+		{
+			name:    "Task cannot be read and get error is expected",
+			args:    3,
+			wantErr: customerror.ErrGet,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mockStorage.Get(context.Background(), tt.args); !errors.Is(err, tt.wantErr) {
+				t.Errorf("taskStorage.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
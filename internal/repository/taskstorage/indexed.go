@@ -0,0 +1,154 @@
+package taskstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/search"
+)
+
+// indexedStorage answers searches from an in-process inverted index instead
+// of the storage it wraps, for backends without full-text search of their
+// own. Every task a write changes is read again once the write is
+// committed and indexed as read, or removed from the index when it left
+// the live set. Only the changes made through it are seen, so the storage
+// must not be shared with another server.
+type indexedStorage struct {
+	TaskStorer
+	index *search.Index
+	// changed collects the IDs of the tasks changed in an Atomic
+	// transaction, indexed once it is committed. It is nil outside of one.
+	changed *[]uint
+}
+
+// NewIndexedStorage wraps storer so that searches are answered by index,
+// which is filled with the live tasks of storer first.
+func NewIndexedStorage(ctx context.Context, storer TaskStorer, index *search.Index) (TaskStorer, error) {
+	tasks, err := storer.List(ctx, TaskFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("search index: %w", err)
+	}
+	for _, task := range tasks {
+		index.Add(task)
+	}
+	return &indexedStorage{TaskStorer: storer, index: index}, nil
+}
+
+func (s *indexedStorage) Search(_ context.Context, query TaskSearch) ([]TaskSearchHit, int64, error) {
+	hits, total := s.index.Search(query)
+	return hits, total, nil
+}
+
+// reindex indexes the task id as it is stored now, or records it to be
+// indexed once the transaction the storage is bound to is committed.
+func (s *indexedStorage) reindex(ctx context.Context, id uint) {
+	if s.changed != nil {
+		*s.changed = append(*s.changed, id)
+		return
+	}
+	task, err := s.TaskStorer.Get(ctx, id)
+	switch {
+	case err == nil:
+		s.index.Add(task)
+	case errors.Is(err, customerror.ErrIDNotFound):
+		s.index.Remove(id)
+	}
+}
+
+func (s *indexedStorage) Set(ctx context.Context, task Task) error {
+	if err := s.TaskStorer.Set(ctx, task); err != nil {
+		return err
+	}
+	s.reindex(ctx, task.ID)
+	return nil
+}
+
+func (s *indexedStorage) Update(ctx context.Context, task Task) error {
+	if err := s.TaskStorer.Update(ctx, task); err != nil {
+		return err
+	}
+	s.reindex(ctx, task.ID)
+	return nil
+}
+
+func (s *indexedStorage) Delete(ctx context.Context, task Task) error {
+	if err := s.TaskStorer.Delete(ctx, task); err != nil {
+		return err
+	}
+	s.reindex(ctx, task.ID)
+	return nil
+}
+
+func (s *indexedStorage) Restore(ctx context.Context, task Task) error {
+	if err := s.TaskStorer.Restore(ctx, task); err != nil {
+		return err
+	}
+	s.reindex(ctx, task.ID)
+	return nil
+}
+
+func (s *indexedStorage) Purge(ctx context.Context, id uint) error {
+	if err := s.TaskStorer.Purge(ctx, id); err != nil {
+		return err
+	}
+	s.reindex(ctx, id)
+	return nil
+}
+
+func (s *indexedStorage) PurgeDeletedBefore(ctx context.Context, t time.Time) ([]uint, error) {
+	ids, err := s.TaskStorer.PurgeDeletedBefore(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		s.reindex(ctx, id)
+	}
+	return ids, nil
+}
+
+func (s *indexedStorage) AddTags(ctx context.Context, task Task, tags []string) error {
+	if err := s.TaskStorer.AddTags(ctx, task, tags); err != nil {
+		return err
+	}
+	s.reindex(ctx, task.ID)
+	return nil
+}
+
+func (s *indexedStorage) RemoveTags(ctx context.Context, task Task, tags []string) error {
+	if err := s.TaskStorer.RemoveTags(ctx, task, tags); err != nil {
+		return err
+	}
+	s.reindex(ctx, task.ID)
+	return nil
+}
+
+func (s *indexedStorage) SetParent(ctx context.Context, task Task) error {
+	if err := s.TaskStorer.SetParent(ctx, task); err != nil {
+		return err
+	}
+	s.reindex(ctx, task.ID)
+	return nil
+}
+
+// Atomic indexes the tasks changed by fn once its transaction is committed,
+// and none of them when it is rolled back.
+func (s *indexedStorage) Atomic(ctx context.Context, fn func(TaskStorer) error) error {
+	if s.changed != nil {
+		return fn(s)
+	}
+	var changed []uint
+	err := s.TaskStorer.Atomic(ctx, func(tx TaskStorer) error {
+		return fn(&indexedStorage{TaskStorer: tx, index: s.index, changed: &changed})
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range changed {
+		s.reindex(ctx, id)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/search"
+)
+
+// memStorage keeps live tasks in memory. Its transactions apply their
+// changes when they are committed. Other methods are not used by the
+// indexed storage.
+type memStorage struct {
+	TaskStorer
+	tasks map[uint]models.Task
+}
+
+func (s *memStorage) List(context.Context, models.TaskFilter) ([]models.Task, error) {
+	tasks := make([]models.Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func (s *memStorage) Get(_ context.Context, id uint) (models.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return models.Task{}, customerror.ErrIDNotFound.AddData("'" + strconv.Itoa(int(id)) + "' does not exist in the database.")
+	}
+	return task, nil
+}
+
+func (s *memStorage) Set(_ context.Context, task models.Task) error {
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *memStorage) Delete(_ context.Context, task models.Task) error {
+	delete(s.tasks, task.ID)
+	return nil
+}
+
+func (s *memStorage) Atomic(ctx context.Context, fn func(TaskStorer) error) error {
+	tx := &memStorage{tasks: make(map[uint]models.Task)}
+	for id, task := range s.tasks {
+		tx.tasks[id] = task
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	s.tasks = tx.tasks
+	return nil
+}
+
+func ids(hits []models.TaskSearchHit) []uint {
+	res := make([]uint, 0, len(hits))
+	for _, hit := range hits {
+		res = append(res, hit.Task.ID)
+	}
+	return res
+}
+
+func Test_indexedStorage_Search(t *testing.T) {
+	ctx := context.Background()
+	storer, err := NewIndexedStorage(ctx, &memStorage{tasks: map[uint]models.Task{
+		1: {ID: 1, Title: "Pay invoice 4711"},
+		2: {ID: 2, Title: "Write report"},
+	}}, search.NewIndex())
+	if err != nil {
+		t.Fatalf("NewIndexedStorage() error = %v", err)
+	}
+
+	find := func(query string) []uint {
+		t.Helper()
+		hits, total, err := storer.Search(ctx, models.TaskSearch{Query: query})
+		if err != nil || total != int64(len(hits)) {
+			t.Fatalf("Search(%q) = %v hits, total %v, error %v", query, len(hits), total, err)
+		}
+		return ids(hits)
+	}
+
+	if got := find("invoice"); len(got) != 1 || got[0] != 1 {
+		t.Errorf("tasks stored before should be indexed, got %v", got)
+	}
+
+	if err := storer.Set(ctx, models.Task{ID: 3, Title: "Send invoice"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := find("invoice"); len(got) != 2 {
+		t.Errorf("a task set should be indexed, got %v", got)
+	}
+
+	if err := storer.Delete(ctx, models.Task{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if got := find("invoice"); len(got) != 1 || got[0] != 3 {
+		t.Errorf("a task deleted should not be found, got %v", got)
+	}
+
+	errRollback := errors.New("rollback")
+	err = storer.Atomic(ctx, func(tx TaskStorer) error {
+		_ = tx.Set(ctx, models.Task{ID: 4, Title: "Archive invoice"})
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Atomic() error = %v, want %v", err, errRollback)
+	}
+	if got := find("archive"); len(got) != 0 {
+		t.Errorf("a task of a rolled back transaction should not be indexed, got %v", got)
+	}
+
+	err = storer.Atomic(ctx, func(tx TaskStorer) error {
+		if err := tx.Set(ctx, models.Task{ID: 4, Title: "Archive invoice"}); err != nil {
+			return err
+		}
+		// Not committed yet.
+		if got := find("archive"); len(got) != 0 {
+			t.Errorf("a task should be indexed once committed, got %v", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Atomic() error = %v", err)
+	}
+	if got := find("archive"); len(got) != 1 || got[0] != 4 {
+		t.Errorf("a task of a committed transaction should be indexed, got %v", got)
+	}
+}
@@ -1,26 +1,259 @@
 package taskstorage
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 )
 
-func (s *taskStorage) List(status string) ([]Task, error) {
+// sortColumns maps the sort keys accepted by List to their columns.
+var sortColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"created_by": "created_by",
+	"updated_by": "updated_by",
+	"deleted_at": "deleted_at",
+	"priority":   "priority",
+	"due_at":     "COALESCE(due_at, CAST('9999-12-31 23:59:59' AS DATETIME))",
+}
+
+// timeColumns are the sort keys whose cursor values are timestamps.
+var timeColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+	"due_at":     true,
+}
+
+// intColumns are the sort keys whose cursor values are integers.
+var intColumns = map[string]bool{
+	"priority": true,
+}
+
+func (s *taskStorage) List(ctx context.Context, filter TaskFilter) ([]Task, error) {
 	tasks := make([]Task, 0)
-	rows, err := s.db.Query("SELECT id, title, description, status FROM tasks WHERE status = ?", status)
+	query, args, err := listQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.conn().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("'"+status+"' could not be listed."))
+		return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("tasks could not be listed."))
 	}
 	defer rows.Close()
 	for rows.Next() {
-		task := Task{}
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Status)
+		task, err := scanTask(rows)
 		if err != nil {
-			return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("'"+status+"' could not be listed."))
+			return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("tasks could not be listed."))
 		}
 		tasks = append(tasks, task)
 	}
+	// A connection lost while reading ends the rows early, which must not
+	// pass for the end of the list.
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("tasks could not be listed."))
+	}
 	return tasks, nil
 }
+
+// Count returns the number of tasks matching filter, ignoring its cursor
+// and limit.
+func (s *taskStorage) Count(ctx context.Context, filter TaskFilter) (int64, error) {
+	var total int64
+	conds, args := filterConditions(filter)
+	query := "SELECT COUNT(*) FROM tasks WHERE " + strings.Join(conds, " AND ")
+	if err := s.conn().QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("%w", customerror.ErrGetAll.AddData("tasks could not be counted."))
+	}
+	return total, nil
+}
+
+func listQuery(filter TaskFilter) (string, []any, error) {
+	conds, args := filterConditions(filter)
+
+	key, column := sortColumn(filter)
+	order := "ASC"
+	if strings.EqualFold(filter.SortOrder, "desc") {
+		order = "DESC"
+	}
+
+	if filter.Cursor != "" {
+		cond, cursorArgs, err := cursorCondition(filter.Cursor, key, column, order)
+		if err != nil {
+			return "", nil, err
+		}
+		conds = append(conds, cond)
+		args = append(args, cursorArgs...)
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE " + strings.Join(conds, " AND ")
+	query += " ORDER BY " + column + " " + order
+	if column != "id" {
+		query += ", id " + order
+	}
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	return query, args, nil
+}
+
+func filterConditions(filter TaskFilter) ([]string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	if filter.Deleted {
+		conds = append(conds, "deleted_at IS NOT NULL")
+	} else {
+		conds = append(conds, "deleted_at IS NULL")
+	}
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.Title != "" {
+		conds = append(conds, "title LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Title)+"%")
+	}
+	if filter.Description != "" {
+		conds = append(conds, "description LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Description)+"%")
+	}
+	if filter.IDFrom > 0 {
+		conds = append(conds, "id >= ?")
+		args = append(args, filter.IDFrom)
+	}
+	if filter.IDTo > 0 {
+		conds = append(conds, "id <= ?")
+		args = append(args, filter.IDTo)
+	}
+	if len(filter.Priorities) > 0 {
+		conds = append(conds, "priority IN ("+placeholders(len(filter.Priorities))+")")
+		for _, priority := range filter.Priorities {
+			args = append(args, priority)
+		}
+	}
+	if filter.Assignee != "" {
+		conds = append(conds, "assignee = ?")
+		args = append(args, filter.Assignee)
+	}
+	if !filter.DueAfter.IsZero() {
+		conds = append(conds, "due_at >= ?")
+		args = append(args, filter.DueAfter)
+	}
+	if !filter.DueBefore.IsZero() {
+		conds = append(conds, "due_at < ?")
+		args = append(args, filter.DueBefore)
+	}
+	if len(filter.Tags) > 0 {
+		cond := "id IN (SELECT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN (" + placeholders(len(filter.Tags)) + ")"
+		for _, tag := range filter.Tags {
+			args = append(args, tag)
+		}
+		if filter.TagMode == TagModeAll {
+			cond += " GROUP BY task_tags.task_id HAVING COUNT(DISTINCT tags.id) = ?"
+			args = append(args, len(filter.Tags))
+		}
+		conds = append(conds, cond+")")
+	}
+	if filter.CreatedBy != "" {
+		conds = append(conds, "created_by = ?")
+		args = append(args, filter.CreatedBy)
+	}
+	if filter.UpdatedBy != "" {
+		conds = append(conds, "updated_by = ?")
+		args = append(args, filter.UpdatedBy)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conds = append(conds, "created_at >= ?")
+		args = append(args, filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conds = append(conds, "created_at < ?")
+		args = append(args, filter.CreatedBefore)
+	}
+	if !filter.UpdatedAfter.IsZero() {
+		conds = append(conds, "updated_at >= ?")
+		args = append(args, filter.UpdatedAfter)
+	}
+	if !filter.UpdatedBefore.IsZero() {
+		conds = append(conds, "updated_at < ?")
+		args = append(args, filter.UpdatedBefore)
+	}
+	return conds, args
+}
+
+// placeholders returns n comma separated query placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// likeEscaper escapes the LIKE wildcards so substrings match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// sortColumn returns the sort key of filter and the column it orders by.
+// Live tasks have no deletion date, so sorting them by it falls back to id.
+func sortColumn(filter TaskFilter) (string, string) {
+	key := filter.SortBy
+	column, ok := sortColumns[key]
+	if !ok {
+		key, column = "id", "id"
+	}
+	if column == "deleted_at" && !filter.Deleted {
+		column = "id"
+	}
+	return key, column
+}
+
+// cursorCondition returns the keyset condition selecting the rows after the
+// encoded cursor. The cursor must come from a list with the same ordering.
+func cursorCondition(encoded, key, column, order string) (string, []any, error) {
+	invalid := func() error {
+		return fmt.Errorf("%w", customerror.ErrCursor.AddData("'"+encoded+"' does not belong to this listing."))
+	}
+	cursor, err := DecodeTaskCursor(encoded)
+	if err != nil {
+		return "", nil, invalid()
+	}
+	if cursor.SortBy != key || !strings.EqualFold(cursor.Order, order) {
+		return "", nil, invalid()
+	}
+	op := ">"
+	if order == "DESC" {
+		op = "<"
+	}
+	if column == "id" {
+		return "id " + op + " ?", []any{cursor.ID}, nil
+	}
+	var value any = cursor.Value
+	if timeColumns[key] {
+		t, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return "", nil, invalid()
+		}
+		// Stored times are in UTC; so are cursors, unless made by hand.
+		value = t.UTC()
+	}
+	if intColumns[key] {
+		n, err := strconv.Atoi(cursor.Value)
+		if err != nil {
+			return "", nil, invalid()
+		}
+		value = n
+	}
+	cond := "(" + column + " " + op + " ? OR (" + column + " = ? AND id " + op + " ?))"
+	return cond, []any{value, value, cursor.ID}, nil
+}
@@ -1,9 +1,13 @@
 package taskstorage_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
 )
 
@@ -14,37 +18,228 @@ func Test_taskStorage_List(t *testing.T) {
 	}
 	defer db.Close()
 
+	now := time.Now()
+	columns := []string{"id", "title", "description", "status", "priority", "due_at", "assignee", "parent_id", "created_at", "updated_at", "created_by", "updated_by", "deleted_at", "deleted_by", "tags"}
 	mockStorage := NewTaskStorage(WithTaskDB(db))
-	mock.ExpectQuery("SELECT id, title, description, status FROM tasks WHERE status = ?").
-		WithArgs("active").WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status"}).
-		AddRow(1, "title", "description", "status"))
+	mock.ExpectQuery("SELECT id, title, description, status, priority, due_at, assignee, parent_id, created_at, updated_at, created_by, updated_by, deleted_at, deleted_by, \\(SELECT GROUP_CONCAT\\(tags.name ORDER BY tags.name SEPARATOR ','\\) FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id\\) AS tags FROM tasks WHERE deleted_at IS NULL AND status IN \\(\\?\\) ORDER BY id ASC").
+		WithArgs("active").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(1, "title", "description", "status", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND status IN \\(\\?\\) AND created_by = \\? AND created_at >= \\? ORDER BY updated_at DESC, id DESC").
+		WithArgs("done", "alice", now).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(2, "title", "description", "done", 0, nil, "", nil, now, now, "alice", "bob", nil, "", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND status IN \\(\\?, \\?\\) AND title LIKE \\? AND description LIKE \\? AND id >= \\? AND id <= \\? ORDER BY id ASC").
+		WithArgs("todo", "done", "%50\\%%", "%a\\_b%", 10, 20).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(11, "50% off", "a_b", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(3, "title", "description", "done", 0, nil, "", nil, now, now, "alice", "bob", now, "carol", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND status IN \\(\\?\\) AND id > \\? ORDER BY id ASC LIMIT \\?").
+		WithArgs("active", 4, 2).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(5, "title", "description", "active", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND status IN \\(\\?\\) AND \\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\?").
+		WithArgs("active", now.UTC(), now.UTC(), 7, 10).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(6, "title", "description", "active", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND priority IN \\(\\?, \\?\\) AND assignee = \\? AND due_at >= \\? AND due_at < \\? ORDER BY priority DESC, id DESC").
+		WithArgs(4, 5, "carol", now, now.Add(time.Hour)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(8, "title", "description", "todo", 5, now, "carol", nil, now, now, "alice", "alice", nil, "", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND \\(COALESCE\\(due_at, CAST\\('9999-12-31 23:59:59' AS DATETIME\\)\\) > \\? OR \\(COALESCE\\(due_at, CAST\\('9999-12-31 23:59:59' AS DATETIME\\)\\) = \\? AND id > \\?\\)\\) ORDER BY COALESCE\\(due_at, CAST\\('9999-12-31 23:59:59' AS DATETIME\\)\\) ASC, id ASC LIMIT \\?").
+		WithArgs(models.NoDueAt, models.NoDueAt, 9, 10).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(12, "title", "description", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil))
+	dueAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND \\(COALESCE\\(due_at, (.+)\\) > \\? OR \\(COALESCE\\(due_at, (.+)\\) = \\? AND id > \\?\\)\\) ORDER BY (.+) LIMIT \\?").
+		WithArgs(dueAt, dueAt, 9, 10).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(12, "title", "description", "todo", 0, dueAt, "", nil, now, now, "alice", "alice", nil, "", nil))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND id IN \\(SELECT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN \\(\\?, \\?\\)\\) ORDER BY id ASC").
+		WithArgs("backend", "urgent").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(13, "title", "description", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", "backend"))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND id IN \\(SELECT task_tags.task_id FROM task_tags JOIN tags ON tags.id = task_tags.tag_id WHERE tags.name IN \\(\\?, \\?\\) GROUP BY task_tags.task_id HAVING COUNT\\(DISTINCT tags.id\\) = \\?\\) ORDER BY id ASC").
+		WithArgs("backend", "urgent", 2).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(14, "title", "description", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", "backend,urgent"))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NULL AND status IN \\(\\?\\) ORDER BY id ASC").
+		WithArgs("blocked").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(15, "title", "description", "blocked", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil).
+		AddRow(16, "title", "description", "blocked", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil).
+		RowError(1, errors.New("connection lost")))
 
 	tests := []struct {
 		name    string
-		args    string
+		args    models.TaskFilter
 		wantErr bool
 	}{
 		{
 			name:    "Status is 'active' and no error is expected",
-			args:    "active",
+			args:    models.TaskFilter{Statuses: []string{"active"}},
 			wantErr: false,
 		},
+		{
+			name: "Creator, creation date and sort key are applied and no error is expected",
+			args: models.TaskFilter{
+				Statuses:     []string{"done"},
+				CreatedBy:    "alice",
+				CreatedAfter: now,
+				SortBy:       "updated_at",
+				SortOrder:    "desc",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Statuses, substrings and ID range are applied and no error is expected",
+			args: models.TaskFilter{
+				Statuses:    []string{"todo", "done"},
+				Title:       "50%",
+				Description: "a_b",
+				IDFrom:      10,
+				IDTo:        20,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Trash is listed by deletion date and no error is expected",
+			args:    models.TaskFilter{Deleted: true, SortBy: "deleted_at", SortOrder: "desc"},
+			wantErr: false,
+		},
+		{
+			name: "Cursor and limit are applied and no error is expected",
+			args: models.TaskFilter{
+				Statuses: []string{"active"},
+				Limit:    2,
+				Cursor:   models.NewTaskCursor(models.Task{ID: 4}, "", "").Encode(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cursor on a timestamp is applied and no error is expected",
+			args: models.TaskFilter{
+				Statuses:  []string{"active"},
+				SortBy:    "created_at",
+				SortOrder: "desc",
+				Limit:     10,
+				Cursor:    models.NewTaskCursor(models.Task{ID: 7, CreatedAt: now}, "created_at", "desc").Encode(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Priorities, assignee and due date range are applied and no error is expected",
+			args: models.TaskFilter{
+				Priorities: []int{4, 5},
+				Assignee:   "carol",
+				DueAfter:   now,
+				DueBefore:  now.Add(time.Hour),
+				SortBy:     "priority",
+				SortOrder:  "desc",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cursor on a task without due date is applied and no error is expected",
+			args: models.TaskFilter{
+				SortBy: "due_at",
+				Limit:  10,
+				Cursor: models.NewTaskCursor(models.Task{ID: 9}, "due_at", "asc").Encode(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cursor on a due date in another time zone is compared in UTC and no error is expected",
+			args: models.TaskFilter{
+				SortBy: "due_at",
+				Limit:  10,
+				Cursor: models.TaskCursor{SortBy: "due_at", Order: "asc", Value: "2024-01-02T06:04:05+03:00", ID: 9}.Encode(),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Any of the tags is applied and no error is expected",
+			args:    models.TaskFilter{Tags: []string{"backend", "urgent"}},
+			wantErr: false,
+		},
+		{
+			name:    "All of the tags is applied and no error is expected",
+			args:    models.TaskFilter{Tags: []string{"backend", "urgent"}, TagMode: models.TagModeAll},
+			wantErr: false,
+		},
+		{
+			name:    "Rows ending on an error and error is expected",
+			args:    models.TaskFilter{Statuses: []string{"blocked"}},
+			wantErr: true,
+		},
+		{
+			name: "Cursor from another ordering and error is expected",
+			args: models.TaskFilter{
+				Statuses:  []string{"active"},
+				SortBy:    "updated_at",
+				SortOrder: "asc",
+				Cursor:    models.NewTaskCursor(models.Task{ID: 7, CreatedAt: now}, "created_at", "desc").Encode(),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Malformed cursor and error is expected",
+			args:    models.TaskFilter{Statuses: []string{"active"}, Cursor: "not a cursor"},
+			wantErr: true,
+		},
 		{
 			name:    "Status is 'status' and error is expected",
-			args:    "status",
+			args:    models.TaskFilter{Statuses: []string{"status"}},
 			wantErr: true,
 		},
 		{
 			name:    "Status is empty and error is expected",
-			args:    "",
+			args:    models.TaskFilter{},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if _, err := mockStorage.List(tt.args); (err != nil) != tt.wantErr {
+			if _, err := mockStorage.List(context.Background(), tt.args); (err != nil) != tt.wantErr {
 				t.Errorf("taskStorage.List() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_taskStorage_Count(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE deleted_at IS NULL AND status IN \\(\\?\\)").
+		WithArgs("active").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	tests := []struct {
+		name    string
+		args    models.TaskFilter
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "Cursor and limit are ignored and no error is expected",
+			args: models.TaskFilter{
+				Statuses: []string{"active"},
+				Limit:    2,
+				Cursor:   models.NewTaskCursor(models.Task{ID: 4}, "", "").Encode(),
+			},
+			want:    42,
+			wantErr: false,
+		},
+		{
+			name:    "Query fails and error is expected",
+			args:    models.TaskFilter{Statuses: []string{"done"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mockStorage.Count(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("taskStorage.Count() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("taskStorage.Count() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
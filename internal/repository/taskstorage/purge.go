@@ -0,0 +1,76 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+// Purge permanently removes a task that is in the trash.
+func (s *taskStorage) Purge(ctx context.Context, id uint) error {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		old, err := lockTask(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE id = ? AND deleted_at IS NOT NULL", id); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, id, TaskEventPurged, &old, nil)
+	})
+	_id := strconv.Itoa(int(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the trash."))
+	}
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrPurge.AddData("'"+_id+"' could not be purged."))
+	}
+	return nil
+}
+
+// PurgeDeletedBefore permanently removes every task that was moved to the
+// trash before t and returns the IDs of the removed tasks.
+func (s *taskStorage) PurgeDeletedBefore(ctx context.Context, t time.Time) ([]uint, error) {
+	var ids []uint
+	err := s.inTx(ctx, func(tx dbtx) error {
+		rows, err := tx.QueryContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ? FOR UPDATE", t)
+		if err != nil {
+			return err
+		}
+		var expired []Task
+		for rows.Next() {
+			task, err := scanTask(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			expired = append(expired, task)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for i := range expired {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", expired[i].ID); err != nil {
+				return err
+			}
+			if err := recordEvent(ctx, tx, expired[i].ID, TaskEventPurged, &expired[i], nil); err != nil {
+				return err
+			}
+		}
+		for _, task := range expired {
+			ids = append(ids, task.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrPurge.AddData("trash could not be purged."))
+	}
+	return ids, nil
+}
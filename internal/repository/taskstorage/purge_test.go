@@ -0,0 +1,108 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+func Test_taskStorage_Purge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NOT NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, &now))
+	mock.ExpectExec("DELETE FROM tasks WHERE id = \\? AND deleted_at IS NOT NULL").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(1, models.TaskEventPurged, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NOT NULL FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(taskColumns))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		name    string
+		args    uint
+		wantErr error
+	}{
+		{
+			name:    "Task is in the trash and no error is expected",
+			args:    1,
+			wantErr: nil,
+		},
+		{
+			name:    "Task is not in the trash and not found error is expected",
+			args:    2,
+			wantErr: customerror.ErrIDNotFound,
+		},
+		{
+			name:    "Query fails and purge error is expected",
+			args:    3,
+			wantErr: customerror.ErrPurge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := mockStorage.Purge(context.Background(), tt.args); !errors.Is(err, tt.wantErr) {
+				t.Errorf("taskStorage.Purge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_taskStorage_PurgeDeletedBefore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	cutoff := time.Now().Add(-time.Hour)
+	deletedAt := cutoff.Add(-time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < \\? FOR UPDATE").
+		WithArgs(cutoff).
+		WillReturnRows(sqlmock.NewRows(taskColumns).
+			AddRow(1, "title", "description", "status", 0, nil, "", nil, deletedAt, deletedAt, "alice", "alice", deletedAt, "alice", nil).
+			AddRow(2, "title", "description", "status", 0, nil, "", nil, deletedAt, deletedAt, "alice", "alice", deletedAt, "alice", nil))
+	for _, id := range []int{1, 2} {
+		mock.ExpectExec("DELETE FROM tasks WHERE id = \\?").
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO task_events").
+			WithArgs(id, models.TaskEventPurged, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	ids, err := mockStorage.PurgeDeletedBefore(context.Background(), cutoff)
+	if err != nil {
+		t.Fatalf("taskStorage.PurgeDeletedBefore() error = %v, wantErr %v", err, nil)
+	}
+	if !reflect.DeepEqual(ids, []uint{1, 2}) {
+		t.Errorf("taskStorage.PurgeDeletedBefore() = %v, want %v", ids, []uint{1, 2})
+	}
+	if _, err := mockStorage.PurgeDeletedBefore(context.Background(), cutoff); err == nil {
+		t.Errorf("taskStorage.PurgeDeletedBefore() error = %v, wantErr %v", err, true)
+	}
+}
@@ -0,0 +1,41 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+// Restore takes the task out of the trash.
+func (s *taskStorage) Restore(ctx context.Context, task Task) error {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		old, err := lockTask(ctx, tx, task.ID, true)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET deleted_at = NULL, deleted_by = '', updated_at = ?, updated_by = ? WHERE id = ? AND deleted_at IS NOT NULL",
+			task.UpdatedAt, task.UpdatedBy, task.ID)
+		if err != nil {
+			return err
+		}
+		restored := old
+		restored.DeletedAt = nil
+		restored.DeletedBy = ""
+		restored.UpdatedAt = task.UpdatedAt
+		restored.UpdatedBy = task.UpdatedBy
+		return recordEvent(ctx, tx, task.ID, TaskEventRestored, &old, &restored)
+	})
+	_id := strconv.Itoa(int(task.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the trash."))
+	}
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrRestore.AddData("'"+_id+"' could not be restored."))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+func Test_taskStorage_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NOT NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, &now))
+	mock.ExpectExec("UPDATE tasks SET deleted_at = NULL, deleted_by = '', updated_at = \\?, updated_by = \\? WHERE id = \\? AND deleted_at IS NOT NULL").
+		WithArgs(now, "alice", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(1, models.TaskEventRestored, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NOT NULL FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(taskColumns))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		name    string
+		args    models.Task
+		wantErr error
+	}{
+		{
+			name:    "Task is in the trash and no error is expected",
+			args:    models.Task{ID: 1, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: nil,
+		},
+		{
+			name:    "Task is not in the trash and not found error is expected",
+			args:    models.Task{ID: 2, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: customerror.ErrIDNotFound,
+		},
+		{
+			name:    "Query fails and restore error is expected",
+			args:    models.Task{ID: 3, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: customerror.ErrRestore,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := mockStorage.Restore(context.Background(), tt.args); !errors.Is(err, tt.wantErr) {
+				t.Errorf("taskStorage.Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package taskstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+// match ranks tasks against the query with the FULLTEXT index on title and
+// description.
+const match = "MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+// scoredRow scans a task row followed by its relevance score.
+type scoredRow struct {
+	scanner
+	score *float64
+}
+
+func (r scoredRow) Scan(dest ...any) error {
+	return r.scanner.Scan(append(dest, r.score)...)
+}
+
+func (s *taskStorage) Search(ctx context.Context, query TaskSearch) ([]TaskSearchHit, int64, error) {
+	var total int64
+	if err := s.conn().QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM tasks WHERE deleted_at IS NULL AND "+match,
+		query.Query,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("%w", customerror.ErrSearch.AddData("'"+query.Query+"' could not be searched."))
+	}
+
+	hits := make([]TaskSearchHit, 0)
+	rows, err := s.conn().QueryContext(ctx,
+		"SELECT "+taskColumns+", "+match+" AS score FROM tasks WHERE deleted_at IS NULL AND "+match+
+			" ORDER BY score DESC, id ASC LIMIT ? OFFSET ?",
+		query.Query, query.Query, query.Limit, query.Offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w", customerror.ErrSearch.AddData("'"+query.Query+"' could not be searched."))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hit TaskSearchHit
+		hit.Task, err = scanTask(scoredRow{rows, &hit.Score})
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w", customerror.ErrSearch.AddData("'"+query.Query+"' could not be searched."))
+		}
+		hits = append(hits, hit)
+	}
+	return hits, total, nil
+}
@@ -0,0 +1,54 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+func Test_taskStorage_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	match := "MATCH\\(title, description\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\)"
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE deleted_at IS NULL AND " + match).
+		WithArgs("invoice 4711").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+), "+match+" AS score FROM tasks WHERE deleted_at IS NULL AND "+match+" ORDER BY score DESC, id ASC LIMIT \\? OFFSET \\?").
+		WithArgs("invoice 4711", "invoice 4711", 2, 0).
+		WillReturnRows(sqlmock.NewRows(append(taskColumns, "score")).
+			AddRow(4, "Pay invoice 4711", "description", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil, 1.5).
+			AddRow(9, "Call bank", "about invoice 4711", "todo", 0, nil, "", nil, now, now, "alice", "alice", nil, "", nil, 0.5))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE deleted_at IS NULL AND " + match).
+		WithArgs("broken").
+		WillReturnError(errors.New("fulltext index missing"))
+
+	hits, total, err := mockStorage.Search(context.Background(), models.TaskSearch{Query: "invoice 4711", Limit: 2})
+	if err != nil {
+		t.Fatalf("taskStorage.Search() error = %v, wantErr %v", err, nil)
+	}
+	if total != 3 || len(hits) != 2 {
+		t.Fatalf("taskStorage.Search() = %v hits of %v, want 2 of 3", len(hits), total)
+	}
+	if hits[0].Task.ID != 4 || hits[0].Score != 1.5 {
+		t.Errorf("taskStorage.Search() first hit = %+v", hits[0])
+	}
+
+	if _, _, err := mockStorage.Search(context.Background(), models.TaskSearch{Query: "broken", Limit: 2}); !errors.Is(err, customerror.ErrSearch) {
+		t.Errorf("taskStorage.Search() error = %v, wantErr %v", err, customerror.ErrSearch)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -1,18 +1,42 @@
 package taskstorage
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 )
 
-func (s *taskStorage) Set(task Task) error {
-	_, err := s.db.Exec("INSERT INTO tasks (id, title, description, status) VALUES (?, ?, ?, ?)", task.ID, task.Title, task.Description, task.Status)
+func (s *taskStorage) Set(ctx context.Context, task Task) error {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO tasks (id, title, description, status, priority, due_at, assignee, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			task.ID, task.Title, task.Description, task.Status, task.Priority, task.DueAt, task.Assignee,
+			task.CreatedAt, task.UpdatedAt, task.CreatedBy, task.UpdatedBy)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, task.ID, TaskEventCreated, nil, &task)
+	})
 	_id := strconv.Itoa(int(task.ID))
+	if isDuplicateKey(err) {
+		// The ID may be taken by a task in the trash, which Get does not
+		// see.
+		return fmt.Errorf("%w", customerror.ErrIDExists.AddData("'"+_id+"' already exists in the database."))
+	}
 	if err != nil {
 		return fmt.Errorf("%w", customerror.ErrSet.AddData("'"+_id+"' could not be set."))
 	}
 	return nil
 }
+
+// erDupEntry is the MySQL error of a row breaking a unique key.
+const erDupEntry = 1062
+
+func isDuplicateKey(err error) bool {
+	var myErr *mysql.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == erDupEntry
+}
@@ -1,9 +1,13 @@
 package taskstorage_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
 )
@@ -30,10 +34,25 @@ func Test_taskStorage_Set(t *testing.T) {
 		},
 	}
 	for _, task := range tasks {
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO tasks").
-			WithArgs(task.ID, task.Title, task.Description, task.Status).
+			WithArgs(task.ID, task.Title, task.Description, task.Status, task.Priority, task.DueAt, task.Assignee,
+				task.CreatedAt, task.UpdatedAt, task.CreatedBy, task.UpdatedBy).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").
+			WithArgs(task.ID, models.TaskEventCreated, nil, sqlmock.AnyArg(), "", "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 	}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tasks").
+		WithArgs(3, "test", "test", "test", 0, nil, "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(3, models.TaskEventCreated, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+
 	tests := []struct {
 		name    string
 		args    models.Task
@@ -49,6 +68,11 @@ func Test_taskStorage_Set(t *testing.T) {
 			args:    models.Task{ID: 2, Title: "test", Description: "test", Status: "test"},
 			wantErr: false,
 		},
+		{
+			name:    "History write fails: Error is expected",
+			args:    models.Task{ID: 3, Title: "test", Description: "test", Status: "test"},
+			wantErr: true,
+		},
 		{
 			name:    "Invalid Task: Error is expected",
 			args:    models.Task{ID: 0, Title: "test", Description: "test", Status: "test"},
@@ -57,9 +81,36 @@ func Test_taskStorage_Set(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := mockStorage.Set(tt.args); (err != nil) != tt.wantErr {
+			if err := mockStorage.Set(context.Background(), tt.args); (err != nil) != tt.wantErr {
 				t.Errorf("taskStorage.Set() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_SetTrashedID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	// The task 1 was deleted: it is in the trash and keeps its ID.
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tasks").
+		WithArgs(1, "title", "description", "todo", 0, nil, "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'tasks.PRIMARY'"})
+	mock.ExpectRollback()
+
+	err = mockStorage.Set(context.Background(), models.Task{ID: 1, Title: "title", Description: "description", Status: "todo"})
+	if !errors.Is(err, customerror.ErrIDExists) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrIDExists, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
 }
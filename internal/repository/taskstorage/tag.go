@@ -0,0 +1,156 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+// errTooManyTags reports tags that would leave a task with more than
+// MaxTags.
+var errTooManyTags = errors.New("too many tags")
+
+// AddTags attaches the tags to a live task, creating the tags that do not
+// exist yet. Tags the task already has are left as they are. A task may
+// carry MaxTags at most.
+func (s *taskStorage) AddTags(ctx context.Context, task Task, tags []string) error {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		old, err := lockTask(ctx, tx, task.ID, false)
+		if err != nil {
+			return err
+		}
+		tagged := union(old.Tags, tags)
+		if len(tagged) > MaxTags {
+			return errTooManyTags
+		}
+		args := make([]any, 0, len(tags))
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+		values := strings.TrimSuffix(strings.Repeat("(?), ", len(tags)), ", ")
+		if _, err = tx.ExecContext(ctx, "INSERT IGNORE INTO tags (name) VALUES "+values, args...); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO task_tags (task_id, tag_id) SELECT ?, id FROM tags WHERE name IN ("+placeholders(len(tags))+")",
+			append([]any{task.ID}, args...)...)
+		if err != nil {
+			return err
+		}
+		return touchTags(ctx, tx, old, task, tagged)
+	})
+	_id := strconv.Itoa(int(task.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
+	if errors.Is(err, errTooManyTags) {
+		return fmt.Errorf("%w", customerror.ErrTag.AddData("'"+_id+"' may carry "+strconv.Itoa(MaxTags)+" tags at most."))
+	}
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrTagging.AddData("'"+_id+"' could not be tagged."))
+	}
+	return nil
+}
+
+// RemoveTags detaches the tags from a live task. Tags the task does not
+// have are ignored.
+func (s *taskStorage) RemoveTags(ctx context.Context, task Task, tags []string) error {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		old, err := lockTask(ctx, tx, task.ID, false)
+		if err != nil {
+			return err
+		}
+		args := []any{task.ID}
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ? AND tag_id IN (SELECT id FROM tags WHERE name IN ("+placeholders(len(tags))+"))", args...)
+		if err != nil {
+			return err
+		}
+		return touchTags(ctx, tx, old, task, difference(old.Tags, tags))
+	})
+	_id := strconv.Itoa(int(task.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrTagging.AddData("'"+_id+"' could not be untagged."))
+	}
+	return nil
+}
+
+// TagCounts returns every tag carried by a live task with the number of
+// such tasks, most used first.
+func (s *taskStorage) TagCounts(ctx context.Context) ([]TagCount, error) {
+	counts := make([]TagCount, 0)
+	rows, err := s.conn().QueryContext(ctx, "SELECT tags.name, COUNT(*) AS count FROM tags JOIN task_tags ON task_tags.tag_id = tags.id "+
+		"JOIN tasks ON tasks.id = task_tags.task_id WHERE tasks.deleted_at IS NULL GROUP BY tags.name ORDER BY count DESC, tags.name ASC")
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("tags could not be counted."))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var count TagCount
+		if err := rows.Scan(&count.Name, &count.Count); err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrGetAll.AddData("tags could not be counted."))
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+// touchTags marks the task as updated by task.UpdatedBy and records the
+// change of its tags from old to tags.
+func touchTags(ctx context.Context, tx dbtx, old, task Task, tags []string) error {
+	_, err := tx.ExecContext(ctx, "UPDATE tasks SET updated_at = ?, updated_by = ? WHERE id = ?",
+		task.UpdatedAt, task.UpdatedBy, task.ID)
+	if err != nil {
+		return err
+	}
+	updated := old
+	updated.Tags = tags
+	updated.UpdatedAt = task.UpdatedAt
+	updated.UpdatedBy = task.UpdatedBy
+	return recordEvent(ctx, tx, task.ID, TaskEventUpdated, &old, &updated)
+}
+
+func union(a, b []string) []string {
+	set := make(map[string]bool, len(a)+len(b))
+	for _, tag := range a {
+		set[tag] = true
+	}
+	for _, tag := range b {
+		set[tag] = true
+	}
+	return sortedKeys(set)
+}
+
+func difference(a, b []string) []string {
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[tag] = true
+	}
+	for _, tag := range b {
+		delete(set, tag)
+	}
+	return sortedKeys(set)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
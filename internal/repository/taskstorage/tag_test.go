@@ -0,0 +1,151 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+func Test_taskStorage_AddTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, nil))
+	mock.ExpectExec("INSERT IGNORE INTO tags \\(name\\) VALUES \\(\\?\\), \\(\\?\\)").
+		WithArgs("backend", "urgent").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec("INSERT IGNORE INTO task_tags \\(task_id, tag_id\\) SELECT \\?, id FROM tags WHERE name IN \\(\\?, \\?\\)").
+		WithArgs(1, "backend", "urgent").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE tasks SET updated_at = \\?, updated_by = \\? WHERE id = \\?").
+		WithArgs(now, "alice", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(1, models.TaskEventUpdated, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(taskColumns))
+	mock.ExpectRollback()
+	full := make([]string, models.MaxTags)
+	for i := range full {
+		full[i] = fmt.Sprintf("tag%02d", i)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(taskColumns).
+			AddRow(4, "title", "description", "status", 0, nil, "", nil, now, now, "alice", "alice", nil, "", strings.Join(full, ",")))
+	mock.ExpectRollback()
+
+	tests := []struct {
+		name    string
+		args    models.Task
+		wantErr error
+	}{
+		{
+			name:    "Task exists and no error is expected",
+			args:    models.Task{ID: 1, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: nil,
+		},
+		{
+			name:    "Task does not exist and not found error is expected",
+			args:    models.Task{ID: 2, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: customerror.ErrIDNotFound,
+		},
+		{
+			name:    "Task carries the most tags and tag error is expected",
+			args:    models.Task{ID: 4, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: customerror.ErrTag,
+		},
+		{
+			name:    "Query fails and tagging error is expected",
+			args:    models.Task{ID: 3, UpdatedAt: now, UpdatedBy: "alice"},
+			wantErr: customerror.ErrTagging,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := mockStorage.AddTags(context.Background(), tt.args, []string{"backend", "urgent"}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("taskStorage.AddTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_taskStorage_RemoveTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(taskRows(1, nil))
+	mock.ExpectExec("DELETE FROM task_tags WHERE task_id = \\? AND tag_id IN \\(SELECT id FROM tags WHERE name IN \\(\\?\\)\\)").
+		WithArgs(1, "urgent").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE tasks SET updated_at = \\?, updated_by = \\? WHERE id = \\?").
+		WithArgs(now, "alice", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_events").
+		WithArgs(1, models.TaskEventUpdated, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+
+	err = mockStorage.RemoveTags(context.Background(), models.Task{ID: 1, UpdatedAt: now, UpdatedBy: "alice"}, []string{"urgent"})
+	if !errors.Is(err, customerror.ErrTagging) {
+		t.Errorf("taskStorage.RemoveTags() error = %v, wantErr %v", err, customerror.ErrTagging)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_TagCounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	mock.ExpectQuery("SELECT tags.name, COUNT\\(\\*\\) AS count FROM tags JOIN task_tags (.+) WHERE tasks.deleted_at IS NULL GROUP BY tags.name ORDER BY count DESC, tags.name ASC").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("backend", 3).AddRow("urgent", 1))
+	mock.ExpectQuery("SELECT tags.name").WillReturnError(sqlmock.ErrCancelled)
+
+	counts, err := mockStorage.TagCounts(context.Background())
+	if err != nil {
+		t.Fatalf("taskStorage.TagCounts() error = %v", err)
+	}
+	want := []models.TagCount{{Name: "backend", Count: 3}, {Name: "urgent", Count: 1}}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("taskStorage.TagCounts() = %v, want %v", counts, want)
+	}
+	if _, err := mockStorage.TagCounts(context.Background()); !errors.Is(err, customerror.ErrGetAll) {
+		t.Errorf("taskStorage.TagCounts() error = %v, wantErr %v", err, customerror.ErrGetAll)
+	}
+}
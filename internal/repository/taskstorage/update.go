@@ -1,6 +1,9 @@
 package taskstorage
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -8,9 +11,32 @@ import (
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 )
 
-func (s *taskStorage) Update(task Task) error {
-	_, err := s.db.Exec("UPDATE tasks SET title = ?, description = ?, status = ? WHERE id = ?", task.Title, task.Description, task.Status, task.ID)
+func (s *taskStorage) Update(ctx context.Context, task Task) error {
+	err := s.inTx(ctx, func(tx dbtx) error {
+		old, err := lockTask(ctx, tx, task.ID, false)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET title = ?, description = ?, status = ?, priority = ?, due_at = ?, assignee = ?, updated_at = ?, updated_by = ? WHERE id = ? AND deleted_at IS NULL",
+			task.Title, task.Description, task.Status, task.Priority, task.DueAt, task.Assignee, task.UpdatedAt, task.UpdatedBy, task.ID)
+		if err != nil {
+			return err
+		}
+		updated := old
+		updated.Title = task.Title
+		updated.Description = task.Description
+		updated.Status = task.Status
+		updated.Priority = task.Priority
+		updated.DueAt = task.DueAt
+		updated.Assignee = task.Assignee
+		updated.UpdatedAt = task.UpdatedAt
+		updated.UpdatedBy = task.UpdatedBy
+		return recordEvent(ctx, tx, task.ID, TaskEventUpdated, &old, &updated)
+	})
 	_id := strconv.Itoa(int(task.ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("'"+_id+"' does not exist in the database."))
+	}
 	if err != nil {
 		return fmt.Errorf("%w", customerror.ErrUpdate.AddData("'"+_id+"' could not be updated."))
 	}
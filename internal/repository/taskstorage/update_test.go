@@ -1,11 +1,16 @@
 package taskstorage_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
 func Test_taskStorage_Update(t *testing.T) {
@@ -15,49 +20,77 @@ func Test_taskStorage_Update(t *testing.T) {
 	}
 	defer db.Close()
 	mockStorage := NewTaskStorage(WithTaskDB(db))
+	now := time.Now()
 	tasks := []models.Task{
 		{
 			ID:          1,
 			Title:       "title",
 			Description: "description",
 			Status:      "status",
+			UpdatedAt:   now,
+			UpdatedBy:   "bob",
 		},
 		{
 			ID:          2,
 			Title:       "test",
 			Description: "test",
 			Status:      "test",
+			Priority:    3,
+			DueAt:       &now,
+			Assignee:    "carol",
+			UpdatedAt:   now,
+			UpdatedBy:   "bob",
 		},
 	}
 	for _, task := range tasks {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+			WithArgs(task.ID).
+			WillReturnRows(taskRows(task.ID, nil))
 		mock.ExpectExec("UPDATE tasks").
-			WithArgs(task.Title, task.Description, task.Status, task.ID).
+			WithArgs(task.Title, task.Description, task.Status, task.Priority, task.DueAt, task.Assignee, task.UpdatedAt, task.UpdatedBy, task.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO task_events").
+			WithArgs(task.ID, models.TaskEventUpdated, sqlmock.AnyArg(), sqlmock.AnyArg(), "bob", "req-1", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\? AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(taskColumns))
+	mock.ExpectRollback()
+
 	tests := []struct {
 		name    string
 		args    models.Task
-		wantErr bool
+		wantErr error
 	}{
 		{
 			name:    "Task is valid and no error is expected",
-			args:    models.Task{ID: 1, Title: "title", Description: "description", Status: "status"},
-			wantErr: false,
+			args:    tasks[0],
+			wantErr: nil,
 		},
 		{
 			name:    "Task is valid and no error is expected",
-			args:    models.Task{ID: 2, Title: "test", Description: "test", Status: "test"},
-			wantErr: false,
+			args:    tasks[1],
+			wantErr: nil,
+		},
+		{
+			name:    "Task does not exist and not found error is expected",
+			args:    models.Task{ID: 3, Title: "test", Description: "test", Status: "test"},
+			wantErr: customerror.ErrIDNotFound,
 		},
 		{
 			name:    "Task is invalid and error is expected",
 			args:    models.Task{ID: 0, Title: "test", Description: "test", Status: "test"},
-			wantErr: true,
+			wantErr: customerror.ErrUpdate,
 		},
 	}
+	ctx := util.WithRequestID(util.WithActor(context.Background(), "bob"), "req-1")
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := mockStorage.Update(tt.args); (err != nil) != tt.wantErr {
+			if err := mockStorage.Update(ctx, tt.args); !errors.Is(err, tt.wantErr) {
 				t.Errorf("taskStorage.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
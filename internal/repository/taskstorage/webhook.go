@@ -0,0 +1,184 @@
+package taskstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+const webhookColumns = "id, url, events, secret, enabled, failures, created_by, created_at, updated_at, disabled_at"
+
+const webhookDeliveryColumns = "id, webhook_id, event_id, event_type, task_id, payload, attempt, status_code, error, success, duration_ms, created_at"
+
+// scanWebhook reads a webhook row. Its event types are kept joined by
+// commas; they never contain commas.
+func scanWebhook(row scanner) (Webhook, error) {
+	var (
+		w          Webhook
+		events     string
+		disabledAt sql.NullTime
+	)
+	err := row.Scan(&w.ID, &w.URL, &events, &w.Secret, &w.Enabled, &w.Failures, &w.CreatedBy, &w.CreatedAt, &w.UpdatedAt, &disabledAt)
+	if events != "" {
+		w.Events = strings.Split(events, ",")
+	}
+	if disabledAt.Valid {
+		w.DisabledAt = &disabledAt.Time
+	}
+	return w, err
+}
+
+func scanWebhookDelivery(row scanner) (WebhookDelivery, error) {
+	var d WebhookDelivery
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.TaskID, &d.Payload, &d.Attempt,
+		&d.StatusCode, &d.Error, &d.Success, &d.Duration, &d.CreatedAt)
+	return d, err
+}
+
+// AddWebhook stores a webhook and returns it with its ID.
+func (s *taskStorage) AddWebhook(ctx context.Context, w Webhook) (Webhook, error) {
+	res, err := s.conn().ExecContext(ctx, "INSERT INTO webhooks (url, events, secret, enabled, failures, created_by, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		w.URL, strings.Join(w.Events, ","), w.Secret, w.Enabled, w.Failures, w.CreatedBy, w.CreatedAt, w.UpdatedAt)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("%w", customerror.ErrWebhook.AddData("'"+w.URL+"' could not be added."))
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Webhook{}, fmt.Errorf("%w", customerror.ErrWebhook.AddData("'"+w.URL+"' could not be added."))
+	}
+	w.ID = uint(id)
+	return w, nil
+}
+
+// GetWebhook returns a webhook.
+func (s *taskStorage) GetWebhook(ctx context.Context, id uint) (Webhook, error) {
+	row := s.conn().QueryRowContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id)
+	w, err := scanWebhook(row)
+	_id := strconv.Itoa(int(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Webhook{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("webhook '"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return Webhook{}, fmt.Errorf("%w", customerror.ErrWebhook.AddData("webhook '"+_id+"' could not be read."))
+	}
+	return w, nil
+}
+
+// ListWebhooks returns every webhook, oldest first.
+func (s *taskStorage) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	rows, err := s.conn().QueryContext(ctx, "SELECT "+webhookColumns+" FROM webhooks ORDER BY id ASC")
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrWebhook.AddData("webhooks could not be listed."))
+	}
+	defer rows.Close()
+	webhooks := make([]Webhook, 0)
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrWebhook.AddData("webhooks could not be listed."))
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries.
+func (s *taskStorage) DeleteWebhook(ctx context.Context, id uint) error {
+	res, err := s.conn().ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+	return webhookChanged(res, err, id, "deleted")
+}
+
+// EnableWebhook enables a webhook and clears its failures.
+func (s *taskStorage) EnableWebhook(ctx context.Context, id uint, at time.Time) error {
+	res, err := s.conn().ExecContext(ctx, "UPDATE webhooks SET enabled = TRUE, failures = 0, disabled_at = NULL, updated_at = ? WHERE id = ?", at, id)
+	return webhookChanged(res, err, id, "enabled")
+}
+
+func webhookChanged(res sql.Result, err error, id uint, action string) error {
+	_id := strconv.Itoa(int(id))
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrWebhook.AddData("webhook '"+_id+"' could not be "+action+"."))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w", customerror.ErrWebhook.AddData("webhook '"+_id+"' could not be "+action+"."))
+	}
+	if n == 0 {
+		return fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("webhook '"+_id+"' does not exist in the database."))
+	}
+	return nil
+}
+
+// AddWebhookDelivery logs a delivery attempt and counts it against its
+// webhook: a success clears the failures, and a failure adds one and
+// disables the webhook once it has failed maxFailures times in a row. The
+// webhook is returned as it is afterwards.
+func (s *taskStorage) AddWebhookDelivery(ctx context.Context, d WebhookDelivery, maxFailures int) (Webhook, error) {
+	var w Webhook
+	err := s.inTx(ctx, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, task_id, payload, attempt, status_code, error, success, duration_ms, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			d.WebhookID, d.EventID, d.EventType, d.TaskID, d.Payload, d.Attempt, d.StatusCode, d.Error, d.Success, d.Duration, d.CreatedAt); err != nil {
+			return err
+		}
+		var err error
+		if d.Success {
+			_, err = tx.ExecContext(ctx, "UPDATE webhooks SET failures = 0 WHERE id = ?", d.WebhookID)
+		} else {
+			// MySQL assigns from left to right, so disabled_at and enabled
+			// see the failures already counted.
+			_, err = tx.ExecContext(ctx, "UPDATE webhooks SET failures = failures + 1, "+
+				"disabled_at = IF(enabled AND failures >= ?, ?, disabled_at), enabled = enabled AND failures < ? WHERE id = ?",
+				maxFailures, d.CreatedAt, maxFailures, d.WebhookID)
+		}
+		if err != nil {
+			return err
+		}
+		w, err = scanWebhook(tx.QueryRowContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", d.WebhookID))
+		return err
+	})
+	_id := strconv.Itoa(int(d.WebhookID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Webhook{}, fmt.Errorf("%w", customerror.ErrIDNotFound.AddData("webhook '"+_id+"' does not exist in the database."))
+	}
+	if err != nil {
+		return Webhook{}, fmt.Errorf("%w", customerror.ErrWebhook.AddData("delivery to webhook '"+_id+"' could not be logged."))
+	}
+	return w, nil
+}
+
+// ListWebhookDeliveries returns the latest deliveries of a webhook, newest
+// first, at most limit of them when limit is positive.
+func (s *taskStorage) ListWebhookDeliveries(ctx context.Context, webhookID uint, limit int) ([]WebhookDelivery, error) {
+	if _, err := s.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	_id := strconv.Itoa(int(webhookID))
+	query := "SELECT " + webhookDeliveryColumns + " FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC"
+	args := []any{webhookID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := s.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrWebhook.AddData("deliveries of webhook '"+_id+"' could not be listed."))
+	}
+	defer rows.Close()
+	deliveries := make([]WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrWebhook.AddData("deliveries of webhook '"+_id+"' could not be listed."))
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
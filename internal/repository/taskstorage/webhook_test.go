@@ -0,0 +1,247 @@
+package taskstorage_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+)
+
+var (
+	webhookColumns         = []string{"id", "url", "events", "secret", "enabled", "failures", "created_by", "created_at", "updated_at", "disabled_at"}
+	webhookDeliveryColumns = []string{"id", "webhook_id", "event_id", "event_type", "task_id", "payload", "attempt", "status_code", "error", "success", "duration_ms", "created_at"}
+)
+
+func Test_taskStorage_AddWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	w := models.Webhook{URL: "http://example.com/hook", Events: []string{"created", "deleted"}, Secret: "secret", Enabled: true, CreatedBy: "alice", CreatedAt: now, UpdatedAt: now}
+	mock.ExpectExec("INSERT INTO webhooks \\(url, events, secret, enabled, failures, created_by, created_at, updated_at\\) VALUES \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?\\)").
+		WithArgs("http://example.com/hook", "created,deleted", "secret", true, 0, "alice", now, now).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+
+	got, err := mockStorage.AddWebhook(context.Background(), w)
+	if err != nil {
+		t.Fatalf("taskStorage.AddWebhook() error = %v", err)
+	}
+	if got.ID != 4 {
+		t.Errorf("taskStorage.AddWebhook() id = %v, want %v", got.ID, 4)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_GetWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	want := models.Webhook{ID: 4, URL: "http://example.com/hook", Events: []string{"created"}, Secret: "secret", Failures: 5, CreatedBy: "alice", CreatedAt: now, UpdatedAt: now, DisabledAt: &now}
+	mock.ExpectQuery("SELECT (.+) FROM webhooks WHERE id = \\?").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(4, "http://example.com/hook", "created", "secret", false, 5, "alice", now, now, now))
+	mock.ExpectQuery("SELECT (.+) FROM webhooks WHERE id = \\?").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(webhookColumns))
+
+	got, err := mockStorage.GetWebhook(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("taskStorage.GetWebhook() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("taskStorage.GetWebhook() = %v, want %v", got, want)
+	}
+	if _, err := mockStorage.GetWebhook(context.Background(), 5); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.GetWebhook() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_ListWebhooks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM webhooks ORDER BY id ASC").
+		WillReturnRows(sqlmock.NewRows(webhookColumns).
+			AddRow(1, "http://a.example.com", "", "a", true, 0, "alice", now, now, nil).
+			AddRow(2, "http://b.example.com", "updated", "b", true, 1, "bob", now, now, nil))
+
+	got, err := mockStorage.ListWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("taskStorage.ListWebhooks() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Events != nil || !reflect.DeepEqual(got[1].Events, []string{"updated"}) {
+		t.Errorf("taskStorage.ListWebhooks() = %v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_DeleteWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	mock.ExpectExec("DELETE FROM webhooks WHERE id = \\?").
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM webhooks WHERE id = \\?").
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := mockStorage.DeleteWebhook(context.Background(), 4); err != nil {
+		t.Errorf("taskStorage.DeleteWebhook() error = %v", err)
+	}
+	if err := mockStorage.DeleteWebhook(context.Background(), 5); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.DeleteWebhook() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_EnableWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectExec("UPDATE webhooks SET enabled = TRUE, failures = 0, disabled_at = NULL, updated_at = \\? WHERE id = \\?").
+		WithArgs(now, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE webhooks SET enabled = TRUE").
+		WithArgs(now, 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := mockStorage.EnableWebhook(context.Background(), 4, now); err != nil {
+		t.Errorf("taskStorage.EnableWebhook() error = %v", err)
+	}
+	if err := mockStorage.EnableWebhook(context.Background(), 5, now); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.EnableWebhook() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_AddWebhookDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	failed := models.WebhookDelivery{WebhookID: 4, EventID: 9, EventType: "created", TaskID: 1, Payload: []byte("{}"), Attempt: 5, StatusCode: 500, Error: "500 Internal Server Error", Duration: 3, CreatedAt: now}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO webhook_deliveries").
+		WithArgs(4, 9, "created", 1, []byte("{}"), 5, 500, "500 Internal Server Error", false, 3, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE webhooks SET failures = failures \\+ 1, disabled_at = IF\\(enabled AND failures >= \\?, \\?, disabled_at\\), enabled = enabled AND failures < \\? WHERE id = \\?").
+		WithArgs(5, now, 5, 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM webhooks WHERE id = \\?").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(4, "http://example.com/hook", "", "secret", false, 5, "alice", now, now, now))
+	mock.ExpectCommit()
+
+	succeeded := failed
+	succeeded.Success, succeeded.StatusCode, succeeded.Error = true, 200, ""
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO webhook_deliveries").
+		WithArgs(4, 9, "created", 1, []byte("{}"), 5, 200, "", true, 3, now).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec("UPDATE webhooks SET failures = 0 WHERE id = \\?").
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM webhooks WHERE id = \\?").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(4, "http://example.com/hook", "", "secret", true, 0, "alice", now, now, nil))
+	mock.ExpectCommit()
+
+	got, err := mockStorage.AddWebhookDelivery(context.Background(), failed, 5)
+	if err != nil {
+		t.Fatalf("taskStorage.AddWebhookDelivery() error = %v", err)
+	}
+	if got.Enabled || got.Failures != 5 || got.DisabledAt == nil {
+		t.Errorf("taskStorage.AddWebhookDelivery() = %v, want the webhook disabled", got)
+	}
+	got, err = mockStorage.AddWebhookDelivery(context.Background(), succeeded, 5)
+	if err != nil {
+		t.Fatalf("taskStorage.AddWebhookDelivery() error = %v", err)
+	}
+	if !got.Enabled || got.Failures != 0 {
+		t.Errorf("taskStorage.AddWebhookDelivery() = %v, want the failures cleared", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_taskStorage_ListWebhookDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mockStorage := NewTaskStorage(WithTaskDB(db))
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM webhooks WHERE id = \\?").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(4, "http://example.com/hook", "", "secret", true, 0, "alice", now, now, nil))
+	mock.ExpectQuery("SELECT (.+) FROM webhook_deliveries WHERE webhook_id = \\? ORDER BY id DESC LIMIT \\?").
+		WithArgs(4, 10).
+		WillReturnRows(sqlmock.NewRows(webhookDeliveryColumns).
+			AddRow(2, 4, 9, "created", 1, []byte("{}"), 2, 200, "", true, 3, now).
+			AddRow(1, 4, 9, "created", 1, []byte("{}"), 1, 0, "timeout", false, 10000, now))
+	mock.ExpectQuery("SELECT (.+) FROM webhooks WHERE id = \\?").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(webhookColumns))
+
+	got, err := mockStorage.ListWebhookDeliveries(context.Background(), 4, 10)
+	if err != nil {
+		t.Fatalf("taskStorage.ListWebhookDeliveries() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 2 || !got[0].Success || got[1].Error != "timeout" {
+		t.Errorf("taskStorage.ListWebhookDeliveries() = %v", got)
+	}
+	if _, err := mockStorage.ListWebhookDeliveries(context.Background(), 5, 10); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("taskStorage.ListWebhookDeliveries() error = %v, wantErr %v", err, customerror.ErrIDNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
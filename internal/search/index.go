@@ -0,0 +1,119 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+// titleWeight makes a term found in the title count more than one found in
+// the description.
+const titleWeight = 2
+
+// Index is a concurrency-safe in-memory inverted index over task titles and
+// descriptions, ranking hits by TF-IDF. Backends keep it in sync by calling
+// Add on every write and Remove when a task leaves the live set.
+type Index struct {
+	mu       sync.RWMutex
+	tasks    map[uint]Task
+	postings map[string]map[uint]float64
+}
+
+func NewIndex() *Index {
+	return &Index{
+		tasks:    make(map[uint]Task),
+		postings: make(map[string]map[uint]float64),
+	}
+}
+
+// Add indexes task, replacing any earlier version of it.
+func (i *Index) Add(task Task) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(task.ID)
+	i.tasks[task.ID] = task
+	weights := make(map[string]float64)
+	for _, term := range Tokenize(task.Title) {
+		weights[term] += titleWeight
+	}
+	for _, term := range Tokenize(task.Description) {
+		weights[term]++
+	}
+	for term, w := range weights {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[uint]float64)
+		}
+		i.postings[term][task.ID] = w
+	}
+}
+
+// Remove drops the task with the given ID from the index.
+func (i *Index) Remove(id uint) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(id)
+}
+
+func (i *Index) remove(id uint) {
+	task, ok := i.tasks[id]
+	if !ok {
+		return
+	}
+	delete(i.tasks, id)
+	for _, term := range append(Tokenize(task.Title), Tokenize(task.Description)...) {
+		delete(i.postings[term], id)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+}
+
+// Search returns the requested page of tasks matching any term of the
+// query, best first, and the total number of matches.
+func (i *Index) Search(query TaskSearch) ([]TaskSearchHit, int64) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	scores := make(map[uint]float64)
+	seen := make(map[string]bool)
+	n := float64(len(i.tasks))
+	for _, term := range Tokenize(query.Query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		posting := i.postings[term]
+		if len(posting) == 0 {
+			continue
+		}
+		idf := math.Log(1 + n/float64(len(posting)))
+		for id, w := range posting {
+			scores[id] += w * idf
+		}
+	}
+
+	hits := make([]TaskSearchHit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, TaskSearchHit{Task: i.tasks[id], Score: score})
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].Task.ID < hits[b].Task.ID
+	})
+
+	total := int64(len(hits))
+	if query.Offset >= len(hits) {
+		return []TaskSearchHit{}, total
+	}
+	hits = hits[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(hits) {
+		hits = hits[:query.Limit]
+	}
+	return hits, total
+}
@@ -0,0 +1,75 @@
+package search_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/search"
+)
+
+func TestIndexSearch(t *testing.T) {
+	index := NewIndex()
+	index.Add(models.Task{ID: 1, Title: "Pay invoice 4711", Description: "Accounting asked twice"})
+	index.Add(models.Task{ID: 2, Title: "Call the bank", Description: "Ask about invoice 4711"})
+	index.Add(models.Task{ID: 3, Title: "Write report", Description: "Quarterly numbers"})
+
+	hits, total := index.Search(models.TaskSearch{Query: "invoice 4711"})
+	if total != 2 {
+		t.Fatalf("Search() total = %v, want %v", total, 2)
+	}
+	if hits[0].Task.ID != 1 || hits[1].Task.ID != 2 {
+		t.Errorf("Search() ranked %v before %v, want the title match first", hits[0].Task.ID, hits[1].Task.ID)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Errorf("Search() scores = %v, %v, want descending", hits[0].Score, hits[1].Score)
+	}
+
+	page, total := index.Search(models.TaskSearch{Query: "invoice", Offset: 1, Limit: 1})
+	if total != 2 || len(page) != 1 || page[0].Task.ID != 2 {
+		t.Errorf("Search() page = %+v total %v, want task 2 of 2", page, total)
+	}
+
+	if hits, total := index.Search(models.TaskSearch{Query: "holiday"}); total != 0 || len(hits) != 0 {
+		t.Errorf("Search() = %+v total %v, want no hits", hits, total)
+	}
+}
+
+func TestIndexUpdateAndRemove(t *testing.T) {
+	index := NewIndex()
+	index.Add(models.Task{ID: 1, Title: "Pay invoice", Description: "today"})
+	index.Add(models.Task{ID: 1, Title: "Pay rent", Description: "today"})
+
+	if _, total := index.Search(models.TaskSearch{Query: "invoice"}); total != 0 {
+		t.Errorf("Search() found the replaced title, total = %v", total)
+	}
+	if _, total := index.Search(models.TaskSearch{Query: "rent"}); total != 1 {
+		t.Errorf("Search() total = %v, want %v", total, 1)
+	}
+
+	index.Remove(1)
+	if _, total := index.Search(models.TaskSearch{Query: "today"}); total != 0 {
+		t.Errorf("Search() found a removed task, total = %v", total)
+	}
+}
+
+func TestIndexConcurrentUse(t *testing.T) {
+	index := NewIndex()
+	wg := sync.WaitGroup{}
+	for i := 1; i <= 50; i++ {
+		wg.Add(2)
+		go func(id uint) {
+			defer wg.Done()
+			index.Add(models.Task{ID: id, Title: "concurrent task", Description: "body"})
+		}(uint(i))
+		go func() {
+			defer wg.Done()
+			index.Search(models.TaskSearch{Query: "concurrent"})
+		}()
+	}
+	wg.Wait()
+
+	if _, total := index.Search(models.TaskSearch{Query: "concurrent"}); total != 50 {
+		t.Errorf("Search() total = %v, want %v", total, 50)
+	}
+}
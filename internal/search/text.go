@@ -0,0 +1,82 @@
+// Package search holds the backend independent parts of full-text task
+// search: tokenizing, highlighting and an in-process inverted index for
+// storage backends without full-text support of their own.
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	markOpen  = "<mark>"
+	markClose = "</mark>"
+)
+
+type token struct {
+	term       string
+	start, end int
+}
+
+func tokens(s string) []token {
+	var (
+		out   []token
+		start = -1
+	)
+	for i, r := range s {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		}
+		if !word && start >= 0 {
+			out = append(out, token{strings.ToLower(s[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		out = append(out, token{strings.ToLower(s[start:]), start, len(s)})
+	}
+	return out
+}
+
+// Tokenize splits s into lower-cased words made of letters and digits.
+func Tokenize(s string) []string {
+	toks := tokens(s)
+	terms := make([]string, 0, len(toks))
+	for _, t := range toks {
+		terms = append(terms, t.term)
+	}
+	return terms
+}
+
+// Highlight returns text, HTML escaped, with every word matching one of the
+// query's terms wrapped in <mark> tags. It returns an empty string when
+// nothing matches.
+func Highlight(text, query string) string {
+	terms := make(map[string]bool)
+	for _, term := range Tokenize(query) {
+		terms[term] = true
+	}
+	var (
+		b       strings.Builder
+		last    int
+		matched bool
+	)
+	for _, t := range tokens(text) {
+		if !terms[t.term] {
+			continue
+		}
+		matched = true
+		b.WriteString(html.EscapeString(text[last:t.start]))
+		b.WriteString(markOpen)
+		b.WriteString(html.EscapeString(text[t.start:t.end]))
+		b.WriteString(markClose)
+		last = t.end
+	}
+	if !matched {
+		return ""
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package search_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/search"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Pay invoice #4711, ASAP! Ödeme-günü")
+	want := []string{"pay", "invoice", "4711", "asap", "ödeme", "günü"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		want  string
+	}{
+		{
+			name:  "Matching words are marked case-insensitively",
+			text:  "Invoice 4711 for ACME",
+			query: "invoice 4711",
+			want:  "<mark>Invoice</mark> <mark>4711</mark> for ACME",
+		},
+		{
+			name:  "Text around the matches is escaped",
+			text:  "<b>invoice</b> & co",
+			query: "invoice",
+			want:  "&lt;b&gt;<mark>invoice</mark>&lt;/b&gt; &amp; co",
+		},
+		{
+			name:  "Partial words are not marked",
+			text:  "invoices are due",
+			query: "invoice",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.text, tt.query); got != tt.want {
+				t.Errorf("Highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
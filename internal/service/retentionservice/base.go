@@ -0,0 +1,86 @@
+package retentionservice
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+)
+
+type trashRetention struct {
+	maxAge   time.Duration
+	interval time.Duration
+	timeout  time.Duration
+	logger   *slog.Logger
+	service  taskservice.TaskService
+	done     chan struct{}
+	Wg       *sync.WaitGroup
+}
+
+type TrashRetentionOption func(*trashRetention)
+
+// WithMaxAge sets how long deleted tasks are kept in the trash.
+func WithMaxAge(d time.Duration) TrashRetentionOption {
+	return func(t *trashRetention) {
+		t.maxAge = d
+	}
+}
+
+// WithInterval sets how often the trash is checked for expired tasks.
+func WithInterval(d time.Duration) TrashRetentionOption {
+	return func(t *trashRetention) {
+		t.interval = d
+	}
+}
+
+func WithTimeout(d time.Duration) TrashRetentionOption {
+	return func(t *trashRetention) {
+		t.timeout = d
+	}
+}
+
+func WithLogger(l *slog.Logger) TrashRetentionOption {
+	return func(t *trashRetention) {
+		t.logger = l
+	}
+}
+
+func WithService(service taskservice.TaskService) TrashRetentionOption {
+	return func(t *trashRetention) {
+		t.service = service
+	}
+}
+
+func WithWaitGroup(wg *sync.WaitGroup) TrashRetentionOption {
+	return func(t *trashRetention) {
+		t.Wg = wg
+	}
+}
+
+func WithDone(done chan struct{}) TrashRetentionOption {
+	return func(t *trashRetention) {
+		t.done = done
+	}
+}
+
+// StartTrashRetention purges expired tasks from the trash in the background
+// until done is closed. It does nothing when the max age or interval is not
+// positive.
+func StartTrashRetention(opts ...TrashRetentionOption) {
+	tr := &trashRetention{
+		timeout: 30 * time.Second,
+		logger:  slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		Wg:      &sync.WaitGroup{},
+	}
+	for _, opt := range opts {
+		opt(tr)
+	}
+	if tr.maxAge <= 0 || tr.interval <= 0 {
+		tr.logger.Info("trash retention disabled")
+		return
+	}
+	tr.Wg.Add(1)
+	go tr.run()
+}
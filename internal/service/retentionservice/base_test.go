@@ -0,0 +1,29 @@
+package retentionservice_test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+type mockTaskService struct {
+	taskservice.TaskService
+	mu       sync.Mutex
+	requests []dto.PurgeTrashRequest
+	purgeErr error
+}
+
+func (m *mockTaskService) PurgeTrash(_ context.Context, req dto.PurgeTrashRequest) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, req)
+	return 1, m.purgeErr
+}
+
+func (m *mockTaskService) calls() []dto.PurgeTrashRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]dto.PurgeTrashRequest(nil), m.requests...)
+}
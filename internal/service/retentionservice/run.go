@@ -0,0 +1,47 @@
+package retentionservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// actor is recorded in the task history for purges done by the retention job.
+const actor = "system:trash-retention"
+
+func (t *trashRetention) run() {
+	defer t.Wg.Done()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	t.purge()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+			t.purge()
+		}
+	}
+}
+
+func (t *trashRetention) purge() {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+	ctx = util.WithActor(ctx, actor)
+
+	req := dto.PurgeTrashRequest{
+		Before: time.Now().UTC().Add(-t.maxAge),
+	}
+	n, err := t.service.PurgeTrash(ctx, req)
+	if err != nil {
+		t.logger.Error("trash retention purge failed", "err", err)
+		return
+	}
+	if n > 0 {
+		t.logger.Info("trash retention purged tasks", "count", n, "before", req.Before)
+	}
+}
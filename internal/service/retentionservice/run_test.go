@@ -0,0 +1,88 @@
+package retentionservice_test
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/retentionservice"
+)
+
+var logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+func TestTrashRetentionPurgesExpired(t *testing.T) {
+	mockService := &mockTaskService{}
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	maxAge := 24 * time.Hour
+
+	start := time.Now().UTC()
+	StartTrashRetention(
+		WithMaxAge(maxAge),
+		WithInterval(10*time.Millisecond),
+		WithService(mockService),
+		WithLogger(logger),
+		WithWaitGroup(wg),
+		WithDone(doneCh),
+	)
+	time.Sleep(50 * time.Millisecond)
+	close(doneCh)
+	wg.Wait()
+
+	calls := mockService.calls()
+	if len(calls) < 2 {
+		t.Fatalf("expected at least %v purges, got: %v", 2, len(calls))
+	}
+	if cutoff := calls[0].Before; cutoff.After(start.Add(-maxAge).Add(time.Second)) || cutoff.Before(start.Add(-maxAge).Add(-time.Second)) {
+		t.Errorf("expected cutoff near: %v, got: %v", start.Add(-maxAge), cutoff)
+	}
+}
+
+func TestTrashRetentionKeepsRunningOnError(t *testing.T) {
+	mockService := &mockTaskService{
+		purgeErr: errors.New("purge error"),
+	}
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+
+	StartTrashRetention(
+		WithMaxAge(time.Hour),
+		WithInterval(10*time.Millisecond),
+		WithService(mockService),
+		WithLogger(logger),
+		WithWaitGroup(wg),
+		WithDone(doneCh),
+	)
+	time.Sleep(50 * time.Millisecond)
+	close(doneCh)
+	wg.Wait()
+
+	if calls := mockService.calls(); len(calls) < 2 {
+		t.Errorf("expected at least %v purges, got: %v", 2, len(calls))
+	}
+}
+
+func TestTrashRetentionDisabled(t *testing.T) {
+	mockService := &mockTaskService{}
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+
+	StartTrashRetention(
+		WithMaxAge(0),
+		WithInterval(10*time.Millisecond),
+		WithService(mockService),
+		WithLogger(logger),
+		WithWaitGroup(wg),
+		WithDone(doneCh),
+	)
+	time.Sleep(30 * time.Millisecond)
+	close(doneCh)
+	wg.Wait()
+
+	if calls := mockService.calls(); len(calls) != 0 {
+		t.Errorf("expected no purges, got: %v", len(calls))
+	}
+}
@@ -0,0 +1,200 @@
+package taskservice
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/blobstore"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// attachmentTypes are the content types files may have to be attached.
+// The type is sniffed from the contents, not taken from the client.
+var attachmentTypes = map[string]bool{
+	"image/png":          true,
+	"image/jpeg":         true,
+	"image/gif":          true,
+	"image/webp":         true,
+	"text/plain":         true,
+	"application/pdf":    true,
+	"application/zip":    true,
+	"application/x-gzip": true,
+}
+
+// Attach stores the file in the blob store and records it on the task. The
+// blob is removed again if the task cannot take it.
+func (s *taskService) Attach(ctx context.Context, req dto.AttachRequest) (dto.AttachmentResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.AttachmentResponse{}, ctx.Err()
+	default:
+		if s.blobs == nil {
+			return dto.AttachmentResponse{}, fmt.Errorf("service.Attach: %w", customerror.ErrAttachment.AddData("attachments are not configured."))
+		}
+		content := bufio.NewReader(req.Content)
+		head, err := content.Peek(512)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return dto.AttachmentResponse{}, fmt.Errorf("service.Attach: %w", customerror.ErrAttachment.AddData("file could not be read."))
+		}
+		contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+		if !attachmentTypes[contentType] {
+			return dto.AttachmentResponse{}, fmt.Errorf("service.Attach: %w", customerror.ErrFileType.AddData("'"+contentType+"' files cannot be attached."))
+		}
+		key, err := attachmentKey(req.TaskID)
+		if err != nil {
+			return dto.AttachmentResponse{}, fmt.Errorf("service.Attach: %w", customerror.ErrAttachment.AddData("file could not be named."))
+		}
+		size, err := s.blobs.Put(ctx, key, io.LimitReader(content, dto.MaxAttachmentSize+1))
+		if err != nil {
+			return dto.AttachmentResponse{}, fmt.Errorf("service.Attach blobs.Put: %w", customerror.ErrAttachment.AddData("file could not be stored."))
+		}
+		if size > dto.MaxAttachmentSize {
+			s.dropBlob(ctx, key)
+			return dto.AttachmentResponse{}, fmt.Errorf("service.Attach: %w", customerror.ErrFileSize.AddData("files are limited to "+strconv.Itoa(dto.MaxAttachmentSize)+" bytes."))
+		}
+		attachment, err := s.taskStorage.AddAttachment(ctx, models.Attachment{
+			TaskID:      req.TaskID,
+			Name:        attachmentName(req.Name),
+			ContentType: contentType,
+			Size:        size,
+			Key:         key,
+			CreatedBy:   util.ActorFromContext(ctx),
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			s.dropBlob(ctx, key)
+			return dto.AttachmentResponse{}, fmt.Errorf("service.Attach storage.AddAttachment: %w", err)
+		}
+		return dto.NewAttachmentResponse(attachment), nil
+	}
+}
+
+// Download opens the contents of an attachment of a live task.
+func (s *taskService) Download(ctx context.Context, req dto.GetAttachmentRequest) (dto.AttachmentDownload, error) {
+	select {
+	case <-ctx.Done():
+		return dto.AttachmentDownload{}, ctx.Err()
+	default:
+		if s.blobs == nil {
+			return dto.AttachmentDownload{}, fmt.Errorf("service.Download: %w", customerror.ErrAttachment.AddData("attachments are not configured."))
+		}
+		attachment, err := s.taskStorage.GetAttachment(ctx, req.ID)
+		if err != nil {
+			return dto.AttachmentDownload{}, fmt.Errorf("service.Download storage.GetAttachment: %w", err)
+		}
+		content, err := s.blobs.Get(ctx, attachment.Key)
+		_id := strconv.Itoa(int(req.ID))
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return dto.AttachmentDownload{}, fmt.Errorf("service.Download blobs.Get: %w", customerror.ErrIDNotFound.AddData("contents of attachment '"+_id+"' are gone."))
+		}
+		if err != nil {
+			return dto.AttachmentDownload{}, fmt.Errorf("service.Download blobs.Get: %w", customerror.ErrAttachment.AddData("contents of attachment '"+_id+"' could not be read."))
+		}
+		return dto.AttachmentDownload{
+			Attachment: dto.NewAttachmentResponse(attachment),
+			Content:    content,
+		}, nil
+	}
+}
+
+// Detach removes an attachment from a live task and drops its contents.
+func (s *taskService) Detach(ctx context.Context, req dto.DeleteAttachmentRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		attachment, err := s.taskStorage.GetAttachment(ctx, req.ID)
+		if err != nil {
+			return fmt.Errorf("service.Detach storage.GetAttachment: %w", err)
+		}
+		if err := s.taskStorage.DeleteAttachment(ctx, req.ID); err != nil {
+			return fmt.Errorf("service.Detach storage.DeleteAttachment: %w", err)
+		}
+		s.dropBlob(ctx, attachment.Key)
+		return nil
+	}
+}
+
+func (s *taskService) Attachments(ctx context.Context, req dto.ListAttachmentsRequest) ([]dto.AttachmentResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		attachments, err := s.taskStorage.ListAttachments(ctx, req.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("service.Attachments storage.ListAttachments: %w", err)
+		}
+		res := make([]dto.AttachmentResponse, 0, len(attachments))
+		for _, attachment := range attachments {
+			res = append(res, dto.NewAttachmentResponse(attachment))
+		}
+		return res, nil
+	}
+}
+
+// dropBlob removes a blob that is no longer referenced. A failure only
+// leaves garbage behind, so it is logged instead of failing the request.
+func (s *taskService) dropBlob(ctx context.Context, key string) {
+	if s.blobs == nil {
+		return
+	}
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		s.logger.ErrorContext(ctx, "service blobs.Delete", "key", key, "err", err)
+	}
+}
+
+// dropAttachments removes the contents of every attachment of a purged
+// task. Their metadata goes with the task.
+func (s *taskService) dropAttachments(ctx context.Context, taskID uint) {
+	if s.blobs == nil {
+		return
+	}
+	prefix := attachmentPrefix(taskID)
+	if err := s.blobs.DeletePrefix(ctx, prefix); err != nil {
+		s.logger.ErrorContext(ctx, "service blobs.DeletePrefix", "prefix", prefix, "err", err)
+	}
+}
+
+// attachmentPrefix groups the blobs of a task so they can be dropped
+// together when it is purged.
+func attachmentPrefix(taskID uint) string {
+	return "tasks/" + strconv.Itoa(int(taskID)) + "/"
+}
+
+func attachmentKey(taskID uint) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return attachmentPrefix(taskID) + hex.EncodeToString(b), nil
+}
+
+// attachmentName strips the directories and control characters some
+// clients send along with the file name.
+func attachmentName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" || name == "" {
+		return "file"
+	}
+	return name
+}
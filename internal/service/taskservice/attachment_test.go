@@ -0,0 +1,136 @@
+package taskservice_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/blobstore"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestAttach(t *testing.T) {
+	blobs := blobstore.NewLocal(blobstore.WithRoot(t.TempDir()))
+	mockTaskStorage := &mockTaskStorage{attachment: Attachment{ID: 3}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithBlobStore(blobs))
+
+	ctx := util.WithActor(context.Background(), "alice")
+	res, err := taskService.Attach(ctx, dto.AttachRequest{TaskID: 1, Name: `C:\logs\server.log`, Content: strings.NewReader("line one\nline two\n")})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if res.ID != 3 || res.Name != "server.log" || res.ContentType != "text/plain" || res.Size != 18 || res.CreatedBy != "alice" {
+		t.Errorf("unexpected attachment: %+v", res)
+	}
+	key := mockTaskStorage.attached[0].Key
+	if !strings.HasPrefix(key, "tasks/1/") {
+		t.Errorf("unexpected blob key: %v", key)
+	}
+	content, err := blobs.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("blob was not stored: %v", err)
+	}
+	b, _ := io.ReadAll(content)
+	content.Close()
+	if string(b) != "line one\nline two\n" {
+		t.Errorf("unexpected blob: %q", b)
+	}
+}
+
+func TestAttachRejectsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content io.Reader
+		wantErr error
+	}{
+		{"executable", bytes.NewReader([]byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff")), customerror.ErrFileType},
+		{"too large", io.MultiReader(strings.NewReader("text"), bytes.NewReader(bytes.Repeat([]byte("a"), dto.MaxAttachmentSize))), customerror.ErrFileSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			mockTaskStorage := &mockTaskStorage{}
+			taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithBlobStore(blobstore.NewLocal(blobstore.WithRoot(root))))
+
+			_, err := taskService.Attach(context.Background(), dto.AttachRequest{TaskID: 1, Name: "file", Content: tt.content})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if len(mockTaskStorage.attached) != 0 {
+				t.Errorf("attachment should not be stored: %+v", mockTaskStorage.attached)
+			}
+		})
+	}
+}
+
+func TestAttachDropsBlobOnStorageError(t *testing.T) {
+	blobs := blobstore.NewLocal(blobstore.WithRoot(t.TempDir()))
+	mockTaskStorage := &mockTaskStorage{attachErr: customerror.ErrIDNotFound}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithBlobStore(blobs))
+
+	_, err := taskService.Attach(context.Background(), dto.AttachRequest{TaskID: 1, Name: "log.txt", Content: strings.NewReader("log")})
+	if !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Fatalf("expected error: %v, got: %v", customerror.ErrIDNotFound, err)
+	}
+	if _, err := blobs.Get(context.Background(), mockTaskStorage.attached[0].Key); !errors.Is(err, blobstore.ErrNotFound) {
+		t.Errorf("blob should be dropped, got: %v", err)
+	}
+}
+
+func TestDownloadAndDetach(t *testing.T) {
+	blobs := blobstore.NewLocal(blobstore.WithRoot(t.TempDir()))
+	if _, err := blobs.Put(context.Background(), "tasks/1/ab", strings.NewReader("log")); err != nil {
+		t.Fatal(err)
+	}
+	mockTaskStorage := &mockTaskStorage{attachment: Attachment{ID: 3, TaskID: 1, Name: "log.txt", Key: "tasks/1/ab"}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithBlobStore(blobs))
+
+	res, err := taskService.Download(context.Background(), dto.GetAttachmentRequest{ID: 3})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	b, _ := io.ReadAll(res.Content)
+	res.Content.Close()
+	if string(b) != "log" || res.Attachment.Name != "log.txt" {
+		t.Errorf("unexpected download: %+v %q", res.Attachment, b)
+	}
+
+	if err := taskService.Detach(context.Background(), dto.DeleteAttachmentRequest{ID: 3}); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.detached != 3 {
+		t.Errorf("expected detached: %v, got: %v", 3, mockTaskStorage.detached)
+	}
+	if _, err := taskService.Download(context.Background(), dto.GetAttachmentRequest{ID: 3}); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrIDNotFound, err)
+	}
+}
+
+func TestPurgeDropsAttachments(t *testing.T) {
+	blobs := blobstore.NewLocal(blobstore.WithRoot(t.TempDir()))
+	for _, key := range []string{"tasks/3/a", "tasks/4/a", "tasks/5/a"} {
+		if _, err := blobs.Put(context.Background(), key, strings.NewReader("x")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{purged: []uint{3, 4}}), WithBlobStore(blobs))
+
+	if err := taskService.Purge(context.Background(), dto.PurgeTaskRequest{ID: 5}); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if _, err := taskService.PurgeTrash(context.Background(), dto.PurgeTrashRequest{}); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	for _, key := range []string{"tasks/3/a", "tasks/4/a", "tasks/5/a"} {
+		if _, err := blobs.Get(context.Background(), key); !errors.Is(err, blobstore.ErrNotFound) {
+			t.Errorf("blob %v should be dropped, got: %v", key, err)
+		}
+	}
+}
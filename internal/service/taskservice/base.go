@@ -2,21 +2,67 @@ package taskservice
 
 import (
 	"context"
+	"log/slog"
+	"net/http"
 
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/blobstore"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/workflow"
 )
 
 type TaskService interface {
 	Set(context.Context, dto.SetTaskRequest) (dto.TaskResponse, error)
 	Get(context.Context, dto.GetTaskRequest) (dto.TaskResponse, error)
-	List(context.Context, dto.ListTaskRequest) ([]dto.TaskResponse, error)
+	List(context.Context, dto.ListTaskRequest) (dto.TaskListResponse, error)
+	Search(context.Context, dto.SearchTaskRequest) (dto.TaskSearchResponse, error)
 	Update(context.Context, dto.UpdateTaskRequest) (dto.TaskResponse, error)
 	Delete(context.Context, dto.DeleteTaskRequest) error
+	Restore(context.Context, dto.RestoreTaskRequest) (dto.TaskResponse, error)
+	Purge(context.Context, dto.PurgeTaskRequest) error
+	PurgeTrash(context.Context, dto.PurgeTrashRequest) (int64, error)
+	History(context.Context, dto.TaskHistoryRequest) ([]dto.TaskEventResponse, error)
+	Snapshot(context.Context, dto.TaskSnapshotRequest) (dto.TaskResponse, error)
+	Workflow(context.Context) (dto.WorkflowResponse, error)
+	Tag(context.Context, dto.TagTaskRequest) (dto.TaskResponse, error)
+	Untag(context.Context, dto.TagTaskRequest) (dto.TaskResponse, error)
+	Tags(context.Context) ([]dto.TagCountResponse, error)
+	SetParent(context.Context, dto.SetParentRequest) (dto.TaskResponse, error)
+	Block(context.Context, dto.TaskDependencyRequest) (dto.TaskGraphResponse, error)
+	Unblock(context.Context, dto.TaskDependencyRequest) (dto.TaskGraphResponse, error)
+	Graph(context.Context, dto.TaskGraphRequest) (dto.TaskGraphResponse, error)
+	AddComment(context.Context, dto.AddCommentRequest) (dto.CommentResponse, error)
+	EditComment(context.Context, dto.EditCommentRequest) (dto.CommentResponse, error)
+	DeleteComment(context.Context, dto.DeleteCommentRequest) error
+	Comments(context.Context, dto.ListCommentsRequest) (dto.CommentListResponse, error)
+	Attach(context.Context, dto.AttachRequest) (dto.AttachmentResponse, error)
+	Download(context.Context, dto.GetAttachmentRequest) (dto.AttachmentDownload, error)
+	Detach(context.Context, dto.DeleteAttachmentRequest) error
+	Attachments(context.Context, dto.ListAttachmentsRequest) ([]dto.AttachmentResponse, error)
+	Bulk(context.Context, dto.BulkRequest) (dto.BulkResponse, error)
+	Export(context.Context, dto.ExportTaskRequest) error
+	Patch(context.Context, dto.PatchTaskRequest) (dto.TaskResponse, error)
+	AddWebhook(context.Context, dto.AddWebhookRequest) (dto.WebhookResponse, error)
+	Webhooks(context.Context) ([]dto.WebhookResponse, error)
+	DeleteWebhook(context.Context, dto.DeleteWebhookRequest) error
+	EnableWebhook(context.Context, dto.EnableWebhookRequest) (dto.WebhookResponse, error)
+	WebhookDeliveries(context.Context, dto.ListWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, error)
+	Deliver(context.Context, dto.DeliverWebhookRequest) (dto.WebhookDeliveryResponse, error)
 }
 
 type taskService struct {
 	taskStorage TaskStorer
+	workflow    workflow.Workflow
+	blobs       blobstore.Store
+	events      events.Publisher
+	client      *http.Client
+	// webhookFailures is the number of deliveries a webhook may fail in
+	// a row before it is disabled.
+	webhookFailures int
+	// privateWebhooks lets webhooks target internal addresses.
+	privateWebhooks bool
+	logger          *slog.Logger
 }
 
 type TaskServiceOption func(*taskService)
@@ -27,10 +73,93 @@ func WithTaskStorage(taskStorage TaskStorer) TaskServiceOption {
 	}
 }
 
+// WithWorkflow sets the status workflow enforced on set and update. The
+// default workflow is used otherwise.
+func WithWorkflow(w workflow.Workflow) TaskServiceOption {
+	return func(s *taskService) {
+		s.workflow = w
+	}
+}
+
+// WithBlobStore sets the store keeping the contents of attachments.
+// Attachments are disabled without one.
+func WithBlobStore(blobs blobstore.Store) TaskServiceOption {
+	return func(s *taskService) {
+		s.blobs = blobs
+	}
+}
+
+// WithEvents sets the publisher told about the tasks created, updated and
+// deleted. Changes made in a transaction are told once it is committed.
+func WithEvents(p events.Publisher) TaskServiceOption {
+	return func(s *taskService) {
+		s.events = p
+	}
+}
+
+// WithHTTPClient sets the client sending webhook deliveries. Its timeout
+// bounds every delivery. Redirects are never followed, but the transport
+// of the client is used as is: it is the one keeping deliveries from
+// connecting to internal addresses.
+func WithHTTPClient(client *http.Client) TaskServiceOption {
+	return func(s *taskService) {
+		s.client = client
+	}
+}
+
+// WithWebhookFailures sets the number of deliveries a webhook may fail in
+// a row before it is disabled, DefaultWebhookFailures by default.
+func WithWebhookFailures(n int) TaskServiceOption {
+	return func(s *taskService) {
+		s.webhookFailures = n
+	}
+}
+
+// WithPrivateWebhooks lets webhooks target loopback, private and
+// link-local addresses, which are refused by default. It is meant for
+// trusted networks and tests.
+func WithPrivateWebhooks(allow bool) TaskServiceOption {
+	return func(s *taskService) {
+		s.privateWebhooks = allow
+	}
+}
+
+// WithLogger sets the logger used for failures that do not fail the
+// request, such as leftover blobs that could not be removed.
+func WithLogger(logger *slog.Logger) TaskServiceOption {
+	return func(s *taskService) {
+		s.logger = logger
+	}
+}
+
 func NewTaskService(opts ...TaskServiceOption) TaskService {
-	s := &taskService{}
+	s := &taskService{
+		workflow:        workflow.Default(),
+		webhookFailures: DefaultWebhookFailures,
+		logger:          slog.Default(),
+	}
 	for _, opt := range opts {
 		opt(s)
 	}
+	if s.client == nil {
+		s.client = webhookClient(s.privateWebhooks)
+	}
+	client := *s.client
+	client.CheckRedirect = noRedirect
+	s.client = &client
 	return s
 }
+
+// publish tells the publisher, if any, about a change of task.
+func (s *taskService) publish(typ string, task dto.TaskResponse) {
+	if s.events != nil {
+		s.events.Publish(typ, task)
+	}
+}
+
+// flush tells the publisher, if any, about the changes kept in batch.
+func (s *taskService) flush(batch *events.Batch) {
+	if s.events != nil {
+		batch.Flush(s.events)
+	}
+}
@@ -1,43 +1,270 @@
 package taskservice_test
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
 )
 
 var (
-	errStorageDelete = errors.New("storage delete error")
-	errStorageGet    = errors.New("storage get error")
-	errStorageList   = errors.New("storage list error")
-	errStorageSet    = errors.New("storage set error")
-	errStorageUpdate = errors.New("storage update error")
+	errStorageDelete  = errors.New("storage delete error")
+	errStorageGet     = errors.New("storage get error")
+	errStorageList    = errors.New("storage list error")
+	errStorageSet     = errors.New("storage set error")
+	errStorageUpdate  = errors.New("storage update error")
+	errStorageRestore = errors.New("storage restore error")
+	errStoragePurge   = errors.New("storage purge error")
+	errStorageHistory = errors.New("storage history error")
+	errStorageTag     = errors.New("storage tag error")
+	errStorageLink    = errors.New("storage link error")
+	errStorageComment = errors.New("storage comment error")
+	errStorageWebhook = errors.New("storage webhook error")
 )
 
 type mockTaskStorage struct {
-	deleteErr error
-	getErr    error
-	listErr   error
-	setErr    error
-	updateErr error
+	getRes     Task
+	setTask    Task
+	updTask    Task
+	delTask    Task
+	purged     []uint
+	deleteErr  error
+	restoreErr error
+	purgeErr   error
+	getErr     error
+	listErr    error
+	setErr     error
+	updateErr  error
+	events     []TaskEvent
+	listRes    []Task
+	pages      [][]Task
+	filter     TaskFilter
+	total      int64
+	searchRes  []TaskSearchHit
+	search     TaskSearch
+	searchErr  error
+	historyErr error
+	tags       []string
+	tagTask    Task
+	tagCounts  []TagCount
+	tagErr     error
+	parentTask Task
+	dep        TaskDependency
+	blockers   []Task
+	graph      TaskGraph
+	linkErr    error
+	comment    Comment
+	comments   []Comment
+	comFilter  CommentFilter
+	updComment Comment
+	delComment Comment
+	commentErr error
+	attachment Attachment
+	attached   []Attachment
+	detached   uint
+	attachErr  error
+	atomic     bool
+	atomicErr  error
+	webhook    Webhook
+	webhooks   []Webhook
+	deliveries []WebhookDelivery
+	failures   int
+	webhookErr error
 }
 
-func (m *mockTaskStorage) Delete(uint) error {
+func (m *mockTaskStorage) Delete(_ context.Context, task Task) error {
+	m.delTask = task
 	return m.deleteErr
 }
 
-func (m *mockTaskStorage) Get(uint) (Task, error) {
-	return Task{}, m.getErr
+func (m *mockTaskStorage) Get(context.Context, uint) (Task, error) {
+	return m.getRes, m.getErr
 }
 
-func (m *mockTaskStorage) List(string) ([]Task, error) {
-	return []Task{}, m.listErr
+func (m *mockTaskStorage) Lock(context.Context, uint) (Task, error) {
+	return m.getRes, m.getErr
 }
 
-func (m *mockTaskStorage) Set(Task) error {
+func (m *mockTaskStorage) Restore(context.Context, Task) error {
+	return m.restoreErr
+}
+
+func (m *mockTaskStorage) Purge(context.Context, uint) error {
+	return m.purgeErr
+}
+
+func (m *mockTaskStorage) PurgeDeletedBefore(context.Context, time.Time) ([]uint, error) {
+	return m.purged, m.purgeErr
+}
+
+func (m *mockTaskStorage) List(_ context.Context, filter TaskFilter) ([]Task, error) {
+	m.filter = filter
+	if len(m.pages) > 0 {
+		page := m.pages[0]
+		m.pages = m.pages[1:]
+		return page, m.listErr
+	}
+	return m.listRes, m.listErr
+}
+
+func (m *mockTaskStorage) Count(context.Context, TaskFilter) (int64, error) {
+	return m.total, m.listErr
+}
+
+func (m *mockTaskStorage) Set(_ context.Context, task Task) error {
+	m.setTask = task
 	return m.setErr
 }
 
-func (m *mockTaskStorage) Update(Task) error {
+func (m *mockTaskStorage) Update(_ context.Context, task Task) error {
+	m.updTask = task
 	return m.updateErr
 }
+
+func (m *mockTaskStorage) ListEvents(context.Context, uint) ([]TaskEvent, error) {
+	return m.events, m.historyErr
+}
+
+func (m *mockTaskStorage) GetEventAt(context.Context, uint, time.Time) (TaskEvent, error) {
+	if len(m.events) == 0 {
+		return TaskEvent{}, m.historyErr
+	}
+	return m.events[len(m.events)-1], m.historyErr
+}
+
+func (m *mockTaskStorage) Search(_ context.Context, query TaskSearch) ([]TaskSearchHit, int64, error) {
+	m.search = query
+	return m.searchRes, m.total, m.searchErr
+}
+
+func (m *mockTaskStorage) AddTags(_ context.Context, task Task, tags []string) error {
+	m.tagTask = task
+	m.tags = tags
+	return m.tagErr
+}
+
+func (m *mockTaskStorage) RemoveTags(_ context.Context, task Task, tags []string) error {
+	m.tagTask = task
+	m.tags = tags
+	return m.tagErr
+}
+
+func (m *mockTaskStorage) TagCounts(context.Context) ([]TagCount, error) {
+	return m.tagCounts, m.tagErr
+}
+
+func (m *mockTaskStorage) SetParent(_ context.Context, task Task) error {
+	m.parentTask = task
+	return m.linkErr
+}
+
+func (m *mockTaskStorage) AddDependency(_ context.Context, dep TaskDependency) error {
+	m.dep = dep
+	return m.linkErr
+}
+
+func (m *mockTaskStorage) RemoveDependency(_ context.Context, dep TaskDependency) error {
+	m.dep = dep
+	return m.linkErr
+}
+
+func (m *mockTaskStorage) ListBlockers(context.Context, uint) ([]Task, error) {
+	return m.blockers, m.linkErr
+}
+
+func (m *mockTaskStorage) DependencyGraph(context.Context, uint) (TaskGraph, error) {
+	return m.graph, m.linkErr
+}
+
+func (m *mockTaskStorage) AddComment(_ context.Context, comment Comment) (Comment, error) {
+	comment.ID = m.comment.ID
+	return comment, m.commentErr
+}
+
+func (m *mockTaskStorage) GetComment(context.Context, uint) (Comment, error) {
+	return m.comment, m.commentErr
+}
+
+func (m *mockTaskStorage) UpdateComment(_ context.Context, comment Comment) error {
+	m.updComment = comment
+	return m.commentErr
+}
+
+func (m *mockTaskStorage) DeleteComment(_ context.Context, comment Comment) error {
+	m.delComment = comment
+	return m.commentErr
+}
+
+func (m *mockTaskStorage) ListComments(_ context.Context, filter CommentFilter) ([]Comment, int64, error) {
+	m.comFilter = filter
+	return m.comments, m.total, m.commentErr
+}
+
+func (m *mockTaskStorage) AddAttachment(_ context.Context, a Attachment) (Attachment, error) {
+	a.ID = m.attachment.ID
+	m.attached = append(m.attached, a)
+	return a, m.attachErr
+}
+
+func (m *mockTaskStorage) GetAttachment(context.Context, uint) (Attachment, error) {
+	return m.attachment, m.attachErr
+}
+
+func (m *mockTaskStorage) ListAttachments(context.Context, uint) ([]Attachment, error) {
+	return m.attached, m.attachErr
+}
+
+func (m *mockTaskStorage) DeleteAttachment(_ context.Context, id uint) error {
+	m.detached = id
+	return m.attachErr
+}
+
+func (m *mockTaskStorage) AddWebhook(_ context.Context, w Webhook) (Webhook, error) {
+	w.ID = m.webhook.ID
+	m.webhooks = append(m.webhooks, w)
+	return w, m.webhookErr
+}
+
+func (m *mockTaskStorage) GetWebhook(context.Context, uint) (Webhook, error) {
+	return m.webhook, m.webhookErr
+}
+
+func (m *mockTaskStorage) ListWebhooks(context.Context) ([]Webhook, error) {
+	return m.webhooks, m.webhookErr
+}
+
+func (m *mockTaskStorage) DeleteWebhook(context.Context, uint) error {
+	return m.webhookErr
+}
+
+func (m *mockTaskStorage) EnableWebhook(context.Context, uint, time.Time) error {
+	return m.webhookErr
+}
+
+// AddWebhookDelivery counts the failures of m.webhook the way the storage
+// does.
+func (m *mockTaskStorage) AddWebhookDelivery(_ context.Context, d WebhookDelivery, maxFailures int) (Webhook, error) {
+	m.deliveries = append(m.deliveries, d)
+	m.failures = maxFailures
+	if d.Success {
+		m.webhook.Failures = 0
+	} else {
+		m.webhook.Failures++
+		m.webhook.Enabled = m.webhook.Enabled && m.webhook.Failures < maxFailures
+	}
+	return m.webhook, m.webhookErr
+}
+
+func (m *mockTaskStorage) ListWebhookDeliveries(context.Context, uint, int) ([]WebhookDelivery, error) {
+	return m.deliveries, m.webhookErr
+}
+
+func (m *mockTaskStorage) Atomic(_ context.Context, fn func(TaskStorer) error) error {
+	m.atomic = true
+	if m.atomicErr != nil {
+		return m.atomicErr
+	}
+	return fn(m)
+}
@@ -0,0 +1,132 @@
+package taskservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// Bulk applies the operations of req in order, reporting the outcome of
+// each. In atomic mode they run in one transaction: the first failure rolls
+// every change back, the failed operation reports its error and all others
+// report ErrRolledBack. In best effort mode every operation is applied on
+// its own and a failure does not stop the operations after it.
+func (s *taskService) Bulk(ctx context.Context, req dto.BulkRequest) (dto.BulkResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.BulkResponse{}, ctx.Err()
+	default:
+		mode := req.Mode
+		if mode == "" {
+			mode = dto.BulkAtomic
+		}
+		resp := dto.BulkResponse{
+			Mode:    mode,
+			Results: make([]dto.BulkResult, len(req.Operations)),
+		}
+		for i, op := range req.Operations {
+			resp.Results[i] = dto.BulkResult{Index: i, Op: op.Op, ID: op.ID}
+		}
+		if mode == dto.BulkBestEffort {
+			for i, op := range req.Operations {
+				s.applyBulk(ctx, op, &resp.Results[i])
+			}
+			resp.Committed = true
+			return countBulk(resp), nil
+		}
+		failed := -1
+		var batch events.Batch
+		err := s.taskStorage.Atomic(ctx, func(tx TaskStorer) error {
+			bound := *s
+			bound.taskStorage = tx
+			bound.events = &batch
+			for i, op := range req.Operations {
+				if err := bound.applyBulk(ctx, op, &resp.Results[i]); err != nil {
+					failed = i
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil && failed < 0 {
+			return dto.BulkResponse{}, fmt.Errorf("service.Bulk storage.Atomic: %w", err)
+		}
+		if err != nil {
+			for i := range resp.Results {
+				if i != failed {
+					resp.Results[i].Task = nil
+					resp.Results[i].Err = customerror.ErrRolledBack
+					resp.Results[i].Error = customerror.ErrRolledBack.Error()
+				}
+			}
+		} else {
+			s.flush(&batch)
+		}
+		resp.Committed = err == nil
+		return countBulk(resp), nil
+	}
+}
+
+// applyBulk applies op and records its outcome in result. The error is
+// returned as well so that an atomic request can stop at it.
+func (s *taskService) applyBulk(ctx context.Context, op dto.BulkOperation, result *dto.BulkResult) error {
+	var (
+		task dto.TaskResponse
+		err  error
+	)
+	switch r := op.Request().(type) {
+	case dto.SetTaskRequest:
+		task, err = s.Set(ctx, r)
+	case dto.UpdateTaskRequest:
+		task, err = s.Update(ctx, r)
+	case dto.DeleteTaskRequest:
+		err = s.Delete(ctx, r)
+	case dto.ImportTaskRequest:
+		task, err = s.importTask(ctx, r)
+	default:
+		err = fmt.Errorf("service.Bulk: unknown operation '%s'", op.Op)
+	}
+	if err != nil {
+		result.Err = err
+		result.Error = bulkMessage(err)
+		return err
+	}
+	if op.Op != dto.BulkDelete {
+		result.Task = &task
+	}
+	return nil
+}
+
+// bulkMessage renders err the way handlers report errors to clients, for
+// the result of the operation failing with it. Errors other than
+// customerrors and those of contexts are not told, since they may carry
+// the text of the database driver.
+func bulkMessage(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return err.Error()
+	}
+	var cusErr *customerror.Error
+	if !errors.As(err, &cusErr) {
+		return customerror.ErrUnknown.Error()
+	}
+	if data, ok := cusErr.Data.(string); ok {
+		return cusErr.Message + ", " + data
+	}
+	return cusErr.Message
+}
+
+func countBulk(resp dto.BulkResponse) dto.BulkResponse {
+	for _, result := range resp.Results {
+		if result.Err != nil {
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+	}
+	return resp
+}
@@ -0,0 +1,212 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+var errStorageAtomic = errors.New("storage atomic error")
+
+func bulkRequest(mode string) dto.BulkRequest {
+	return dto.BulkRequest{
+		Mode: mode,
+		Operations: []dto.BulkOperation{
+			{Op: dto.BulkDelete, ID: 1},
+			{Op: dto.BulkSet, ID: 2, Title: "title", Description: "description"},
+		},
+	}
+}
+
+func TestBulkWithCancel(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := taskService.Bulk(ctx, bulkRequest("")); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestBulkAtomic(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.BulkRequest{
+		Operations: []dto.BulkOperation{
+			{Op: dto.BulkDelete, ID: 1},
+			{Op: dto.BulkDelete, ID: 2},
+		},
+	}
+	resp, err := taskService.Bulk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if !mockTaskStorage.atomic {
+		t.Errorf("expected the operations to run in a transaction")
+	}
+	if resp.Mode != dto.BulkAtomic || !resp.Committed || resp.Succeeded != 2 || resp.Failed != 0 {
+		t.Errorf("expected a committed atomic response with 2 successes, got: %+v", resp)
+	}
+}
+
+func TestBulkAtomicRollsBack(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	resp, err := taskService.Bulk(context.Background(), bulkRequest(dto.BulkAtomic))
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if resp.Committed || resp.Succeeded != 0 || resp.Failed != 2 {
+		t.Errorf("expected a rolled back response with 2 failures, got: %+v", resp)
+	}
+	if got := resp.Results[0].Err; got != customerror.ErrRolledBack {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrRolledBack, got)
+	}
+	if got := resp.Results[1].Err; !errors.Is(got, customerror.ErrIDExists) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrIDExists, got)
+	}
+}
+
+func TestBulkAtomicWithStorageError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		atomicErr: errStorageAtomic,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	if _, err := taskService.Bulk(context.Background(), bulkRequest(dto.BulkAtomic)); !errors.Is(err, errStorageAtomic) {
+		t.Errorf("expected error: %v, got: %v", errStorageAtomic, err)
+	}
+}
+
+func TestBulkBestEffort(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	resp, err := taskService.Bulk(context.Background(), bulkRequest(dto.BulkBestEffort))
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.atomic {
+		t.Errorf("expected the operations to run on their own")
+	}
+	if !resp.Committed || resp.Succeeded != 1 || resp.Failed != 1 {
+		t.Errorf("expected a committed response with 1 success and 1 failure, got: %+v", resp)
+	}
+	if resp.Results[0].Err != nil || mockTaskStorage.delTask.DeletedAt == nil {
+		t.Errorf("expected the task to be deleted, got: %v", resp.Results[0].Err)
+	}
+	if got := resp.Results[1].Err; !errors.Is(got, customerror.ErrIDExists) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrIDExists, got)
+	}
+}
+
+func TestBulkKeepsEachErrorMessage(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.BulkRequest{
+		Mode: dto.BulkBestEffort,
+		Operations: []dto.BulkOperation{
+			{Op: dto.BulkSet, ID: 1, Title: "title", Description: "description"},
+			{Op: dto.BulkSet, ID: 2, Title: "title", Description: "description"},
+		},
+	}
+	resp, err := taskService.Bulk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	for i, result := range resp.Results {
+		if id := "'" + string(rune('1'+i)) + "'"; !strings.Contains(result.Error, id) {
+			t.Errorf("expected result %d to mention %s, got: %q", i, id, result.Error)
+		}
+	}
+}
+
+func TestBulkSetReturnsTask(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getErr: errStorageGet,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.BulkRequest{
+		Operations: []dto.BulkOperation{
+			{Op: dto.BulkSet, ID: 2, Title: "title", Description: "description"},
+		},
+	}
+	resp, err := taskService.Bulk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if task := resp.Results[0].Task; task == nil || task.ID != 2 {
+		t.Errorf("expected task %d in the result, got: %+v", 2, task)
+	}
+}
+
+func TestBulkImportKeepsExportedFields(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getErr: errStorageGet,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	req := dto.BulkRequest{
+		Mode: dto.BulkBestEffort,
+		Operations: []dto.BulkOperation{
+			{
+				Op: dto.BulkImport, ID: 2, Title: "title", Description: "description", Status: "Done",
+				Tags: []string{"ops", "DB"}, ParentID: 1, CreatedAt: &createdAt, UpdatedAt: &updatedAt,
+			},
+		},
+	}
+	resp, err := taskService.Bulk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if resp.Failed != 0 {
+		t.Fatalf("expected the import to succeed, got: %+v", resp.Results[0])
+	}
+	task := mockTaskStorage.setTask
+	if task.Status != "done" || !task.CreatedAt.Equal(createdAt) || !task.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("expected the status and timestamps of the export, got: %+v", task)
+	}
+	if got := strings.Join(mockTaskStorage.tags, ","); got != "db,ops" {
+		t.Errorf("expected tags %v, got: %v", "db,ops", got)
+	}
+	if got := mockTaskStorage.parentTask.ParentID; got != 1 {
+		t.Errorf("expected parent %d, got: %d", 1, got)
+	}
+	if got := resp.Results[0].Task; got == nil || got.ParentID != 1 || len(got.Tags) != 2 {
+		t.Errorf("expected the imported task in the result, got: %+v", got)
+	}
+}
+
+func TestBulkImportUnknownStatus(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getErr: errStorageGet,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.BulkRequest{
+		Mode: dto.BulkBestEffort,
+		Operations: []dto.BulkOperation{
+			{Op: dto.BulkImport, ID: 2, Title: "title", Description: "description", Status: "shipped"},
+		},
+	}
+	resp, err := taskService.Bulk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if got := resp.Results[0].Err; !errors.Is(got, customerror.ErrStatus) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrStatus, got)
+	}
+}
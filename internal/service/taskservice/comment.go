@@ -0,0 +1,130 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func (s *taskService) AddComment(ctx context.Context, req dto.AddCommentRequest) (dto.CommentResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.CommentResponse{}, ctx.Err()
+	default:
+		now := time.Now().UTC()
+		comment, err := s.taskStorage.AddComment(ctx, models.Comment{
+			TaskID:    req.TaskID,
+			Author:    util.ActorFromContext(ctx),
+			Body:      req.Body,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return dto.CommentResponse{}, fmt.Errorf("service.AddComment storage.AddComment: %w", err)
+		}
+		return dto.NewCommentResponse(comment), nil
+	}
+}
+
+// EditComment replaces the body of a comment. Only its author may edit it.
+func (s *taskService) EditComment(ctx context.Context, req dto.EditCommentRequest) (dto.CommentResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.CommentResponse{}, ctx.Err()
+	default:
+		comment, err := s.ownComment(ctx, req.ID)
+		if err != nil {
+			return dto.CommentResponse{}, fmt.Errorf("service.EditComment: %w", err)
+		}
+		comment.Body = req.Body
+		comment.UpdatedAt = time.Now().UTC()
+		if err := s.taskStorage.UpdateComment(ctx, comment); err != nil {
+			return dto.CommentResponse{}, fmt.Errorf("service.EditComment storage.UpdateComment: %w", err)
+		}
+		return dto.NewCommentResponse(comment), nil
+	}
+}
+
+// DeleteComment removes a comment. Only its author may delete it.
+func (s *taskService) DeleteComment(ctx context.Context, req dto.DeleteCommentRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		comment, err := s.ownComment(ctx, req.ID)
+		if err != nil {
+			return fmt.Errorf("service.DeleteComment: %w", err)
+		}
+		if err := s.taskStorage.DeleteComment(ctx, comment); err != nil {
+			return fmt.Errorf("service.DeleteComment storage.DeleteComment: %w", err)
+		}
+		return nil
+	}
+}
+
+func (s *taskService) Comments(ctx context.Context, req dto.ListCommentsRequest) (dto.CommentListResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.CommentListResponse{}, ctx.Err()
+	default:
+		limit := req.Limit
+		if limit <= 0 {
+			limit = dto.DefaultPageSize
+		}
+		if limit > dto.MaxPageSize {
+			limit = dto.MaxPageSize
+		}
+		var after uint
+		if req.Cursor != "" {
+			cursor, err := models.DecodeTaskCursor(req.Cursor)
+			var ok bool
+			after, ok = cursor.CommentAfter()
+			if err != nil || !ok {
+				return dto.CommentListResponse{}, fmt.Errorf("service.Comments: %w", customerror.ErrCursor.AddData("'"+req.Cursor+"' does not belong to a comment listing."))
+			}
+		}
+		comments, total, err := s.taskStorage.ListComments(ctx, models.CommentFilter{
+			TaskID:  req.TaskID,
+			AfterID: after,
+			Limit:   limit + 1,
+		})
+		if err != nil {
+			return dto.CommentListResponse{}, fmt.Errorf("service.Comments storage.ListComments: %w", err)
+		}
+		res := dto.CommentListResponse{
+			Comments: make([]dto.CommentResponse, 0, len(comments)),
+			Total:    total,
+		}
+		if len(comments) > limit {
+			comments = comments[:limit]
+			res.NextCursor = models.NewCommentCursor(comments[limit-1]).Encode()
+		}
+		for _, comment := range comments {
+			res.Comments = append(res.Comments, dto.NewCommentResponse(comment))
+		}
+		return res, nil
+	}
+}
+
+// ownComment returns the comment if the caller wrote it. Anonymous callers
+// own no comment, since anyone may act as them.
+func (s *taskService) ownComment(ctx context.Context, id uint) (models.Comment, error) {
+	comment, err := s.taskStorage.GetComment(ctx, id)
+	if err != nil {
+		return models.Comment{}, fmt.Errorf("storage.GetComment: %w", err)
+	}
+	_id := strconv.Itoa(int(id))
+	switch actor := util.ActorFromContext(ctx); {
+	case actor == "" || actor == util.AnonymousActor:
+		return models.Comment{}, customerror.ErrNotAuthor.AddData("comment '" + _id + "' may only be changed by a caller with a token of their own.")
+	case comment.Author != actor:
+		return models.Comment{}, customerror.ErrNotAuthor.AddData("comment '" + _id + "' was written by someone else.")
+	}
+	return comment, nil
+}
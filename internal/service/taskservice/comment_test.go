@@ -0,0 +1,141 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestAddComment(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{comment: Comment{ID: 7}}))
+
+	ctx := util.WithActor(context.Background(), "alice")
+	res, err := taskService.AddComment(ctx, dto.AddCommentRequest{TaskID: 1, Body: "looks good"})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if res.ID != 7 || res.TaskID != 1 || res.Author != "alice" || res.Body != "looks good" || res.CreatedAt.IsZero() {
+		t.Errorf("unexpected comment: %+v", res)
+	}
+}
+
+func TestAddCommentWithStorageError(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{commentErr: errStorageComment}))
+
+	if _, err := taskService.AddComment(context.Background(), dto.AddCommentRequest{TaskID: 1, Body: "looks good"}); !errors.Is(err, errStorageComment) {
+		t.Errorf("expected error: %v, got: %v", errStorageComment, err)
+	}
+}
+
+func TestEditComment(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		comment: Comment{ID: 7, TaskID: 1, Author: "alice", Body: "looks good"},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx := util.WithActor(context.Background(), "alice")
+	res, err := taskService.EditComment(ctx, dto.EditCommentRequest{ID: 7, Body: "looks great"})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.updComment.Body != "looks great" || mockTaskStorage.updComment.Author != "alice" {
+		t.Errorf("unexpected comment stored: %+v", mockTaskStorage.updComment)
+	}
+	if res.Body != "looks great" || res.UpdatedAt.IsZero() {
+		t.Errorf("unexpected comment: %+v", res)
+	}
+}
+
+func TestEditCommentNotAuthor(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		comment: Comment{ID: 7, TaskID: 1, Author: "alice", Body: "looks good"},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx := util.WithActor(context.Background(), "bob")
+	if _, err := taskService.EditComment(ctx, dto.EditCommentRequest{ID: 7, Body: "looks bad"}); !errors.Is(err, customerror.ErrNotAuthor) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrNotAuthor, err)
+	}
+	if mockTaskStorage.updComment.ID != 0 {
+		t.Errorf("comment should not be stored: %+v", mockTaskStorage.updComment)
+	}
+}
+
+func TestEditCommentAnonymous(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		comment: Comment{ID: 7, TaskID: 1, Author: util.AnonymousActor, Body: "looks good"},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	for _, ctx := range []context.Context{context.Background(), util.WithActor(context.Background(), util.AnonymousActor)} {
+		if _, err := taskService.EditComment(ctx, dto.EditCommentRequest{ID: 7, Body: "looks bad"}); !errors.Is(err, customerror.ErrNotAuthor) {
+			t.Errorf("expected error: %v, got: %v", customerror.ErrNotAuthor, err)
+		}
+	}
+	if mockTaskStorage.updComment.ID != 0 {
+		t.Errorf("comment should not be stored: %+v", mockTaskStorage.updComment)
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		comment: Comment{ID: 7, TaskID: 1, Author: "alice"},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	if err := taskService.DeleteComment(util.WithActor(context.Background(), "bob"), dto.DeleteCommentRequest{ID: 7}); !errors.Is(err, customerror.ErrNotAuthor) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrNotAuthor, err)
+	}
+	if err := taskService.DeleteComment(util.WithActor(context.Background(), "alice"), dto.DeleteCommentRequest{ID: 7}); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.delComment.ID != 7 {
+		t.Errorf("unexpected comment deleted: %+v", mockTaskStorage.delComment)
+	}
+}
+
+func TestComments(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		comments: []Comment{{ID: 5}, {ID: 6}, {ID: 8}},
+		total:    4,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	res, err := taskService.Comments(context.Background(), dto.ListCommentsRequest{TaskID: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.comFilter.Limit != 3 || mockTaskStorage.comFilter.AfterID != 0 {
+		t.Errorf("unexpected filter: %+v", mockTaskStorage.comFilter)
+	}
+	if len(res.Comments) != 2 || res.Total != 4 || res.NextCursor == "" {
+		t.Fatalf("unexpected page: %+v", res)
+	}
+
+	mockTaskStorage.comments = []Comment{{ID: 8}}
+	res, err = taskService.Comments(context.Background(), dto.ListCommentsRequest{TaskID: 1, Limit: 2, Cursor: res.NextCursor})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.comFilter.AfterID != 6 {
+		t.Errorf("expected comments after: %v, got: %v", 6, mockTaskStorage.comFilter.AfterID)
+	}
+	if len(res.Comments) != 1 || res.NextCursor != "" {
+		t.Errorf("unexpected page: %+v", res)
+	}
+}
+
+func TestCommentsInvalidCursor(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{}))
+
+	cursor := NewSearchCursor(10).Encode()
+	if _, err := taskService.Comments(context.Background(), dto.ListCommentsRequest{TaskID: 1, Cursor: cursor}); !errors.Is(err, customerror.ErrCursor) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrCursor, err)
+	}
+}
@@ -3,8 +3,11 @@ package taskservice
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
 func (s *taskService) Delete(ctx context.Context, req dto.DeleteTaskRequest) error {
@@ -12,12 +15,17 @@ func (s *taskService) Delete(ctx context.Context, req dto.DeleteTaskRequest) err
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
-		if _, err := s.taskStorage.Get(req.ID); err != nil {
+		task, err := s.taskStorage.Get(ctx, req.ID)
+		if err != nil {
 			return fmt.Errorf("service.Delete storage.Get: %w", err)
 		}
-		if err := s.taskStorage.Delete(req.ID); err != nil {
+		now := time.Now().UTC()
+		task.DeletedAt = &now
+		task.DeletedBy = util.ActorFromContext(ctx)
+		if err := s.taskStorage.Delete(ctx, task); err != nil {
 			return fmt.Errorf("service.Delete storage.Delete: %w", err)
 		}
+		s.publish(events.Deleted, s.taskResponse(task))
 		return nil
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
 func TestDeleteWithCancel(t *testing.T) {
@@ -62,3 +63,20 @@ func TestDelete(t *testing.T) {
 		t.Errorf("expected error: %v, got: %v", nil, err)
 	}
 }
+
+func TestDeleteRecordsActor(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.DeleteTaskRequest{
+		ID: 1,
+	}
+	ctx := util.WithActor(context.Background(), "alice")
+	if err := taskService.Delete(ctx, req); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	deleted := mockTaskStorage.delTask
+	if deleted.DeletedAt == nil || deleted.DeletedBy != "alice" {
+		t.Errorf("expected deletion by: %v, got: %v at %v", "alice", deleted.DeletedBy, deleted.DeletedAt)
+	}
+}
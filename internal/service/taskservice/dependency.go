@@ -0,0 +1,114 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func (s *taskService) SetParent(ctx context.Context, req dto.SetParentRequest) (dto.TaskResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskResponse{}, ctx.Err()
+	default:
+		task := models.Task{
+			ID:        req.ID,
+			ParentID:  req.ParentID,
+			UpdatedAt: time.Now().UTC(),
+			UpdatedBy: util.ActorFromContext(ctx),
+		}
+		if err := s.taskStorage.SetParent(ctx, task); err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.SetParent storage.SetParent: %w", err)
+		}
+		updated, err := s.taskStorage.Get(ctx, req.ID)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.SetParent storage.Get: %w", err)
+		}
+		resp := s.taskResponse(updated)
+		s.publish(events.Updated, resp)
+		return resp, nil
+	}
+}
+
+func (s *taskService) Block(ctx context.Context, req dto.TaskDependencyRequest) (dto.TaskGraphResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskGraphResponse{}, ctx.Err()
+	default:
+		dep := models.TaskDependency{TaskID: req.ID, BlockerID: req.BlockerID}
+		if err := s.taskStorage.AddDependency(ctx, dep); err != nil {
+			return dto.TaskGraphResponse{}, fmt.Errorf("service.Block storage.AddDependency: %w", err)
+		}
+		return s.Graph(ctx, dto.TaskGraphRequest{ID: req.ID})
+	}
+}
+
+func (s *taskService) Unblock(ctx context.Context, req dto.TaskDependencyRequest) (dto.TaskGraphResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskGraphResponse{}, ctx.Err()
+	default:
+		dep := models.TaskDependency{TaskID: req.ID, BlockerID: req.BlockerID}
+		if err := s.taskStorage.RemoveDependency(ctx, dep); err != nil {
+			return dto.TaskGraphResponse{}, fmt.Errorf("service.Unblock storage.RemoveDependency: %w", err)
+		}
+		return s.Graph(ctx, dto.TaskGraphRequest{ID: req.ID})
+	}
+}
+
+func (s *taskService) Graph(ctx context.Context, req dto.TaskGraphRequest) (dto.TaskGraphResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskGraphResponse{}, ctx.Err()
+	default:
+		graph, err := s.taskStorage.DependencyGraph(ctx, req.ID)
+		if err != nil {
+			return dto.TaskGraphResponse{}, fmt.Errorf("service.Graph storage.DependencyGraph: %w", err)
+		}
+		res := dto.TaskGraphResponse{
+			ID:           graph.Root.ID,
+			Tasks:        make([]dto.TaskResponse, 0, len(graph.Tasks)),
+			Dependencies: make([]dto.TaskDependencyResponse, 0, len(graph.Dependencies)),
+		}
+		statuses := make(map[uint]string, len(graph.Tasks))
+		for _, task := range graph.Tasks {
+			statuses[task.ID] = task.Status
+			res.Tasks = append(res.Tasks, s.taskResponse(task))
+		}
+		for _, dep := range graph.Dependencies {
+			res.Dependencies = append(res.Dependencies, dto.TaskDependencyResponse{TaskID: dep.TaskID, BlockerID: dep.BlockerID})
+			if dep.TaskID == graph.Root.ID && !s.workflow.IsTerminal(statuses[dep.BlockerID]) {
+				res.Blocked = true
+			}
+		}
+		return res, nil
+	}
+}
+
+// checkBlockers fails with ErrBlocked when the task is about to be moved to
+// a terminal status while any of its blockers is still open.
+func (s *taskService) checkBlockers(ctx context.Context, id uint) error {
+	blockers, err := s.taskStorage.ListBlockers(ctx, id)
+	if err != nil {
+		return err
+	}
+	var open []string
+	for _, blocker := range blockers {
+		if !s.workflow.IsTerminal(blocker.Status) {
+			open = append(open, strconv.Itoa(int(blocker.ID)))
+		}
+	}
+	if len(open) > 0 {
+		_id := strconv.Itoa(int(id))
+		return customerror.ErrBlocked.AddData("'" + _id + "' is blocked by open tasks " + strings.Join(open, ", ") + ".")
+	}
+	return nil
+}
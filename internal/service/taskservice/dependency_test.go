@@ -0,0 +1,241 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestSetParent(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getRes: Task{ID: 1, ParentID: 2},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx := util.WithActor(context.Background(), "alice")
+	res, err := taskService.SetParent(ctx, dto.SetParentRequest{ID: 1, ParentID: 2})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.parentTask.ParentID != 2 || mockTaskStorage.parentTask.UpdatedBy != "alice" {
+		t.Errorf("unexpected task stored: %+v", mockTaskStorage.parentTask)
+	}
+	if res.ParentID != 2 {
+		t.Errorf("expected parent: %v, got: %v", 2, res.ParentID)
+	}
+}
+
+func TestBlockWithStorageError(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{linkErr: errStorageLink}))
+
+	if _, err := taskService.Block(context.Background(), dto.TaskDependencyRequest{ID: 1, BlockerID: 2}); !errors.Is(err, errStorageLink) {
+		t.Errorf("expected error: %v, got: %v", errStorageLink, err)
+	}
+}
+
+func TestGraphBlocked(t *testing.T) {
+	tests := []struct {
+		name          string
+		blockerStatus string
+		want          bool
+	}{
+		{"blocker is open", "in_progress", true},
+		{"blocker is done", "done", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskStorage := &mockTaskStorage{
+				graph: TaskGraph{
+					Root:         Task{ID: 1, Status: "todo"},
+					Tasks:        []Task{{ID: 1, Status: "todo"}, {ID: 2, Status: tt.blockerStatus}, {ID: 3, Status: "todo"}},
+					Dependencies: []TaskDependency{{TaskID: 1, BlockerID: 2}, {TaskID: 3, BlockerID: 1}},
+				},
+			}
+			taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+			res, err := taskService.Graph(context.Background(), dto.TaskGraphRequest{ID: 1})
+			if err != nil {
+				t.Fatalf("expected error: %v, got: %v", nil, err)
+			}
+			if res.Blocked != tt.want {
+				t.Errorf("expected blocked: %v, got: %v", tt.want, res.Blocked)
+			}
+			if len(res.Tasks) != 3 || len(res.Dependencies) != 2 {
+				t.Errorf("unexpected graph: %+v", res)
+			}
+		})
+	}
+}
+
+func TestUpdateToTerminalWithOpenBlockers(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getRes:   Task{ID: 1, Status: "in_progress"},
+		blockers: []Task{{ID: 2, Status: "done"}, {ID: 3, Status: "todo"}},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.UpdateTaskRequest{ID: 1, Title: "title", Description: "description", Status: "done"}
+	_, err := taskService.Update(context.Background(), req)
+	if !errors.Is(err, customerror.ErrBlocked) {
+		t.Fatalf("expected error: %v, got: %v", customerror.ErrBlocked, err)
+	}
+	if mockTaskStorage.updTask.ID != 0 {
+		t.Errorf("expected no update, got: %+v", mockTaskStorage.updTask)
+	}
+}
+
+func TestUpdateToTerminalWithClosedBlockers(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getRes:   Task{ID: 1, Status: "in_progress"},
+		blockers: []Task{{ID: 2, Status: "done"}, {ID: 3, Status: "cancelled"}},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.UpdateTaskRequest{ID: 1, Title: "title", Description: "description", Status: "done"}
+	if _, err := taskService.Update(context.Background(), req); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+}
+
+// linkStorage keeps live tasks and their dependencies in memory, so that
+// blockers come and go the way they do in the storage: a blocker is listed
+// while it is linked and not in the trash.
+type linkStorage struct {
+	*mockTaskStorage
+	tasks map[uint]Task
+	deps  []TaskDependency
+}
+
+func newLinkStorage(tasks []Task, deps []TaskDependency) *linkStorage {
+	s := &linkStorage{mockTaskStorage: &mockTaskStorage{}, tasks: make(map[uint]Task), deps: deps}
+	for _, task := range tasks {
+		s.tasks[task.ID] = task
+	}
+	return s
+}
+
+func (s *linkStorage) Get(_ context.Context, id uint) (Task, error) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return Task{}, customerror.ErrIDNotFound
+	}
+	return task, nil
+}
+
+func (s *linkStorage) Lock(ctx context.Context, id uint) (Task, error) {
+	return s.Get(ctx, id)
+}
+
+func (s *linkStorage) Update(_ context.Context, task Task) error {
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *linkStorage) Delete(_ context.Context, task Task) error {
+	delete(s.tasks, task.ID)
+	return nil
+}
+
+func (s *linkStorage) RemoveDependency(_ context.Context, dep TaskDependency) error {
+	for i, d := range s.deps {
+		if d == dep {
+			s.deps = append(s.deps[:i], s.deps[i+1:]...)
+			return nil
+		}
+	}
+	return customerror.ErrIDNotFound
+}
+
+func (s *linkStorage) ListBlockers(_ context.Context, id uint) ([]Task, error) {
+	var blockers []Task
+	for _, dep := range s.deps {
+		if blocker, ok := s.tasks[dep.BlockerID]; ok && dep.TaskID == id {
+			blockers = append(blockers, blocker)
+		}
+	}
+	return blockers, nil
+}
+
+func (s *linkStorage) DependencyGraph(ctx context.Context, id uint) (TaskGraph, error) {
+	root, err := s.Get(ctx, id)
+	if err != nil {
+		return TaskGraph{}, err
+	}
+	graph := TaskGraph{Root: root, Tasks: []Task{root}}
+	for _, dep := range s.deps {
+		blocker, live := s.tasks[dep.BlockerID]
+		if _, ok := s.tasks[dep.TaskID]; !ok || !live || dep.TaskID != id {
+			continue
+		}
+		graph.Tasks = append(graph.Tasks, blocker)
+		graph.Dependencies = append(graph.Dependencies, dep)
+	}
+	return graph, nil
+}
+
+func (s *linkStorage) Atomic(_ context.Context, fn func(TaskStorer) error) error {
+	return fn(s)
+}
+
+func TestUnblock(t *testing.T) {
+	storage := newLinkStorage(
+		[]Task{{ID: 1, Status: "in_progress"}, {ID: 2, Status: "todo"}},
+		[]TaskDependency{{TaskID: 1, BlockerID: 2}},
+	)
+	taskService := NewTaskService(WithTaskStorage(storage))
+	ctx := context.Background()
+	done := dto.UpdateTaskRequest{ID: 1, Title: "title", Description: "description", Status: "done"}
+
+	if _, err := taskService.Update(ctx, done); !errors.Is(err, customerror.ErrBlocked) {
+		t.Fatalf("expected error: %v, got: %v", customerror.ErrBlocked, err)
+	}
+	res, err := taskService.Unblock(ctx, dto.TaskDependencyRequest{ID: 1, BlockerID: 2})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if res.Blocked || len(res.Dependencies) != 0 {
+		t.Errorf("expected an unblocked task without dependencies, got: %+v", res)
+	}
+	if _, err := taskService.Update(ctx, done); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+}
+
+func TestUnblockWithStorageError(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{linkErr: errStorageLink}))
+
+	if _, err := taskService.Unblock(context.Background(), dto.TaskDependencyRequest{ID: 1, BlockerID: 2}); !errors.Is(err, errStorageLink) {
+		t.Errorf("expected error: %v, got: %v", errStorageLink, err)
+	}
+}
+
+func TestBlockerInTrash(t *testing.T) {
+	storage := newLinkStorage(
+		[]Task{{ID: 1, Status: "in_progress"}, {ID: 2, Status: "todo"}, {ID: 3, Status: "done"}},
+		[]TaskDependency{{TaskID: 1, BlockerID: 2}, {TaskID: 1, BlockerID: 3}},
+	)
+	taskService := NewTaskService(WithTaskStorage(storage))
+	ctx := context.Background()
+
+	if err := taskService.Delete(ctx, dto.DeleteTaskRequest{ID: 2}); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	res, err := taskService.Graph(ctx, dto.TaskGraphRequest{ID: 1})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if res.Blocked {
+		t.Errorf("expected a task blocked by a trashed task to be unblocked, got: %+v", res)
+	}
+	done := dto.UpdateTaskRequest{ID: 1, Title: "title", Description: "description", Status: "done"}
+	if _, err := taskService.Update(ctx, done); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+}
@@ -1,12 +1,78 @@
 package dto
 
-import "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+import (
+	"io"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+const (
+	// DefaultPageSize is the page size used when a list request sets none.
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page a list request may ask for.
+	MaxPageSize = 100
+	// MaxAttachmentSize is the largest file in bytes that can be attached
+	// to a task.
+	MaxAttachmentSize = 10 << 20
+	// MaxBulkOperations is the largest number of operations a bulk request
+	// may carry.
+	MaxBulkOperations = 500
+	// MaxBulkSize is the largest bulk request body in bytes.
+	MaxBulkSize = 1 << 20
+	// MaxImportSize is the largest import body in bytes.
+	MaxImportSize = 32 << 20
+	// MaxPatchSize is the largest patch body in bytes.
+	MaxPatchSize = 64 << 10
+	// MaxTaskSize is the largest body in bytes of a request creating or
+	// updating a task.
+	MaxTaskSize = 64 << 10
+	// MaxImportErrors is the largest number of line errors an import
+	// reports; further failed lines are only counted.
+	MaxImportErrors = 100
+)
+
+// Export and import formats.
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+// Bulk modes. An atomic bulk request applies all of its operations or none
+// of them, a best effort one applies each operation that succeeds.
+const (
+	BulkAtomic     = "atomic"
+	BulkBestEffort = "best_effort"
+)
+
+// Bulk operations.
+const (
+	BulkSet    = "set"
+	BulkUpdate = "update"
+	BulkDelete = "delete"
+	// BulkImport creates a task from a line of an import. Only /import
+	// makes it; the clients of /bulk cannot.
+	BulkImport = "import"
+)
 
 type SetTaskRequest struct {
-	ID          uint   `json:"id" validate:"required,numeric"`
-	Title       string `json:"title" validate:"required,max=255,min=3"`
-	Description string `json:"description" validate:"required,max=255,min=3"`
-	Status      string `json:"status" validate:"required,max=255,min=3"`
+	ID          uint       `json:"id" validate:"required,numeric"`
+	Title       string     `json:"title" validate:"required,max=255,min=3"`
+	Description string     `json:"description" validate:"required,max=255,min=3"`
+	Status      string     `json:"status" validate:"omitempty,max=255,min=3"`
+	Priority    int        `json:"priority" validate:"omitempty,min=1,max=5"`
+	DueAt       *time.Time `json:"due_at"`
+	Assignee    string     `json:"assignee" validate:"max=255"`
+}
+
+// ImportTaskRequest creates a task from a line of an import, an exported
+// task, keeping its status, timestamps, tags and parent.
+type ImportTaskRequest struct {
+	SetTaskRequest
+	Tags      []string   `json:"tags"`
+	ParentID  uint       `json:"parent_id"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
 }
 
 type GetTaskRequest struct {
@@ -14,22 +80,294 @@ type GetTaskRequest struct {
 }
 
 type ListTaskRequest struct {
-	Status string `json:"status" validate:"required"`
+	Statuses      []string  `json:"status" validate:"max=20,dive,min=3,max=255"`
+	Title         string    `json:"title" validate:"max=255"`
+	Description   string    `json:"description" validate:"max=255"`
+	IDFrom        uint      `json:"id_from"`
+	IDTo          uint      `json:"id_to" validate:"omitempty,gtefield=IDFrom"`
+	Priorities    []int     `json:"priority" validate:"max=5,dive,min=1,max=5"`
+	Assignee      string    `json:"assignee" validate:"max=255"`
+	DueAfter      time.Time `json:"due_after"`
+	DueBefore     time.Time `json:"due_before" validate:"omitempty,gtfield=DueAfter"`
+	Tags          []string  `json:"tag" validate:"max=20,dive,min=1,max=64"`
+	TagMode       string    `json:"tag_mode" validate:"omitempty,oneof=any all"`
+	CreatedBy     string    `json:"created_by" validate:"max=255"`
+	UpdatedBy     string    `json:"updated_by" validate:"max=255"`
+	CreatedAfter  time.Time `json:"created_after"`
+	CreatedBefore time.Time `json:"created_before" validate:"omitempty,gtfield=CreatedAfter"`
+	UpdatedAfter  time.Time `json:"updated_after"`
+	UpdatedBefore time.Time `json:"updated_before" validate:"omitempty,gtfield=UpdatedAfter"`
+	SortBy        string    `json:"sort" validate:"omitempty,oneof=id created_at updated_at created_by updated_by deleted_at priority due_at"`
+	SortOrder     string    `json:"order" validate:"omitempty,oneof=asc desc"`
+	Deleted       bool      `json:"deleted"`
+	Limit         int       `json:"limit"`
+	Cursor        string    `json:"cursor"`
+}
+
+// SearchTaskRequest is a full-text search over live task titles and
+// descriptions.
+type SearchTaskRequest struct {
+	Query  string `json:"q" validate:"required,max=255"`
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor"`
 }
 
 type UpdateTaskRequest struct {
-	ID          uint   `json:"id" validate:"required"`
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	Status      string `json:"status" validate:"required"`
+	ID          uint       `json:"id" validate:"required"`
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description" validate:"required"`
+	Status      string     `json:"status" validate:"required"`
+	Priority    int        `json:"priority" validate:"omitempty,min=1,max=5"`
+	DueAt       *time.Time `json:"due_at"`
+	Assignee    string     `json:"assignee" validate:"max=255"`
+}
+
+// Patch media types.
+const (
+	MergePatch = "application/merge-patch+json"
+	JSONPatch  = "application/json-patch+json"
+)
+
+// PatchTaskRequest changes the task ID with Patch, a JSON Merge Patch or a
+// JSON Patch as told by Type. The patch applies to the fields of
+// UpdateTaskRequest other than ID.
+type PatchTaskRequest struct {
+	ID    uint   `json:"id" validate:"required"`
+	Type  string `json:"type" validate:"required,oneof=application/merge-patch+json application/json-patch+json"`
+	Patch []byte `json:"patch" validate:"required,min=1"`
+}
+
+// TagTaskRequest adds tags to or removes tags from a task.
+type TagTaskRequest struct {
+	ID   uint     `json:"id" validate:"required"`
+	Tags []string `json:"tags" validate:"required,min=1,max=20,dive,min=1,max=64"`
+}
+
+// SetParentRequest makes a task a subtask of ParentID, or a top level task
+// when ParentID is 0.
+type SetParentRequest struct {
+	ID       uint `json:"id" validate:"required"`
+	ParentID uint `json:"parent_id"`
+}
+
+// TaskDependencyRequest links a task to a task blocking it.
+type TaskDependencyRequest struct {
+	ID        uint `json:"id" validate:"required"`
+	BlockerID uint `json:"blocker_id" validate:"required"`
+}
+
+// TaskGraphRequest asks for the dependency graph around a task.
+type TaskGraphRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+// AddCommentRequest adds a comment to a task.
+type AddCommentRequest struct {
+	TaskID uint   `json:"task_id" validate:"required"`
+	Body   string `json:"body" validate:"required,max=4096"`
+}
+
+// EditCommentRequest replaces the body of a comment.
+type EditCommentRequest struct {
+	ID   uint   `json:"id" validate:"required"`
+	Body string `json:"body" validate:"required,max=4096"`
+}
+
+type DeleteCommentRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+// ListCommentsRequest asks for a page of the comments of a task.
+type ListCommentsRequest struct {
+	TaskID uint   `json:"task_id" validate:"required"`
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor"`
+}
+
+// AttachRequest attaches the file read from Content to a task.
+type AttachRequest struct {
+	TaskID  uint      `json:"task_id" validate:"required"`
+	Name    string    `json:"name" validate:"required,max=255"`
+	Content io.Reader `json:"-" validate:"required"`
+}
+
+type GetAttachmentRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+type DeleteAttachmentRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+type ListAttachmentsRequest struct {
+	TaskID uint `json:"task_id" validate:"required"`
+}
+
+// AddWebhookRequest subscribes URL to the events of Events, every event
+// when empty. Deliveries are signed with Secret, which is generated when
+// empty.
+type AddWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,http_url,max=2048"`
+	Events []string `json:"events" validate:"dive,oneof=created updated deleted"`
+	Secret string   `json:"secret" validate:"max=255"`
+}
+
+type DeleteWebhookRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+type EnableWebhookRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+// ListWebhookDeliveriesRequest asks for the latest deliveries to a webhook.
+type ListWebhookDeliveriesRequest struct {
+	ID    uint `json:"id" validate:"required"`
+	Limit int  `json:"limit"`
+}
+
+// DeliverWebhookRequest sends Payload, the event EventID, to a webhook.
+// Attempt counts the times the event was sent to it, this one included.
+type DeliverWebhookRequest struct {
+	WebhookID uint   `json:"webhook_id" validate:"required"`
+	EventID   uint64 `json:"event_id"`
+	EventType string `json:"event_type"`
+	TaskID    uint   `json:"task_id"`
+	Payload   []byte `json:"payload"`
+	Attempt   int    `json:"attempt"`
 }
 
 type DeleteTaskRequest struct {
 	ID uint `json:"id" validate:"required"`
 }
 
+type RestoreTaskRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+type PurgeTaskRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+// TaskHistoryRequest asks for every recorded change of a task.
+type TaskHistoryRequest struct {
+	ID uint `json:"id" validate:"required"`
+}
+
+// TaskSnapshotRequest asks for the state of a task as it was at At.
+type TaskSnapshotRequest struct {
+	ID uint      `json:"id" validate:"required"`
+	At time.Time `json:"at" validate:"required"`
+}
+
+// PurgeTrashRequest selects the trashed tasks deleted before Before.
+type PurgeTrashRequest struct {
+	Before time.Time `json:"before" validate:"required"`
+}
+
+// ExportTaskRequest streams every task matching Filter to Emit, in the
+// order of Filter. The limit and cursor of Filter are ignored. An error
+// returned by Emit stops the export.
+type ExportTaskRequest struct {
+	Filter ListTaskRequest
+	Emit   func(TaskResponse) error
+}
+
+// BulkRequest applies Operations in order. Mode defaults to atomic.
+type BulkRequest struct {
+	Mode       string          `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Operations []BulkOperation `json:"operations" validate:"required,min=1,max=500"`
+}
+
+// BulkOperation is one operation of a bulk request. Set and update take the
+// fields of SetTaskRequest and UpdateTaskRequest, delete only takes ID.
+type BulkOperation struct {
+	Op          string     `json:"op" validate:"required,oneof=set update delete"`
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+	Assignee    string     `json:"assignee"`
+	// The fields of BulkImport, which clients do not send.
+	Tags      []string   `json:"-"`
+	ParentID  uint       `json:"-"`
+	CreatedAt *time.Time `json:"-"`
+	UpdatedAt *time.Time `json:"-"`
+}
+
+// Request returns the SetTaskRequest, UpdateTaskRequest, DeleteTaskRequest
+// or ImportTaskRequest the operation stands for, nil for an unknown Op.
+func (o BulkOperation) Request() any {
+	switch o.Op {
+	case BulkSet:
+		return SetTaskRequest{
+			ID:          o.ID,
+			Title:       o.Title,
+			Description: o.Description,
+			Status:      o.Status,
+			Priority:    o.Priority,
+			DueAt:       o.DueAt,
+			Assignee:    o.Assignee,
+		}
+	case BulkUpdate:
+		return UpdateTaskRequest{
+			ID:          o.ID,
+			Title:       o.Title,
+			Description: o.Description,
+			Status:      o.Status,
+			Priority:    o.Priority,
+			DueAt:       o.DueAt,
+			Assignee:    o.Assignee,
+		}
+	case BulkDelete:
+		return DeleteTaskRequest{ID: o.ID}
+	case BulkImport:
+		return ImportTaskRequest{
+			SetTaskRequest: SetTaskRequest{
+				ID:          o.ID,
+				Title:       o.Title,
+				Description: o.Description,
+				Status:      o.Status,
+				Priority:    o.Priority,
+				DueAt:       o.DueAt,
+				Assignee:    o.Assignee,
+			},
+			Tags:      o.Tags,
+			ParentID:  o.ParentID,
+			CreatedAt: o.CreatedAt,
+			UpdatedAt: o.UpdatedAt,
+		}
+	}
+	return nil
+}
+
 func (l ListTaskRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
-	model.Status = l.Status
+	model.Filter = models.TaskFilter{
+		Statuses:      l.Statuses,
+		Title:         l.Title,
+		Description:   l.Description,
+		IDFrom:        l.IDFrom,
+		IDTo:          l.IDTo,
+		Priorities:    l.Priorities,
+		Assignee:      l.Assignee,
+		DueAfter:      l.DueAfter,
+		DueBefore:     l.DueBefore,
+		Tags:          l.Tags,
+		TagMode:       l.TagMode,
+		CreatedBy:     l.CreatedBy,
+		UpdatedBy:     l.UpdatedBy,
+		CreatedAfter:  l.CreatedAfter,
+		CreatedBefore: l.CreatedBefore,
+		UpdatedAfter:  l.UpdatedAfter,
+		UpdatedBefore: l.UpdatedBefore,
+		SortBy:        l.SortBy,
+		SortOrder:     l.SortOrder,
+		Deleted:       l.Deleted,
+		Limit:         l.Limit,
+		Cursor:        l.Cursor,
+	}
 	return *model
 }
 
@@ -38,6 +376,9 @@ func (u UpdateTaskRequest) TaskJobMapper(model *models.TaskJobModel) models.Task
 	model.Title = u.Title
 	model.Description = u.Description
 	model.Status = u.Status
+	model.Priority = u.Priority
+	model.DueAt = u.DueAt
+	model.Assignee = u.Assignee
 	return *model
 }
 
@@ -46,5 +387,79 @@ func (s SetTaskRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJob
 	model.Title = s.Title
 	model.Description = s.Description
 	model.Status = s.Status
+	model.Priority = s.Priority
+	model.DueAt = s.DueAt
+	model.Assignee = s.Assignee
+	return *model
+}
+
+func (l SearchTaskRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.Query = l.Query
+	model.Filter = models.TaskFilter{
+		Limit:  l.Limit,
+		Cursor: l.Cursor,
+	}
+	return *model
+}
+
+func (p PatchTaskRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.ID = p.ID
+	model.PatchType = p.Type
+	model.Patch = p.Patch
+	return *model
+}
+
+func (t TagTaskRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.ID = t.ID
+	model.Tags = t.Tags
+	return *model
+}
+
+func (p SetParentRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.ID = p.ID
+	model.ParentID = p.ParentID
+	return *model
+}
+
+func (d TaskDependencyRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.ID = d.ID
+	model.BlockerID = d.BlockerID
+	return *model
+}
+
+func (c AddCommentRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.ID = c.TaskID
+	model.Body = c.Body
+	return *model
+}
+
+func (c EditCommentRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.CommentID = c.ID
+	model.Body = c.Body
+	return *model
+}
+
+func (a AttachRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.ID = a.TaskID
+	model.Upload = &models.Upload{
+		Name:    a.Name,
+		Content: a.Content,
+	}
+	return *model
+}
+
+func (b BulkRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.Mode = b.Mode
+	model.Operations = make([]models.BulkOperation, len(b.Operations))
+	for i, op := range b.Operations {
+		model.Operations[i] = models.BulkOperation(op)
+	}
+	return *model
+}
+
+func (a AddWebhookRequest) TaskJobMapper(model *models.TaskJobModel) models.TaskJobModel {
+	model.URL = a.URL
+	model.EventTypes = a.Events
+	model.Secret = a.Secret
 	return *model
 }
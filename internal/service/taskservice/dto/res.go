@@ -1,8 +1,323 @@
 package dto
 
+import (
+	"io"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/workflow"
+)
+
+// TaskResponse is a task as returned to clients. Overdue is set by the
+// service for tasks past their due date that are still open.
 type TaskResponse struct {
-	ID          uint   `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Assignee    string     `json:"assignee,omitempty"`
+	Tags        []string   `json:"tags"`
+	ParentID    uint       `json:"parent_id,omitempty"`
+	Overdue     bool       `json:"overdue"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CreatedBy   string     `json:"created_by"`
+	UpdatedBy   string     `json:"updated_by"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy   string     `json:"deleted_by,omitempty"`
+}
+
+func NewTaskResponse(task models.Task) TaskResponse {
+	return TaskResponse{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueAt:       task.DueAt,
+		Assignee:    task.Assignee,
+		Tags:        task.Tags,
+		ParentID:    task.ParentID,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+		CreatedBy:   task.CreatedBy,
+		UpdatedBy:   task.UpdatedBy,
+		DeletedAt:   task.DeletedAt,
+		DeletedBy:   task.DeletedBy,
+	}
+}
+
+// TaskListResponse is one page of a task listing. NextCursor is empty on
+// the last page.
+type TaskListResponse struct {
+	Tasks      []TaskResponse `json:"tasks"`
+	Total      int64          `json:"total"`
+	NextCursor string         `json:"next_cursor"`
+}
+
+// TaskSearchHitResponse is a task found by a search. Highlights hold the
+// HTML escaped title and description with the matched words wrapped in
+// <mark> tags, and are empty for a field without matches.
+type TaskSearchHitResponse struct {
+	Task       TaskResponse   `json:"task"`
+	Score      float64        `json:"score"`
+	Highlights TaskHighlights `json:"highlights"`
+}
+
+type TaskHighlights struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TaskSearchResponse is one page of search hits, best first.
+type TaskSearchResponse struct {
+	Hits       []TaskSearchHitResponse `json:"hits"`
+	Total      int64                   `json:"total"`
+	NextCursor string                  `json:"next_cursor"`
+}
+
+type TaskEventResponse struct {
+	ID        uint64        `json:"id"`
+	TaskID    uint          `json:"task_id"`
+	Type      string        `json:"type"`
+	OldValue  *TaskResponse `json:"old_value"`
+	NewValue  *TaskResponse `json:"new_value"`
+	Actor     string        `json:"actor"`
+	RequestID string        `json:"request_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+func NewTaskEventResponse(event models.TaskEvent) TaskEventResponse {
+	res := TaskEventResponse{
+		ID:        event.ID,
+		TaskID:    event.TaskID,
+		Type:      event.Type,
+		Actor:     event.Actor,
+		RequestID: event.RequestID,
+		CreatedAt: event.CreatedAt,
+	}
+	if event.OldValue != nil {
+		old := NewTaskResponse(*event.OldValue)
+		res.OldValue = &old
+	}
+	if event.NewValue != nil {
+		updated := NewTaskResponse(*event.NewValue)
+		res.NewValue = &updated
+	}
+	return res
+}
+
+// WorkflowResponse describes the status workflow. Transitions lists the
+// next statuses of every status, empty for terminal ones.
+type WorkflowResponse struct {
+	Statuses    []string            `json:"statuses"`
+	Initial     string              `json:"initial"`
+	Transitions map[string][]string `json:"transitions"`
+	Terminal    []string            `json:"terminal"`
+}
+
+func NewWorkflowResponse(w workflow.Workflow) WorkflowResponse {
+	res := WorkflowResponse{
+		Statuses:    w.Statuses,
+		Initial:     w.Initial,
+		Transitions: make(map[string][]string, len(w.Statuses)),
+		Terminal:    w.Terminal,
+	}
+	if res.Terminal == nil {
+		res.Terminal = []string{}
+	}
+	for _, status := range w.Statuses {
+		res.Transitions[status] = w.Next(status)
+	}
+	return res
+}
+
+// TagCountResponse is a tag and the number of live tasks carrying it.
+type TagCountResponse struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// TaskGraphResponse is the dependency graph around the task ID. Tasks holds
+// the task itself, its parent and subtasks, and the tasks it transitively
+// blocks or is blocked by. Blocked tells whether any direct blocker of the
+// task is still open.
+type TaskGraphResponse struct {
+	ID           uint                     `json:"id"`
+	Blocked      bool                     `json:"blocked"`
+	Tasks        []TaskResponse           `json:"tasks"`
+	Dependencies []TaskDependencyResponse `json:"dependencies"`
+}
+
+// TaskDependencyResponse is a link from a task to a task blocking it.
+type TaskDependencyResponse struct {
+	TaskID    uint `json:"task_id"`
+	BlockerID uint `json:"blocker_id"`
+}
+
+type CommentResponse struct {
+	ID        uint      `json:"id"`
+	TaskID    uint      `json:"task_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewCommentResponse(comment models.Comment) CommentResponse {
+	return CommentResponse{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+// CommentListResponse is one page of the comments of a task, oldest first.
+// NextCursor is empty on the last page.
+type CommentListResponse struct {
+	Comments   []CommentResponse `json:"comments"`
+	Total      int64             `json:"total"`
+	NextCursor string            `json:"next_cursor"`
+}
+
+type AttachmentResponse struct {
+	ID          uint      `json:"id"`
+	TaskID      uint      `json:"task_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewAttachmentResponse(a models.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID,
+		TaskID:      a.TaskID,
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// AttachmentDownload is an attachment and its contents. The receiver must
+// close Content.
+type AttachmentDownload struct {
+	Attachment AttachmentResponse
+	Content    io.ReadCloser
+}
+
+// WebhookResponse is a webhook. Secret is only told when the webhook is
+// added.
+type WebhookResponse struct {
+	ID         uint       `json:"id"`
+	URL        string     `json:"url"`
+	Events     []string   `json:"events"`
+	Secret     string     `json:"secret,omitempty"`
+	Enabled    bool       `json:"enabled"`
+	Failures   int        `json:"failures"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+func NewWebhookResponse(w models.Webhook) WebhookResponse {
+	events := w.Events
+	if events == nil {
+		events = []string{}
+	}
+	return WebhookResponse{
+		ID:         w.ID,
+		URL:        w.URL,
+		Events:     events,
+		Enabled:    w.Enabled,
+		Failures:   w.Failures,
+		CreatedBy:  w.CreatedBy,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+		DisabledAt: w.DisabledAt,
+	}
+}
+
+// WebhookDeliveryResponse is an attempt at sending an event to a webhook.
+// StatusCode is zero when no response was received.
+type WebhookDeliveryResponse struct {
+	ID         uint      `json:"id"`
+	WebhookID  uint      `json:"webhook_id"`
+	EventID    uint64    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	TaskID     uint      `json:"task_id"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+	Success    bool      `json:"success"`
+	Duration   int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewWebhookDeliveryResponse(d models.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:         d.ID,
+		WebhookID:  d.WebhookID,
+		EventID:    d.EventID,
+		EventType:  d.EventType,
+		TaskID:     d.TaskID,
+		Attempt:    d.Attempt,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		Success:    d.Success,
+		Duration:   d.Duration,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+// BulkResponse reports the outcome of a bulk request. Committed tells
+// whether its changes were kept; an atomic request that failed keeps none.
+type BulkResponse struct {
+	Mode      string       `json:"mode"`
+	Committed bool         `json:"committed"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []BulkResult `json:"results"`
+}
+
+// BulkResult is the outcome of the operation at Index of a bulk request.
+// Task is the task as left by a successful set or update. Err is the
+// failure of the operation, or nil; Status, Code and Error describe it to
+// clients.
+type BulkResult struct {
+	Index  int           `json:"index"`
+	Op     string        `json:"op"`
+	ID     uint          `json:"id"`
+	Status int           `json:"status"`
+	Task   *TaskResponse `json:"task,omitempty"`
+	Code   string        `json:"code,omitempty"`
+	Error  string        `json:"error,omitempty"`
+	Err    error         `json:"-"`
+}
+
+// ImportResponse reports the outcome of an import. Errors holds the first
+// MaxImportErrors failed lines.
+type ImportResponse struct {
+	Imported int               `json:"imported"`
+	Failed   int               `json:"failed"`
+	Errors   []ImportLineError `json:"errors"`
+}
+
+// ImportLineError is the failure of the line at Line of an import, counted
+// from 1. ID is the task the line stands for, 0 if it could not be read.
+// Code is the problem code of the failure.
+type ImportLineError struct {
+	Line  int    `json:"line"`
+	ID    uint   `json:"id,omitempty"`
+	Code  string `json:"code"`
+	Error string `json:"error"`
 }
@@ -0,0 +1,120 @@
+package taskservice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+type published struct {
+	typ string
+	id  uint
+}
+
+type mockPublisher struct {
+	events []published
+}
+
+func (m *mockPublisher) Publish(typ string, task dto.TaskResponse) {
+	m.events = append(m.events, published{typ, task.ID})
+}
+
+func TestEventsPublished(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *mockTaskStorage
+		run     func(TaskService) error
+		want    []published
+	}{
+		{
+			name:    "set",
+			storage: &mockTaskStorage{getErr: errStorageGet},
+			run: func(s TaskService) error {
+				_, err := s.Set(context.Background(), dto.SetTaskRequest{ID: 1, Title: "title", Description: "description"})
+				return err
+			},
+			want: []published{{events.Created, 1}},
+		},
+		{
+			name:    "update",
+			storage: &mockTaskStorage{getRes: Task{ID: 1, Status: "todo"}},
+			run: func(s TaskService) error {
+				_, err := s.Update(context.Background(), dto.UpdateTaskRequest{ID: 1, Title: "title", Description: "description", Status: "todo"})
+				return err
+			},
+			want: []published{{events.Updated, 1}},
+		},
+		{
+			name:    "delete",
+			storage: &mockTaskStorage{getRes: Task{ID: 1, Status: "todo"}},
+			run: func(s TaskService) error {
+				return s.Delete(context.Background(), dto.DeleteTaskRequest{ID: 1})
+			},
+			want: []published{{events.Deleted, 1}},
+		},
+		{
+			name:    "failed update",
+			storage: &mockTaskStorage{getRes: Task{ID: 1, Status: "todo"}, updateErr: errStorageUpdate},
+			run: func(s TaskService) error {
+				_, err := s.Update(context.Background(), dto.UpdateTaskRequest{ID: 1, Title: "title", Description: "description", Status: "todo"})
+				if err == nil {
+					t.Errorf("expected error: %v, got: nil", errStorageUpdate)
+				}
+				return nil
+			},
+		},
+		{
+			name:    "committed bulk",
+			storage: &mockTaskStorage{getRes: Task{ID: 1, Status: "todo"}},
+			run: func(s TaskService) error {
+				_, err := s.Bulk(context.Background(), dto.BulkRequest{
+					Operations: []dto.BulkOperation{
+						{Op: dto.BulkDelete, ID: 1},
+						{Op: dto.BulkDelete, ID: 2},
+					},
+				})
+				return err
+			},
+			want: []published{{events.Deleted, 1}, {events.Deleted, 1}},
+		},
+		{
+			name:    "rolled back bulk",
+			storage: &mockTaskStorage{getRes: Task{ID: 1, Status: "todo"}},
+			run: func(s TaskService) error {
+				_, err := s.Bulk(context.Background(), bulkRequest(dto.BulkAtomic))
+				return err
+			},
+		},
+		{
+			name:    "patch",
+			storage: &mockTaskStorage{getRes: Task{ID: 1, Title: "title", Description: "description", Status: "todo"}},
+			run: func(s TaskService) error {
+				_, err := s.Patch(context.Background(), dto.PatchTaskRequest{ID: 1, Type: dto.MergePatch, Patch: []byte(`{"title":"new title"}`)})
+				return err
+			},
+			want: []published{{events.Updated, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &mockPublisher{}
+			taskService := NewTaskService(WithTaskStorage(tt.storage), WithEvents(publisher))
+
+			if err := tt.run(taskService); err != nil {
+				t.Fatalf("expected error: %v, got: %v", nil, err)
+			}
+			if len(publisher.events) != len(tt.want) {
+				t.Fatalf("expected events: %v, got: %v", tt.want, publisher.events)
+			}
+			for i := range tt.want {
+				if publisher.events[i] != tt.want[i] {
+					t.Errorf("expected events: %v, got: %v", tt.want, publisher.events)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// Export emits the tasks matching the filter of req one at a time. They
+// are read a page at a time so that only one page is held in memory.
+func (s *taskService) Export(ctx context.Context, req dto.ExportTaskRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		req.Filter.Cursor = ""
+		filter, err := taskFilter(req.Filter)
+		if err != nil {
+			return fmt.Errorf("service.Export: %w", err)
+		}
+		filter.Limit = dto.MaxPageSize
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			tasks, err := s.taskStorage.List(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("service.Export storage.List: %w", err)
+			}
+			for _, task := range tasks {
+				if err := req.Emit(s.taskResponse(task)); err != nil {
+					return fmt.Errorf("service.Export: %w", err)
+				}
+			}
+			if len(tasks) < filter.Limit {
+				return nil
+			}
+			filter.Cursor = models.NewTaskCursor(tasks[len(tasks)-1], req.Filter.SortBy, req.Filter.SortOrder).Encode()
+		}
+	}
+}
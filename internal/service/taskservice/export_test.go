@@ -0,0 +1,109 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestExportWithCancel(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := dto.ExportTaskRequest{
+		Emit: func(dto.TaskResponse) error { return nil },
+	}
+	if err := taskService.Export(ctx, req); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestExportWithStorageError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		listErr: errStorageList,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.ExportTaskRequest{
+		Emit: func(dto.TaskResponse) error { return nil },
+	}
+	if err := taskService.Export(context.Background(), req); !errors.Is(err, errStorageList) {
+		t.Errorf("expected error: %v, got: %v", errStorageList, err)
+	}
+}
+
+func TestExportWithInvalidTag(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.ExportTaskRequest{
+		Filter: dto.ListTaskRequest{Tags: []string{"-bad"}},
+		Emit:   func(dto.TaskResponse) error { return nil },
+	}
+	if err := taskService.Export(context.Background(), req); !errors.Is(err, customerror.ErrTag) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrTag, err)
+	}
+}
+
+func TestExportStopsOnEmitError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		listRes: []models.Task{{ID: 1}, {ID: 2}},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	errEmit := errors.New("client went away")
+	emitted := 0
+	req := dto.ExportTaskRequest{
+		Emit: func(dto.TaskResponse) error {
+			emitted++
+			return errEmit
+		},
+	}
+	if err := taskService.Export(context.Background(), req); !errors.Is(err, errEmit) {
+		t.Errorf("expected error: %v, got: %v", errEmit, err)
+	}
+	if emitted != 1 {
+		t.Errorf("expected %d emitted tasks, got: %d", 1, emitted)
+	}
+}
+
+func TestExportPages(t *testing.T) {
+	page := make([]models.Task, dto.MaxPageSize)
+	for i := range page {
+		page[i] = models.Task{ID: uint(i + 1)}
+	}
+	mockTaskStorage := &mockTaskStorage{
+		pages: [][]models.Task{page, {{ID: uint(dto.MaxPageSize + 1)}}},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	var ids []uint
+	req := dto.ExportTaskRequest{
+		Filter: dto.ListTaskRequest{Limit: 5, Cursor: "ignored"},
+		Emit: func(task dto.TaskResponse) error {
+			ids = append(ids, task.ID)
+			return nil
+		},
+	}
+	if err := taskService.Export(context.Background(), req); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if len(ids) != dto.MaxPageSize+1 || ids[len(ids)-1] != uint(dto.MaxPageSize+1) {
+		t.Errorf("expected %d tasks in order, got: %v", dto.MaxPageSize+1, ids)
+	}
+	filter := mockTaskStorage.filter
+	if filter.Limit != dto.MaxPageSize {
+		t.Errorf("expected limit: %v, got: %v", dto.MaxPageSize, filter.Limit)
+	}
+	cursor, err := models.DecodeTaskCursor(filter.Cursor)
+	if err != nil || cursor.ID != uint(dto.MaxPageSize) {
+		t.Errorf("expected the second page to start after task %d, got: %+v, %v", dto.MaxPageSize, cursor, err)
+	}
+}
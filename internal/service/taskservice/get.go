@@ -12,15 +12,10 @@ func (s *taskService) Get(ctx context.Context, req dto.GetTaskRequest) (dto.Task
 	case <-ctx.Done():
 		return dto.TaskResponse{}, ctx.Err()
 	default:
-		task, err := s.taskStorage.Get(req.ID)
+		task, err := s.taskStorage.Get(ctx, req.ID)
 		if err != nil {
 			return dto.TaskResponse{}, fmt.Errorf("service.Get storage.Get: %w", err)
 		}
-		return dto.TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-		}, nil
+		return s.taskResponse(task), nil
 	}
 }
@@ -0,0 +1,47 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func (s *taskService) History(ctx context.Context, req dto.TaskHistoryRequest) ([]dto.TaskEventResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		events, err := s.taskStorage.ListEvents(ctx, req.ID)
+		if err != nil {
+			return nil, fmt.Errorf("service.History storage.ListEvents: %w", err)
+		}
+		var eventResponses []dto.TaskEventResponse
+		for _, event := range events {
+			eventResponses = append(eventResponses, dto.NewTaskEventResponse(event))
+		}
+		return eventResponses, nil
+	}
+}
+
+// Snapshot rebuilds the task from the last change recorded at or before
+// req.At. A task that had been purged by then is reported as not found.
+func (s *taskService) Snapshot(ctx context.Context, req dto.TaskSnapshotRequest) (dto.TaskResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskResponse{}, ctx.Err()
+	default:
+		event, err := s.taskStorage.GetEventAt(ctx, req.ID, req.At)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Snapshot storage.GetEventAt: %w", err)
+		}
+		if event.NewValue == nil {
+			_id := strconv.Itoa(int(req.ID))
+			return dto.TaskResponse{}, fmt.Errorf("service.Snapshot: %w", customerror.ErrIDNotFound.AddData("'"+_id+"' was purged before "+req.At.Format(time.RFC3339)+"."))
+		}
+		return dto.NewTaskResponse(*event.NewValue), nil
+	}
+}
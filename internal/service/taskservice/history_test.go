@@ -0,0 +1,103 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestHistoryWithCancel(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := taskService.History(ctx, dto.TaskHistoryRequest{ID: 1}); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestHistoryWithStorageError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		historyErr: errStorageHistory,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	if _, err := taskService.History(context.Background(), dto.TaskHistoryRequest{ID: 1}); !errors.Is(err, errStorageHistory) {
+		t.Errorf("expected error: %v, got: %v", errStorageHistory, err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	task := Task{ID: 1, Title: "title", Description: "description", Status: "todo"}
+	mockTaskStorage := &mockTaskStorage{
+		events: []TaskEvent{
+			{ID: 1, TaskID: 1, Type: TaskEventCreated, NewValue: &task, Actor: "alice"},
+			{ID: 2, TaskID: 1, Type: TaskEventDeleted, OldValue: &task, NewValue: &task, Actor: "bob"},
+		},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	res, err := taskService.History(context.Background(), dto.TaskHistoryRequest{ID: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("expected 2 events, got: %d", len(res))
+	}
+	if res[0].OldValue != nil || res[0].NewValue == nil || res[0].NewValue.Title != task.Title {
+		t.Errorf("unexpected created event: %+v", res[0])
+	}
+	if res[1].Type != TaskEventDeleted || res[1].Actor != "bob" {
+		t.Errorf("unexpected deleted event: %+v", res[1])
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	task := Task{ID: 1, Title: "title", Description: "description", Status: "done"}
+	mockTaskStorage := &mockTaskStorage{
+		events: []TaskEvent{
+			{ID: 3, TaskID: 1, Type: TaskEventUpdated, NewValue: &task},
+		},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	res, err := taskService.Snapshot(context.Background(), dto.TaskSnapshotRequest{ID: 1, At: time.Now()})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if res.Status != task.Status {
+		t.Errorf("expected status: %v, got: %v", task.Status, res.Status)
+	}
+}
+
+func TestSnapshotAfterPurge(t *testing.T) {
+	task := Task{ID: 1}
+	mockTaskStorage := &mockTaskStorage{
+		events: []TaskEvent{
+			{ID: 4, TaskID: 1, Type: TaskEventPurged, OldValue: &task},
+		},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	if _, err := taskService.Snapshot(context.Background(), dto.TaskSnapshotRequest{ID: 1, At: time.Now()}); !errors.Is(err, customerror.ErrIDNotFound) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrIDNotFound, err)
+	}
+}
+
+func TestSnapshotWithStorageError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		historyErr: errStorageHistory,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	if _, err := taskService.Snapshot(context.Background(), dto.TaskSnapshotRequest{ID: 1, At: time.Now()}); !errors.Is(err, errStorageHistory) {
+		t.Errorf("expected error: %v, got: %v", errStorageHistory, err)
+	}
+}
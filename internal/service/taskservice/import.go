@@ -0,0 +1,99 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// importTask creates the task of a line of an import, which is a task of an
+// export: unlike Set it may be in any status of the workflow and keeps the
+// timestamps, tags and parent it was exported with. Its parent must exist
+// already, stored before or imported on an earlier line. The actors of the
+// export are not kept, since nothing vouches for them; the task is recorded
+// as created and updated by the caller.
+func (s *taskService) importTask(ctx context.Context, req dto.ImportTaskRequest) (dto.TaskResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskResponse{}, ctx.Err()
+	default:
+		if _, err := s.taskStorage.Get(ctx, req.ID); err == nil {
+			_id := strconv.Itoa(int(req.ID))
+			return dto.TaskResponse{}, fmt.Errorf("service.Import storage.Get: %w", customerror.ErrIDExists.AddData("'"+_id+"' already exists in the database."))
+		}
+		status := s.workflow.Initial
+		if strings.TrimSpace(req.Status) != "" {
+			known, err := s.knownStatus(req.Status)
+			if err != nil {
+				return dto.TaskResponse{}, fmt.Errorf("service.Import: %w", err)
+			}
+			status = known
+		}
+		var tags []string
+		if len(req.Tags) > 0 {
+			normalized, err := normalizeTags(req.Tags)
+			if err != nil {
+				return dto.TaskResponse{}, fmt.Errorf("service.Import: %w", err)
+			}
+			// Sorted, as the storage keeps them.
+			sort.Strings(normalized)
+			tags = normalized
+		}
+		now := time.Now().UTC()
+		createdAt, updatedAt := now, now
+		if req.CreatedAt != nil {
+			createdAt = req.CreatedAt.UTC()
+		}
+		if req.UpdatedAt != nil {
+			updatedAt = req.UpdatedAt.UTC()
+		}
+		actor := util.ActorFromContext(ctx)
+		task := models.Task{
+			ID:          req.ID,
+			Title:       req.Title,
+			Description: req.Description,
+			Status:      status,
+			Priority:    req.Priority,
+			DueAt:       req.DueAt,
+			Assignee:    req.Assignee,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			CreatedBy:   actor,
+			UpdatedBy:   actor,
+		}
+		err := s.taskStorage.Atomic(ctx, func(tx TaskStorer) error {
+			if err := tx.Set(ctx, task); err != nil {
+				return fmt.Errorf("storage.Set: %w", err)
+			}
+			if len(tags) > 0 {
+				if err := tx.AddTags(ctx, task, tags); err != nil {
+					return fmt.Errorf("storage.AddTags: %w", err)
+				}
+				task.Tags = tags
+			}
+			if req.ParentID != 0 {
+				task.ParentID = req.ParentID
+				if err := tx.SetParent(ctx, task); err != nil {
+					return fmt.Errorf("storage.SetParent: %w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Import %w", err)
+		}
+		resp := s.taskResponse(task)
+		s.publish(events.Created, resp)
+		return resp, nil
+	}
+}
@@ -4,27 +4,82 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
 )
 
-func (s *taskService) List(ctx context.Context, req dto.ListTaskRequest) ([]dto.TaskResponse, error) {
+func (s *taskService) List(ctx context.Context, req dto.ListTaskRequest) (dto.TaskListResponse, error) {
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return dto.TaskListResponse{}, ctx.Err()
 	default:
-		tasks, err := s.taskStorage.List(req.Status)
+		limit := req.Limit
+		if limit <= 0 {
+			limit = dto.DefaultPageSize
+		}
+		if limit > dto.MaxPageSize {
+			limit = dto.MaxPageSize
+		}
+		filter, err := taskFilter(req)
+		if err != nil {
+			return dto.TaskListResponse{}, fmt.Errorf("service.List: %w", err)
+		}
+		// One extra row tells whether there is a next page.
+		filter.Limit = limit + 1
+		tasks, err := s.taskStorage.List(ctx, filter)
 		if err != nil {
-			return nil, fmt.Errorf("service.List storage.List: %w", err)
+			return dto.TaskListResponse{}, fmt.Errorf("service.List storage.List: %w", err)
+		}
+		total, err := s.taskStorage.Count(ctx, filter)
+		if err != nil {
+			return dto.TaskListResponse{}, fmt.Errorf("service.List storage.Count: %w", err)
+		}
+		res := dto.TaskListResponse{
+			Tasks: make([]dto.TaskResponse, 0, len(tasks)),
+			Total: total,
+		}
+		if len(tasks) > limit {
+			tasks = tasks[:limit]
+			res.NextCursor = models.NewTaskCursor(tasks[limit-1], req.SortBy, req.SortOrder).Encode()
 		}
-		var taskResponses []dto.TaskResponse
 		for _, task := range tasks {
-			taskResponses = append(taskResponses, dto.TaskResponse{
-				ID:          task.ID,
-				Title:       task.Title,
-				Description: task.Description,
-				Status:      task.Status,
-			})
-		}
-		return taskResponses, nil
+			res.Tasks = append(res.Tasks, s.taskResponse(task))
+		}
+		return res, nil
+	}
+}
+
+// taskFilter turns the filters of req into a storage filter without a limit.
+func taskFilter(req dto.ListTaskRequest) (models.TaskFilter, error) {
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return models.TaskFilter{}, err
+	}
+	tagMode := req.TagMode
+	if tagMode == "" {
+		tagMode = models.TagModeAny
 	}
+	return models.TaskFilter{
+		Statuses:      req.Statuses,
+		Title:         req.Title,
+		Description:   req.Description,
+		IDFrom:        req.IDFrom,
+		IDTo:          req.IDTo,
+		Priorities:    req.Priorities,
+		Assignee:      req.Assignee,
+		DueAfter:      req.DueAfter,
+		DueBefore:     req.DueBefore,
+		Tags:          tags,
+		TagMode:       tagMode,
+		CreatedBy:     req.CreatedBy,
+		UpdatedBy:     req.UpdatedBy,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		UpdatedAfter:  req.UpdatedAfter,
+		UpdatedBefore: req.UpdatedBefore,
+		SortBy:        req.SortBy,
+		SortOrder:     req.SortOrder,
+		Deleted:       req.Deleted,
+		Cursor:        req.Cursor,
+	}, nil
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
 )
@@ -16,7 +18,7 @@ func TestListWithCancel(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 	req := dto.ListTaskRequest{
-		Status: "todo",
+		Statuses: []string{"todo"},
 	}
 	if _, err := taskService.List(ctx, req); !errors.Is(err, ctx.Err()) {
 		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
@@ -30,7 +32,7 @@ func TestListWithStorageError(t *testing.T) {
 	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
 
 	req := dto.ListTaskRequest{
-		Status: "todo",
+		Statuses: []string{"todo"},
 	}
 	if _, err := taskService.List(context.Background(), req); !errors.Is(err, errStorageList) {
 		t.Errorf("expected error: %v, got: %v", errStorageList, err)
@@ -42,9 +44,75 @@ func TestList(t *testing.T) {
 	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
 
 	req := dto.ListTaskRequest{
-		Status: "todo",
+		Statuses: []string{"todo"},
 	}
 	if _, err := taskService.List(context.Background(), req); err != nil {
 		t.Errorf("expected error: %v, got: %v", nil, err)
 	}
 }
+
+func TestListNextCursor(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		listRes: []Task{{ID: 1}, {ID: 2}, {ID: 3}},
+		total:   5,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.ListTaskRequest{
+		Statuses: []string{"todo"},
+		Limit:    2,
+	}
+	res, err := taskService.List(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(res.Tasks) != 2 {
+		t.Errorf("expected 2 tasks, got: %d", len(res.Tasks))
+	}
+	if res.Total != 5 {
+		t.Errorf("expected total: 5, got: %d", res.Total)
+	}
+	cursor, err := DecodeTaskCursor(res.NextCursor)
+	if err != nil {
+		t.Fatalf("expected a valid cursor, got: %v", err)
+	}
+	if cursor.ID != 2 || cursor.SortBy != "id" || cursor.Order != "asc" {
+		t.Errorf("expected cursor after task 2, got: %+v", cursor)
+	}
+}
+
+func TestListLastPage(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		listRes: []Task{{ID: 1}},
+		total:   1,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	res, err := taskService.List(context.Background(), dto.ListTaskRequest{Statuses: []string{"todo"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if res.NextCursor != "" {
+		t.Errorf("expected no next cursor, got: %v", res.NextCursor)
+	}
+}
+
+func TestListPlanningFilters(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	now := time.Now()
+	req := dto.ListTaskRequest{
+		Priorities: []int{4, 5},
+		Assignee:   "carol",
+		DueAfter:   now,
+		DueBefore:  now.Add(time.Hour),
+	}
+	if _, err := taskService.List(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	got := mockTaskStorage.filter
+	if len(got.Priorities) != 2 || got.Assignee != "carol" || !got.DueAfter.Equal(now) || !got.DueBefore.Equal(now.Add(time.Hour)) {
+		t.Errorf("filters not passed to storage: %+v", got)
+	}
+}
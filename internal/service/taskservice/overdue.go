@@ -0,0 +1,24 @@
+package taskservice
+
+import (
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// taskResponse returns the response for task with its overdue flag set.
+func (s *taskService) taskResponse(task models.Task) dto.TaskResponse {
+	res := dto.NewTaskResponse(task)
+	res.Overdue = s.overdue(task, time.Now())
+	return res
+}
+
+// overdue reports whether task was due before now and is still open, that
+// is neither in a terminal status nor in the trash.
+func (s *taskService) overdue(task models.Task, now time.Time) bool {
+	if task.DueAt == nil || task.DeletedAt != nil {
+		return false
+	}
+	return task.DueAt.Before(now) && !s.workflow.IsTerminal(task.Status)
+}
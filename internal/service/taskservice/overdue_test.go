@@ -0,0 +1,63 @@
+package taskservice_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestGetOverdue(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name string
+		task models.Task
+		want bool
+	}{
+		{"no due date", models.Task{ID: 1, Status: "todo"}, false},
+		{"due in the future", models.Task{ID: 1, Status: "todo", DueAt: &future}, false},
+		{"past due and open", models.Task{ID: 1, Status: "in_progress", DueAt: &past}, true},
+		{"past due and done", models.Task{ID: 1, Status: "done", DueAt: &past}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{getRes: tt.task}))
+			res, err := taskService.Get(context.Background(), dto.GetTaskRequest{ID: 1})
+			if err != nil {
+				t.Fatalf("expected error: %v, got: %v", nil, err)
+			}
+			if res.Overdue != tt.want {
+				t.Errorf("expected overdue: %v, got: %v", tt.want, res.Overdue)
+			}
+		})
+	}
+}
+
+func TestSetKeepsPlanningFields(t *testing.T) {
+	due := time.Now().Add(24 * time.Hour).UTC()
+	mockTaskStorage := &mockTaskStorage{getErr: errStorageGet}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.SetTaskRequest{
+		ID:          1,
+		Title:       "title",
+		Description: "description",
+		Priority:    4,
+		DueAt:       &due,
+		Assignee:    "carol",
+	}
+	res, err := taskService.Set(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if mockTaskStorage.setTask.Priority != 4 || mockTaskStorage.setTask.DueAt != &due || mockTaskStorage.setTask.Assignee != "carol" {
+		t.Errorf("planning fields not stored: %+v", mockTaskStorage.setTask)
+	}
+	if res.Priority != 4 || res.Assignee != "carol" || res.Overdue {
+		t.Errorf("unexpected response: %+v", res)
+	}
+}
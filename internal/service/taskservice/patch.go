@@ -0,0 +1,130 @@
+package taskservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/jsonpatch"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// patchDocument is the part of a task a patch sees and may change.
+type patchDocument struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+	Assignee    string     `json:"assignee"`
+}
+
+// patchValidator reports fields by their json names, so that errors can be
+// told to clients in the terms of the patch.
+var patchValidator = func() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return validate
+}()
+
+// Patch applies the patch of req to the current state of the task and
+// updates the task to the result like Update does. The task is locked from
+// reading it until it is written, so concurrent patches apply one after
+// the other. A result failing the rules of UpdateTaskRequest is rejected
+// with ErrPatched wrapping the validation errors.
+func (s *taskService) Patch(ctx context.Context, req dto.PatchTaskRequest) (dto.TaskResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskResponse{}, ctx.Err()
+	default:
+		var (
+			resp  dto.TaskResponse
+			batch events.Batch
+		)
+		err := s.taskStorage.Atomic(ctx, func(tx TaskStorer) error {
+			current, err := tx.Lock(ctx, req.ID)
+			if err != nil {
+				return fmt.Errorf("service.Patch storage.Lock: %w", err)
+			}
+			update, err := applyPatch(current, req)
+			if err != nil {
+				return fmt.Errorf("service.Patch: %w", err)
+			}
+			bound := *s
+			bound.taskStorage = tx
+			bound.events = &batch
+			resp, err = bound.update(ctx, update)
+			return err
+		})
+		if err != nil {
+			return dto.TaskResponse{}, err
+		}
+		s.flush(&batch)
+		return resp, nil
+	}
+}
+
+// applyPatch returns the update turning task into the result of the patch.
+func applyPatch(task models.Task, req dto.PatchTaskRequest) (dto.UpdateTaskRequest, error) {
+	doc, err := json.Marshal(patchDocument{
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueAt:       task.DueAt,
+		Assignee:    task.Assignee,
+	})
+	if err != nil {
+		return dto.UpdateTaskRequest{}, err
+	}
+	var patched []byte
+	switch req.Type {
+	case dto.MergePatch:
+		patched, err = jsonpatch.MergePatch(doc, req.Patch)
+	case dto.JSONPatch:
+		patched, err = jsonpatch.Apply(doc, req.Patch)
+	default:
+		return dto.UpdateTaskRequest{}, customerror.ErrPatch.AddData("'" + req.Type + "' is not a supported patch type.")
+	}
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return dto.UpdateTaskRequest{}, fmt.Errorf("%w", customerror.ErrPatchTest.AddData(err.Error()+"."))
+	}
+	if err != nil {
+		return dto.UpdateTaskRequest{}, fmt.Errorf("%w", customerror.ErrPatch.AddData(err.Error()+"."))
+	}
+	var result patchDocument
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&result); err != nil {
+		return dto.UpdateTaskRequest{}, fmt.Errorf("%w", customerror.ErrPatch.AddData("the result is not a task: "+err.Error()+"."))
+	}
+	update := dto.UpdateTaskRequest{
+		ID:          task.ID,
+		Title:       result.Title,
+		Description: result.Description,
+		Status:      result.Status,
+		Priority:    result.Priority,
+		DueAt:       result.DueAt,
+		Assignee:    result.Assignee,
+	}
+	if err := patchValidator.Struct(update); err != nil {
+		return dto.UpdateTaskRequest{}, fmt.Errorf("%w: %w", customerror.ErrPatched, err)
+	}
+	return update, nil
+}
@@ -0,0 +1,144 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func patchStorage() *mockTaskStorage {
+	return &mockTaskStorage{
+		getRes: models.Task{
+			ID:          1,
+			Title:       "title",
+			Description: "description",
+			Status:      "todo",
+			Priority:    2,
+			Assignee:    "alice",
+			CreatedBy:   "alice",
+		},
+	}
+}
+
+func TestPatchWithCancel(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(patchStorage()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := dto.PatchTaskRequest{ID: 1, Type: dto.MergePatch, Patch: []byte(`{}`)}
+	if _, err := taskService.Patch(ctx, req); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestPatchWithLockError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getErr: errStorageGet,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.PatchTaskRequest{ID: 1, Type: dto.MergePatch, Patch: []byte(`{}`)}
+	if _, err := taskService.Patch(context.Background(), req); !errors.Is(err, errStorageGet) {
+		t.Errorf("expected error: %v, got: %v", errStorageGet, err)
+	}
+}
+
+func TestPatchMergePatch(t *testing.T) {
+	mockTaskStorage := patchStorage()
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.PatchTaskRequest{ID: 1, Type: dto.MergePatch, Patch: []byte(`{"status":"in_progress","assignee":null}`)}
+	resp, err := taskService.Patch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if !mockTaskStorage.atomic {
+		t.Errorf("expected the patch to run in a transaction")
+	}
+	updated := mockTaskStorage.updTask
+	if updated.Status != "in_progress" || updated.Assignee != "" || updated.Title != "title" || updated.Priority != 2 {
+		t.Errorf("expected only status and assignee to change, got: %+v", updated)
+	}
+	if resp.Status != "in_progress" {
+		t.Errorf("expected status: %v, got: %v", "in_progress", resp.Status)
+	}
+}
+
+func TestPatchJSONPatch(t *testing.T) {
+	mockTaskStorage := patchStorage()
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	patch := `[{"op":"test","path":"/status","value":"todo"},{"op":"replace","path":"/title","value":"new title"},{"op":"replace","path":"/due_at","value":"2030-01-02T03:04:05Z"}]`
+	req := dto.PatchTaskRequest{ID: 1, Type: dto.JSONPatch, Patch: []byte(patch)}
+	if _, err := taskService.Patch(context.Background(), req); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	updated := mockTaskStorage.updTask
+	if updated.Title != "new title" || updated.DueAt == nil || updated.DueAt.Year() != 2030 || updated.Status != "todo" {
+		t.Errorf("expected title and due date to change, got: %+v", updated)
+	}
+}
+
+func TestPatchErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		patchType string
+		patch     string
+		want      error
+	}{
+		{"malformed merge patch", dto.MergePatch, `{"status":`, customerror.ErrPatch},
+		{"malformed json patch", dto.JSONPatch, `{"op":"replace"}`, customerror.ErrPatch},
+		{"missing path", dto.JSONPatch, `[{"op":"remove","path":"/tags"}]`, customerror.ErrPatch},
+		{"unknown field", dto.MergePatch, `{"id":2}`, customerror.ErrPatch},
+		{"wrong type", dto.MergePatch, `{"priority":"high"}`, customerror.ErrPatch},
+		{"failed test", dto.JSONPatch, `[{"op":"test","path":"/status","value":"done"}]`, customerror.ErrPatchTest},
+		{"invalid result", dto.MergePatch, `{"title":null,"priority":9}`, customerror.ErrPatched},
+		{"unknown status", dto.MergePatch, `{"status":"dnoe"}`, customerror.ErrStatus},
+		{"unsupported type", "application/json", `{}`, customerror.ErrPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskStorage := patchStorage()
+			taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+			req := dto.PatchTaskRequest{ID: 1, Type: tt.patchType, Patch: []byte(tt.patch)}
+			if _, err := taskService.Patch(context.Background(), req); !errors.Is(err, tt.want) {
+				t.Errorf("expected error: %v, got: %v", tt.want, err)
+			}
+			if mockTaskStorage.updTask.ID != 0 {
+				t.Errorf("expected no update, got: %+v", mockTaskStorage.updTask)
+			}
+		})
+	}
+}
+
+func TestPatchRejectsTransition(t *testing.T) {
+	mockTaskStorage := patchStorage()
+	mockTaskStorage.getRes.Status = "done"
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.PatchTaskRequest{ID: 1, Type: dto.MergePatch, Patch: []byte(`{"status":"todo"}`)}
+	if _, err := taskService.Patch(context.Background(), req); !errors.Is(err, customerror.ErrTransition) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrTransition, err)
+	}
+}
+
+func TestPatchReportsInvalidFields(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(patchStorage()))
+
+	req := dto.PatchTaskRequest{ID: 1, Type: dto.MergePatch, Patch: []byte(`{"title":null,"priority":9}`)}
+	_, err := taskService.Patch(context.Background(), req)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 2 {
+		t.Fatalf("expected 2 field errors, got: %v", err)
+	}
+	if fieldErrs[0].Field() != "title" || fieldErrs[1].Field() != "priority" {
+		t.Errorf("expected title and priority to be reported, got: %v", fieldErrs)
+	}
+}
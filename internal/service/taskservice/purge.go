@@ -0,0 +1,37 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func (s *taskService) Purge(ctx context.Context, req dto.PurgeTaskRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := s.taskStorage.Purge(ctx, req.ID); err != nil {
+			return fmt.Errorf("service.Purge storage.Purge: %w", err)
+		}
+		s.dropAttachments(ctx, req.ID)
+		return nil
+	}
+}
+
+func (s *taskService) PurgeTrash(ctx context.Context, req dto.PurgeTrashRequest) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+		ids, err := s.taskStorage.PurgeDeletedBefore(ctx, req.Before)
+		if err != nil {
+			return 0, fmt.Errorf("service.PurgeTrash storage.PurgeDeletedBefore: %w", err)
+		}
+		for _, id := range ids {
+			s.dropAttachments(ctx, id)
+		}
+		return int64(len(ids)), nil
+	}
+}
@@ -0,0 +1,83 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestPurgeWithCancel(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := dto.PurgeTaskRequest{
+		ID: 1,
+	}
+	if err := taskService.Purge(ctx, req); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestPurgeWithStorageError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		purgeErr: errStoragePurge,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.PurgeTaskRequest{
+		ID: 1,
+	}
+	if err := taskService.Purge(context.Background(), req); !errors.Is(err, errStoragePurge) {
+		t.Errorf("expected error: %v, got: %v", errStoragePurge, err)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.PurgeTaskRequest{
+		ID: 1,
+	}
+	if err := taskService.Purge(context.Background(), req); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+}
+
+func TestPurgeTrashWithStorageError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		purgeErr: errStoragePurge,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.PurgeTrashRequest{
+		Before: time.Now(),
+	}
+	if _, err := taskService.PurgeTrash(context.Background(), req); !errors.Is(err, errStoragePurge) {
+		t.Errorf("expected error: %v, got: %v", errStoragePurge, err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		purged: []uint{3, 4},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.PurgeTrashRequest{
+		Before: time.Now(),
+	}
+	n, err := taskService.PurgeTrash(context.Background(), req)
+	if err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+	if n != 2 {
+		t.Errorf("expected purged: %v, got: %v", 2, n)
+	}
+}
@@ -0,0 +1,35 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func (s *taskService) Restore(ctx context.Context, req dto.RestoreTaskRequest) (dto.TaskResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskResponse{}, ctx.Err()
+	default:
+		task := models.Task{
+			ID:        req.ID,
+			UpdatedAt: time.Now().UTC(),
+			UpdatedBy: util.ActorFromContext(ctx),
+		}
+		if err := s.taskStorage.Restore(ctx, task); err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Restore storage.Restore: %w", err)
+		}
+		restored, err := s.taskStorage.Get(ctx, req.ID)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Restore storage.Get: %w", err)
+		}
+		resp := s.taskResponse(restored)
+		s.publish(events.Created, resp)
+		return resp, nil
+	}
+}
@@ -0,0 +1,64 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestRestoreWithCancel(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := dto.RestoreTaskRequest{
+		ID: 1,
+	}
+	if _, err := taskService.Restore(ctx, req); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestRestoreWithStorageError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		restoreErr: errStorageRestore,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.RestoreTaskRequest{
+		ID: 1,
+	}
+	if _, err := taskService.Restore(context.Background(), req); !errors.Is(err, errStorageRestore) {
+		t.Errorf("expected error: %v, got: %v", errStorageRestore, err)
+	}
+}
+
+func TestRestoreWithGetError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getErr: errStorageGet,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.RestoreTaskRequest{
+		ID: 1,
+	}
+	if _, err := taskService.Restore(context.Background(), req); !errors.Is(err, errStorageGet) {
+		t.Errorf("expected error: %v, got: %v", errStorageGet, err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.RestoreTaskRequest{
+		ID: 1,
+	}
+	if _, err := taskService.Restore(context.Background(), req); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+}
@@ -0,0 +1,61 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/search"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func (s *taskService) Search(ctx context.Context, req dto.SearchTaskRequest) (dto.TaskSearchResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskSearchResponse{}, ctx.Err()
+	default:
+		limit := req.Limit
+		if limit <= 0 {
+			limit = dto.DefaultPageSize
+		}
+		if limit > dto.MaxPageSize {
+			limit = dto.MaxPageSize
+		}
+		offset := 0
+		if req.Cursor != "" {
+			cursor, err := models.DecodeTaskCursor(req.Cursor)
+			var ok bool
+			offset, ok = cursor.SearchOffset()
+			if err != nil || !ok {
+				return dto.TaskSearchResponse{}, fmt.Errorf("service.Search: %w", customerror.ErrCursor.AddData("'"+req.Cursor+"' does not belong to a search."))
+			}
+		}
+		hits, total, err := s.taskStorage.Search(ctx, models.TaskSearch{
+			Query:  req.Query,
+			Offset: offset,
+			Limit:  limit,
+		})
+		if err != nil {
+			return dto.TaskSearchResponse{}, fmt.Errorf("service.Search storage.Search: %w", err)
+		}
+		res := dto.TaskSearchResponse{
+			Hits:  make([]dto.TaskSearchHitResponse, 0, len(hits)),
+			Total: total,
+		}
+		for _, hit := range hits {
+			res.Hits = append(res.Hits, dto.TaskSearchHitResponse{
+				Task:  s.taskResponse(hit.Task),
+				Score: hit.Score,
+				Highlights: dto.TaskHighlights{
+					Title:       search.Highlight(hit.Task.Title, req.Query),
+					Description: search.Highlight(hit.Task.Description, req.Query),
+				},
+			})
+		}
+		if next := offset + len(hits); len(hits) > 0 && int64(next) < total {
+			res.NextCursor = models.NewSearchCursor(next).Encode()
+		}
+		return res, nil
+	}
+}
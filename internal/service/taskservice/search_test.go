@@ -0,0 +1,88 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestSearchWithCancel(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := taskService.Search(ctx, dto.SearchTaskRequest{Query: "invoice"}); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestSearchWithStorageError(t *testing.T) {
+	errStorageSearch := errors.New("storage search error")
+	mockTaskStorage := &mockTaskStorage{
+		searchErr: errStorageSearch,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	if _, err := taskService.Search(context.Background(), dto.SearchTaskRequest{Query: "invoice"}); !errors.Is(err, errStorageSearch) {
+		t.Errorf("expected error: %v, got: %v", errStorageSearch, err)
+	}
+}
+
+func TestSearchWithInvalidCursor(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.SearchTaskRequest{
+		Query:  "invoice",
+		Cursor: NewTaskCursor(Task{ID: 1}, "id", "asc").Encode(),
+	}
+	if _, err := taskService.Search(context.Background(), req); !errors.Is(err, customerror.ErrCursor) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrCursor, err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		searchRes: []TaskSearchHit{
+			{Task: Task{ID: 4, Title: "Pay invoice 4711", Description: "Accounting"}, Score: 1.5},
+			{Task: Task{ID: 9, Title: "Call bank", Description: "About invoice 4711"}, Score: 0.5},
+		},
+		total: 5,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.SearchTaskRequest{
+		Query:  "invoice 4711",
+		Limit:  2,
+		Cursor: NewSearchCursor(2).Encode(),
+	}
+	res, err := taskService.Search(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if mockTaskStorage.search.Offset != 2 || mockTaskStorage.search.Limit != 2 {
+		t.Errorf("expected offset 2 and limit 2, got: %+v", mockTaskStorage.search)
+	}
+	if res.Total != 5 || len(res.Hits) != 2 {
+		t.Fatalf("expected 2 hits of 5, got: %d of %d", len(res.Hits), res.Total)
+	}
+	if want := "Pay <mark>invoice</mark> <mark>4711</mark>"; res.Hits[0].Highlights.Title != want {
+		t.Errorf("expected title highlight: %v, got: %v", want, res.Hits[0].Highlights.Title)
+	}
+	if res.Hits[0].Highlights.Description != "" {
+		t.Errorf("expected no description highlight, got: %v", res.Hits[0].Highlights.Description)
+	}
+	cursor, err := DecodeTaskCursor(res.NextCursor)
+	if err != nil {
+		t.Fatalf("expected a valid cursor, got: %v", err)
+	}
+	if offset, ok := cursor.SearchOffset(); !ok || offset != 4 {
+		t.Errorf("expected next offset 4, got: %v", offset)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
 func (s *taskService) Set(ctx context.Context, req dto.SetTaskRequest) (dto.TaskResponse, error) {
@@ -15,24 +18,34 @@ func (s *taskService) Set(ctx context.Context, req dto.SetTaskRequest) (dto.Task
 	case <-ctx.Done():
 		return dto.TaskResponse{}, ctx.Err()
 	default:
-		if _, err := s.taskStorage.Get(req.ID); err == nil {
+		if _, err := s.taskStorage.Get(ctx, req.ID); err == nil {
 			_id := strconv.Itoa(int(req.ID))
 			return dto.TaskResponse{}, fmt.Errorf("service.Set storage.Get: %w", customerror.ErrIDExists.AddData("'"+_id+"' already exists in the database."))
 		}
+		status, err := s.initialStatus(req.Status)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Set: %w", err)
+		}
+		now := time.Now().UTC()
+		actor := util.ActorFromContext(ctx)
 		task := models.Task{
 			ID:          req.ID,
 			Title:       req.Title,
 			Description: req.Description,
-			Status:      req.Status,
+			Status:      status,
+			Priority:    req.Priority,
+			DueAt:       req.DueAt,
+			Assignee:    req.Assignee,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			CreatedBy:   actor,
+			UpdatedBy:   actor,
 		}
-		if err := s.taskStorage.Set(task); err != nil {
+		if err := s.taskStorage.Set(ctx, task); err != nil {
 			return dto.TaskResponse{}, fmt.Errorf("service.Set storage.Set: %w", err)
 		}
-		return dto.TaskResponse{
-			ID:          req.ID,
-			Title:       req.Title,
-			Description: req.Description,
-			Status:      req.Status,
-		}, nil
+		resp := s.taskResponse(task)
+		s.publish(events.Created, resp)
+		return resp, nil
 	}
 }
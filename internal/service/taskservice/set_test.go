@@ -5,8 +5,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
 func TestSetWithCancel(t *testing.T) {
@@ -63,6 +65,25 @@ func TestSetWithStorageError(t *testing.T) {
 	}
 }
 
+func TestSetAfterDelete(t *testing.T) {
+	// A deleted task is not seen by Get, but its ID is still taken.
+	mockTaskStorage := &mockTaskStorage{
+		getErr: customerror.ErrIDNotFound,
+		setErr: customerror.ErrIDExists.AddData("'1' already exists in the database."),
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.SetTaskRequest{
+		ID:          1,
+		Title:       "title",
+		Description: "description",
+		Status:      "todo",
+	}
+	if _, err := taskService.Set(context.Background(), req); !errors.Is(err, customerror.ErrIDExists) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrIDExists, err)
+	}
+}
+
 func TestSet(t *testing.T) {
 	mockTaskStorage := &mockTaskStorage{
 		getErr: errStorageSet,
@@ -79,3 +100,32 @@ func TestSet(t *testing.T) {
 		t.Errorf("expected error: %v, got: %v", nil, err)
 	}
 }
+
+func TestSetRecordsCreator(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getErr: errStorageGet,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.SetTaskRequest{
+		ID:          1,
+		Title:       "title",
+		Description: "description",
+		Status:      "todo",
+	}
+	ctx := util.WithActor(context.Background(), "alice")
+	res, err := taskService.Set(ctx, req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	stored := mockTaskStorage.setTask
+	if stored.CreatedBy != "alice" || stored.UpdatedBy != "alice" {
+		t.Errorf("expected creator and updater: %v, got: %v and %v", "alice", stored.CreatedBy, stored.UpdatedBy)
+	}
+	if stored.CreatedAt.IsZero() || !stored.CreatedAt.Equal(stored.UpdatedAt) {
+		t.Errorf("expected equal non-zero timestamps, got: %v and %v", stored.CreatedAt, stored.UpdatedAt)
+	}
+	if res.CreatedBy != "alice" || !res.CreatedAt.Equal(stored.CreatedAt) {
+		t.Errorf("expected response to carry stored metadata, got: %+v", res)
+	}
+}
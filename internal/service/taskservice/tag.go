@@ -0,0 +1,125 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// maxTagLength is the longest tag name in characters.
+const maxTagLength = 64
+
+func (s *taskService) Tag(ctx context.Context, req dto.TagTaskRequest) (dto.TaskResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskResponse{}, ctx.Err()
+	default:
+		tags, err := normalizeTags(req.Tags)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Tag: %w", err)
+		}
+		task := models.Task{
+			ID:        req.ID,
+			UpdatedAt: time.Now().UTC(),
+			UpdatedBy: util.ActorFromContext(ctx),
+		}
+		if err := s.taskStorage.AddTags(ctx, task, tags); err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Tag storage.AddTags: %w", err)
+		}
+		tagged, err := s.taskStorage.Get(ctx, req.ID)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Tag storage.Get: %w", err)
+		}
+		resp := s.taskResponse(tagged)
+		s.publish(events.Updated, resp)
+		return resp, nil
+	}
+}
+
+func (s *taskService) Untag(ctx context.Context, req dto.TagTaskRequest) (dto.TaskResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.TaskResponse{}, ctx.Err()
+	default:
+		tags, err := normalizeTags(req.Tags)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Untag: %w", err)
+		}
+		task := models.Task{
+			ID:        req.ID,
+			UpdatedAt: time.Now().UTC(),
+			UpdatedBy: util.ActorFromContext(ctx),
+		}
+		if err := s.taskStorage.RemoveTags(ctx, task, tags); err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Untag storage.RemoveTags: %w", err)
+		}
+		untagged, err := s.taskStorage.Get(ctx, req.ID)
+		if err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Untag storage.Get: %w", err)
+		}
+		resp := s.taskResponse(untagged)
+		s.publish(events.Updated, resp)
+		return resp, nil
+	}
+}
+
+func (s *taskService) Tags(ctx context.Context) ([]dto.TagCountResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		counts, err := s.taskStorage.TagCounts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("service.Tags storage.TagCounts: %w", err)
+		}
+		res := make([]dto.TagCountResponse, 0, len(counts))
+		for _, count := range counts {
+			res = append(res, dto.TagCountResponse{Name: count.Name, Count: count.Count})
+		}
+		return res, nil
+	}
+}
+
+// normalizeTags lowercases and trims the tags and drops duplicates, keeping
+// the first occurrence. A tag starts with a letter or digit followed by
+// letters, digits and any of "-_.:/", up to maxTagLength characters.
+func normalizeTags(tags []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !validTag(tag) {
+			return nil, customerror.ErrTag.AddData("'" + tag + "' must start with a letter or digit and contain only letters, digits and -_.:/ up to 64 characters.")
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
+
+func validTag(tag string) bool {
+	if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+		return false
+	}
+	for i, r := range tag {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			continue
+		}
+		if i == 0 || !strings.ContainsRune("-_.:/", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestTagWithCancel(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := taskService.Tag(ctx, dto.TagTaskRequest{ID: 1, Tags: []string{"backend"}}); !errors.Is(err, ctx.Err()) {
+		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
+	}
+}
+
+func TestTagNormalizesTags(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getRes: Task{ID: 1, Tags: []string{"backend", "urgent"}},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx := util.WithActor(context.Background(), "alice")
+	req := dto.TagTaskRequest{ID: 1, Tags: []string{" Backend", "urgent", "BACKEND"}}
+	res, err := taskService.Tag(ctx, req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if want := []string{"backend", "urgent"}; !reflect.DeepEqual(mockTaskStorage.tags, want) {
+		t.Errorf("expected tags: %v, got: %v", want, mockTaskStorage.tags)
+	}
+	if mockTaskStorage.tagTask.UpdatedBy != "alice" {
+		t.Errorf("expected actor: %v, got: %v", "alice", mockTaskStorage.tagTask.UpdatedBy)
+	}
+	if !reflect.DeepEqual(res.Tags, []string{"backend", "urgent"}) {
+		t.Errorf("unexpected response tags: %v", res.Tags)
+	}
+}
+
+func TestTagInvalidTag(t *testing.T) {
+	for _, tag := range []string{"", "-backend", "back end", "a,b"} {
+		taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{}))
+		_, err := taskService.Tag(context.Background(), dto.TagTaskRequest{ID: 1, Tags: []string{tag}})
+		if !errors.Is(err, customerror.ErrTag) {
+			t.Errorf("%q: expected error: %v, got: %v", tag, customerror.ErrTag, err)
+		}
+	}
+}
+
+func TestUntagWithStorageError(t *testing.T) {
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{tagErr: errStorageTag}))
+
+	if _, err := taskService.Untag(context.Background(), dto.TagTaskRequest{ID: 1, Tags: []string{"backend"}}); !errors.Is(err, errStorageTag) {
+		t.Errorf("expected error: %v, got: %v", errStorageTag, err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		tagCounts: []TagCount{{Name: "backend", Count: 3}},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	res, err := taskService.Tags(context.Background())
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if want := []dto.TagCountResponse{{Name: "backend", Count: 3}}; !reflect.DeepEqual(res, want) {
+		t.Errorf("expected counts: %v, got: %v", want, res)
+	}
+}
+
+func TestListTagFilter(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	if _, err := taskService.List(context.Background(), dto.ListTaskRequest{Tags: []string{"Backend"}}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := mockTaskStorage.filter; !reflect.DeepEqual(got.Tags, []string{"backend"}) || got.TagMode != TagModeAny {
+		t.Errorf("unexpected tag filter: %v %v", got.Tags, got.TagMode)
+	}
+}
@@ -3,33 +3,78 @@ package taskservice
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
+// Update replaces the fields of the task taken by req. The task is locked
+// from reading it until it is written, so that concurrent updates and
+// patches apply one after the other and none checks its status change
+// against a state another one replaced.
 func (s *taskService) Update(ctx context.Context, req dto.UpdateTaskRequest) (dto.TaskResponse, error) {
 	select {
 	case <-ctx.Done():
 		return dto.TaskResponse{}, ctx.Err()
 	default:
-		if _, err := s.taskStorage.Get(req.ID); err != nil {
-			return dto.TaskResponse{}, fmt.Errorf("service.Update storage.Get: %w", err)
+		var (
+			resp  dto.TaskResponse
+			batch events.Batch
+		)
+		err := s.taskStorage.Atomic(ctx, func(tx TaskStorer) error {
+			bound := *s
+			bound.taskStorage = tx
+			bound.events = &batch
+			var err error
+			resp, err = bound.update(ctx, req)
+			return err
+		})
+		if err != nil {
+			return dto.TaskResponse{}, err
 		}
-		task := models.Task{
-			ID:          req.ID,
-			Title:       req.Title,
-			Description: req.Description,
-			Status:      req.Status,
-		}
-		if err := s.taskStorage.Update(task); err != nil {
-			return dto.TaskResponse{}, fmt.Errorf("service.Update storage.Update: %w", err)
+		s.flush(&batch)
+		return resp, nil
+	}
+}
+
+// update runs Update with the storage bound to its transaction.
+func (s *taskService) update(ctx context.Context, req dto.UpdateTaskRequest) (dto.TaskResponse, error) {
+	current, err := s.taskStorage.Lock(ctx, req.ID)
+	if err != nil {
+		return dto.TaskResponse{}, fmt.Errorf("service.Update storage.Lock: %w", err)
+	}
+	status, err := s.nextStatus(current.Status, req.Status)
+	if err != nil {
+		return dto.TaskResponse{}, fmt.Errorf("service.Update: %w", err)
+	}
+	if status != current.Status && s.workflow.IsTerminal(status) {
+		if err := s.checkBlockers(ctx, req.ID); err != nil {
+			return dto.TaskResponse{}, fmt.Errorf("service.Update: %w", err)
 		}
-		return dto.TaskResponse{
-			ID:          req.ID,
-			Title:       req.Title,
-			Description: req.Description,
-			Status:      req.Status,
-		}, nil
 	}
+	task := models.Task{
+		ID:          req.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      status,
+		Priority:    req.Priority,
+		DueAt:       req.DueAt,
+		Assignee:    req.Assignee,
+		Tags:        current.Tags,
+		ParentID:    current.ParentID,
+		CreatedAt:   current.CreatedAt,
+		UpdatedAt:   time.Now().UTC(),
+		CreatedBy:   current.CreatedBy,
+		UpdatedBy:   util.ActorFromContext(ctx),
+	}
+	if err := s.taskStorage.Update(ctx, task); err != nil {
+		return dto.TaskResponse{}, fmt.Errorf("service.Update storage.Update: %w", err)
+	}
+	resp := s.taskResponse(task)
+	s.publish(events.Updated, resp)
+	return resp, nil
 }
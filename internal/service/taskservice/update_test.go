@@ -3,10 +3,14 @@ package taskservice_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
 func TestUpdateWithCancel(t *testing.T) {
@@ -19,7 +23,7 @@ func TestUpdateWithCancel(t *testing.T) {
 		ID:          1,
 		Title:       "title",
 		Description: "description",
-		Status:      "status",
+		Status:      "in_progress",
 	}
 	if _, err := taskService.Update(ctx, req); !errors.Is(err, ctx.Err()) {
 		t.Errorf("expected error: %v, got: %v", ctx.Err(), err)
@@ -36,7 +40,7 @@ func TestUpdateWithGetError(t *testing.T) {
 		ID:          1,
 		Title:       "title",
 		Description: "description",
-		Status:      "status",
+		Status:      "in_progress",
 	}
 	if _, err := taskService.Update(context.Background(), req); !errors.Is(err, errStorageGet) {
 		t.Errorf("expected error: %v, got: %v", errStorageGet, err)
@@ -53,7 +57,7 @@ func TestUpdateWithStorageError(t *testing.T) {
 		ID:          1,
 		Title:       "title",
 		Description: "description",
-		Status:      "status",
+		Status:      "in_progress",
 	}
 	if _, err := taskService.Update(context.Background(), req); !errors.Is(err, errStorageUpdate) {
 		t.Errorf("expected error: %v, got: %v", errStorageUpdate, err)
@@ -68,9 +72,76 @@ func TestUpdate(t *testing.T) {
 		ID:          1,
 		Title:       "title",
 		Description: "description",
-		Status:      "status",
+		Status:      "in_progress",
 	}
 	if _, err := taskService.Update(context.Background(), req); err != nil {
 		t.Errorf("expected error: %v, got: %v", nil, err)
 	}
+	if !mockTaskStorage.atomic {
+		t.Errorf("expected the task to be read and written atomically")
+	}
+}
+
+func TestUpdateWithAtomicError(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		atomicErr: errStorageAtomic,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.UpdateTaskRequest{
+		ID:          1,
+		Title:       "title",
+		Description: "description",
+		Status:      "in_progress",
+	}
+	if _, err := taskService.Update(context.Background(), req); !errors.Is(err, errStorageAtomic) {
+		t.Errorf("expected error: %v, got: %v", errStorageAtomic, err)
+	}
+}
+
+func TestUpdateKeepsCreator(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour).UTC()
+	mockTaskStorage := &mockTaskStorage{
+		getRes: models.Task{ID: 1, CreatedAt: createdAt, UpdatedAt: createdAt, CreatedBy: "alice", UpdatedBy: "alice"},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.UpdateTaskRequest{
+		ID:          1,
+		Title:       "title",
+		Description: "description",
+		Status:      "in_progress",
+	}
+	ctx := util.WithActor(context.Background(), "bob")
+	if _, err := taskService.Update(ctx, req); err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	stored := mockTaskStorage.updTask
+	if stored.CreatedBy != "alice" || !stored.CreatedAt.Equal(createdAt) {
+		t.Errorf("expected creator to be kept, got: %v at %v", stored.CreatedBy, stored.CreatedAt)
+	}
+	if stored.UpdatedBy != "bob" || !stored.UpdatedAt.After(createdAt) {
+		t.Errorf("expected updater: %v after %v, got: %v at %v", "bob", createdAt, stored.UpdatedBy, stored.UpdatedAt)
+	}
+}
+
+func TestUpdateKeepsTagsAndParent(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getRes: models.Task{ID: 2, Status: "todo", Tags: []string{"backend", "urgent"}, ParentID: 1},
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.UpdateTaskRequest{
+		ID:          2,
+		Title:       "title",
+		Description: "description",
+		Status:      "in_progress",
+	}
+	resp, err := taskService.Update(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if !reflect.DeepEqual(resp.Tags, []string{"backend", "urgent"}) || resp.ParentID != 1 {
+		t.Errorf("expected tags and parent to be kept, got: %v %v", resp.Tags, resp.ParentID)
+	}
 }
@@ -0,0 +1,232 @@
+package taskservice
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// Headers of a webhook delivery. The signature is "sha256=" followed by
+// the hex HMAC-SHA256, keyed with the secret of the webhook, of the
+// timestamp, a dot and the body.
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// DefaultWebhookFailures is the number of deliveries a webhook may fail in
+// a row before it is disabled.
+const DefaultWebhookFailures = 10
+
+// DefaultWebhookTimeout bounds a delivery when no HTTP client is set.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// SignWebhook returns the signature of a delivery of body sent at
+// timestamp, in Unix seconds.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// AddWebhook subscribes an endpoint to the events of tasks. Endpoints on
+// internal addresses are rejected with ErrTarget. The response is the only
+// one telling the secret.
+func (s *taskService) AddWebhook(ctx context.Context, req dto.AddWebhookRequest) (dto.WebhookResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.WebhookResponse{}, ctx.Err()
+	default:
+		if err := s.checkWebhookTarget(ctx, req.URL); err != nil {
+			return dto.WebhookResponse{}, fmt.Errorf("service.AddWebhook: %w", err)
+		}
+		secret := req.Secret
+		if secret == "" {
+			b := make([]byte, 32)
+			if _, err := rand.Read(b); err != nil {
+				return dto.WebhookResponse{}, fmt.Errorf("service.AddWebhook: %w", customerror.ErrWebhook.AddData("secret could not be generated."))
+			}
+			secret = hex.EncodeToString(b)
+		}
+		now := time.Now().UTC()
+		webhook, err := s.taskStorage.AddWebhook(ctx, models.Webhook{
+			URL:       req.URL,
+			Events:    req.Events,
+			Secret:    secret,
+			Enabled:   true,
+			CreatedBy: util.ActorFromContext(ctx),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return dto.WebhookResponse{}, fmt.Errorf("service.AddWebhook storage.AddWebhook: %w", err)
+		}
+		res := dto.NewWebhookResponse(webhook)
+		res.Secret = webhook.Secret
+		return res, nil
+	}
+}
+
+func (s *taskService) Webhooks(ctx context.Context) ([]dto.WebhookResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		webhooks, err := s.taskStorage.ListWebhooks(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("service.Webhooks storage.ListWebhooks: %w", err)
+		}
+		res := make([]dto.WebhookResponse, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			res = append(res, dto.NewWebhookResponse(webhook))
+		}
+		return res, nil
+	}
+}
+
+func (s *taskService) DeleteWebhook(ctx context.Context, req dto.DeleteWebhookRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := s.taskStorage.DeleteWebhook(ctx, req.ID); err != nil {
+			return fmt.Errorf("service.DeleteWebhook storage.DeleteWebhook: %w", err)
+		}
+		return nil
+	}
+}
+
+// EnableWebhook enables a webhook again after it was disabled for failing.
+func (s *taskService) EnableWebhook(ctx context.Context, req dto.EnableWebhookRequest) (dto.WebhookResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.WebhookResponse{}, ctx.Err()
+	default:
+		if err := s.taskStorage.EnableWebhook(ctx, req.ID, time.Now().UTC()); err != nil {
+			return dto.WebhookResponse{}, fmt.Errorf("service.EnableWebhook storage.EnableWebhook: %w", err)
+		}
+		webhook, err := s.taskStorage.GetWebhook(ctx, req.ID)
+		if err != nil {
+			return dto.WebhookResponse{}, fmt.Errorf("service.EnableWebhook storage.GetWebhook: %w", err)
+		}
+		return dto.NewWebhookResponse(webhook), nil
+	}
+}
+
+func (s *taskService) WebhookDeliveries(ctx context.Context, req dto.ListWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		limit := req.Limit
+		if limit <= 0 {
+			limit = dto.DefaultPageSize
+		}
+		if limit > dto.MaxPageSize {
+			limit = dto.MaxPageSize
+		}
+		deliveries, err := s.taskStorage.ListWebhookDeliveries(ctx, req.ID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("service.WebhookDeliveries storage.ListWebhookDeliveries: %w", err)
+		}
+		res := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			res = append(res, dto.NewWebhookDeliveryResponse(delivery))
+		}
+		return res, nil
+	}
+}
+
+// Deliver sends an event to an enabled webhook and logs the attempt. A
+// response other than 2xx fails the delivery with ErrDelivery; the webhook
+// is disabled once it has failed too many times in a row.
+func (s *taskService) Deliver(ctx context.Context, req dto.DeliverWebhookRequest) (dto.WebhookDeliveryResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.WebhookDeliveryResponse{}, ctx.Err()
+	default:
+		webhook, err := s.taskStorage.GetWebhook(ctx, req.WebhookID)
+		if err != nil {
+			return dto.WebhookDeliveryResponse{}, fmt.Errorf("service.Deliver storage.GetWebhook: %w", err)
+		}
+		_id := strconv.Itoa(int(req.WebhookID))
+		if !webhook.Enabled {
+			return dto.WebhookDeliveryResponse{}, fmt.Errorf("service.Deliver: %w", customerror.ErrDisabled.AddData("webhook '"+_id+"' is disabled."))
+		}
+		delivery := models.WebhookDelivery{
+			WebhookID: req.WebhookID,
+			EventID:   req.EventID,
+			EventType: req.EventType,
+			TaskID:    req.TaskID,
+			Payload:   req.Payload,
+			Attempt:   req.Attempt,
+			CreatedAt: time.Now().UTC(),
+		}
+		delivery.StatusCode, err = s.post(ctx, webhook, req)
+		delivery.Duration = time.Since(delivery.CreatedAt).Milliseconds()
+		delivery.Success = err == nil
+		if err != nil {
+			delivery.Error = err.Error()
+			if len(delivery.Error) > 1024 {
+				delivery.Error = delivery.Error[:1024]
+			}
+		}
+		// The attempt is logged even when the request was canceled
+		// while it was sent.
+		webhook, storeErr := s.taskStorage.AddWebhookDelivery(context.WithoutCancel(ctx), delivery, s.webhookFailures)
+		if storeErr != nil {
+			return dto.WebhookDeliveryResponse{}, fmt.Errorf("service.Deliver storage.AddWebhookDelivery: %w", storeErr)
+		}
+		res := dto.NewWebhookDeliveryResponse(delivery)
+		if err != nil {
+			if !webhook.Enabled {
+				s.logger.WarnContext(ctx, "service.Deliver webhook disabled", "webhook_id", webhook.ID, "failures", webhook.Failures)
+			}
+			return res, fmt.Errorf("service.Deliver: %w", customerror.ErrDelivery.AddData("webhook '"+_id+"' failed: "+delivery.Error))
+		}
+		return res, nil
+	}
+}
+
+// post sends a signed delivery and returns the status code of the
+// response, zero when there was none.
+func (s *taskService) post(ctx context.Context, webhook models.Webhook, req dto.DeliverWebhookRequest) (int, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(WebhookEventHeader, req.EventType)
+	r.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(req.EventID, 10))
+	r.Header.Set(WebhookTimestampHeader, timestamp)
+	r.Header.Set(WebhookSignatureHeader, SignWebhook(webhook.Secret, timestamp, req.Payload))
+	resp, err := s.client.Do(r)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.New(resp.Status)
+	}
+	return resp.StatusCode, nil
+}
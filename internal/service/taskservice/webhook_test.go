@@ -0,0 +1,213 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestAddWebhook(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{webhook: Webhook{ID: 4}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	ctx := util.WithActor(context.Background(), "alice")
+	res, err := taskService.AddWebhook(ctx, dto.AddWebhookRequest{URL: "http://example.com/hook", Events: []string{"created"}})
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if res.ID != 4 || !res.Enabled || res.CreatedBy != "alice" || len(res.Secret) != 64 {
+		t.Errorf("unexpected webhook: %+v", res)
+	}
+	if stored := mockTaskStorage.webhooks[0]; stored.Secret != res.Secret {
+		t.Errorf("unexpected stored secret: %v", stored.Secret)
+	}
+
+	listed, err := taskService.Webhooks(ctx)
+	if err != nil || len(listed) != 1 || listed[0].Secret != "" {
+		t.Errorf("secret should only be told on creation: %+v, %v", listed, err)
+	}
+}
+
+// receiver is a webhook endpoint answering with the statuses in turn and
+// checking the signature of every delivery.
+func receiver(t *testing.T, secret string, statuses ...int) *httptest.Server {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := SignWebhook(secret, r.Header.Get(WebhookTimestampHeader), body)
+		if got := r.Header.Get(WebhookSignatureHeader); got != want {
+			t.Errorf("wrong signature, want %v got %v", want, got)
+		}
+		if r.Header.Get(WebhookEventHeader) != "created" || r.Header.Get(WebhookDeliveryHeader) != "9" {
+			t.Errorf("wrong headers: %v", r.Header)
+		}
+		w.WriteHeader(statuses[int(calls.Add(1)-1)%len(statuses)])
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDeliver(t *testing.T) {
+	server := receiver(t, "secret", http.StatusNoContent)
+	mockTaskStorage := &mockTaskStorage{webhook: Webhook{ID: 4, URL: server.URL, Secret: "secret", Enabled: true, Failures: 2}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithPrivateWebhooks(true))
+
+	req := dto.DeliverWebhookRequest{WebhookID: 4, EventID: 9, EventType: "created", TaskID: 1, Payload: []byte(`{"id":9}`), Attempt: 1}
+	res, err := taskService.Deliver(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected error: %v, got: %v", nil, err)
+	}
+	if !res.Success || res.StatusCode != http.StatusNoContent || res.Attempt != 1 {
+		t.Errorf("unexpected delivery: %+v", res)
+	}
+	if len(mockTaskStorage.deliveries) != 1 || string(mockTaskStorage.deliveries[0].Payload) != `{"id":9}` {
+		t.Errorf("delivery was not logged: %+v", mockTaskStorage.deliveries)
+	}
+	if mockTaskStorage.webhook.Failures != 0 || mockTaskStorage.failures != DefaultWebhookFailures {
+		t.Errorf("failures were not cleared: %+v", mockTaskStorage.webhook)
+	}
+}
+
+func TestDeliverDisablesFailingWebhook(t *testing.T) {
+	server := receiver(t, "secret", http.StatusInternalServerError)
+	mockTaskStorage := &mockTaskStorage{webhook: Webhook{ID: 4, URL: server.URL, Secret: "secret", Enabled: true}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithWebhookFailures(3), WithPrivateWebhooks(true))
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		req := dto.DeliverWebhookRequest{WebhookID: 4, EventID: 9, EventType: "created", Payload: []byte(`{}`), Attempt: attempt}
+		res, err := taskService.Deliver(context.Background(), req)
+		if !errors.Is(err, customerror.ErrDelivery) {
+			t.Fatalf("attempt %v: expected error: %v, got: %v", attempt, customerror.ErrDelivery, err)
+		}
+		if res.Success || res.StatusCode != http.StatusInternalServerError || res.Error != "500 Internal Server Error" {
+			t.Errorf("attempt %v: unexpected delivery: %+v", attempt, res)
+		}
+	}
+	if mockTaskStorage.webhook.Enabled {
+		t.Errorf("webhook should be disabled after %v failures", mockTaskStorage.webhook.Failures)
+	}
+
+	_, err := taskService.Deliver(context.Background(), dto.DeliverWebhookRequest{WebhookID: 4, EventID: 9, EventType: "created", Attempt: 4})
+	if !errors.Is(err, customerror.ErrDisabled) {
+		t.Errorf("expected error: %v, got: %v", customerror.ErrDisabled, err)
+	}
+	if len(mockTaskStorage.deliveries) != 3 {
+		t.Errorf("disabled webhook should not be sent to, got %v deliveries", len(mockTaskStorage.deliveries))
+	}
+}
+
+func TestDeliverUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	mockTaskStorage := &mockTaskStorage{webhook: Webhook{ID: 4, URL: url, Enabled: true}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithPrivateWebhooks(true))
+
+	res, err := taskService.Deliver(context.Background(), dto.DeliverWebhookRequest{WebhookID: 4, Attempt: 1})
+	if !errors.Is(err, customerror.ErrDelivery) {
+		t.Fatalf("expected error: %v, got: %v", customerror.ErrDelivery, err)
+	}
+	if res.StatusCode != 0 || res.Error == "" {
+		t.Errorf("unexpected delivery: %+v", res)
+	}
+}
+
+func TestAddWebhookInternalTarget(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://10.0.0.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://[::ffff:192.168.0.1]/hook",
+	} {
+		if _, err := taskService.AddWebhook(context.Background(), dto.AddWebhookRequest{URL: url}); !errors.Is(err, customerror.ErrTarget) {
+			t.Errorf("%v: expected error: %v, got: %v", url, customerror.ErrTarget, err)
+		}
+	}
+	if len(mockTaskStorage.webhooks) != 0 {
+		t.Errorf("internal targets should not be stored, got: %+v", mockTaskStorage.webhooks)
+	}
+}
+
+func TestDeliverInternalTarget(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	t.Cleanup(server.Close)
+	mockTaskStorage := &mockTaskStorage{webhook: Webhook{ID: 4, URL: server.URL, Enabled: true}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	res, err := taskService.Deliver(context.Background(), dto.DeliverWebhookRequest{WebhookID: 4, Attempt: 1})
+	if !errors.Is(err, customerror.ErrDelivery) {
+		t.Fatalf("expected error: %v, got: %v", customerror.ErrDelivery, err)
+	}
+	if calls.Load() != 0 || res.StatusCode != 0 {
+		t.Errorf("internal target should not be connected to, got %v calls: %+v", calls.Load(), res)
+	}
+}
+
+func TestDeliverRedirect(t *testing.T) {
+	var calls atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	t.Cleanup(target.Close)
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	t.Cleanup(server.Close)
+	mockTaskStorage := &mockTaskStorage{webhook: Webhook{ID: 4, URL: server.URL, Enabled: true}}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage), WithPrivateWebhooks(true))
+
+	res, err := taskService.Deliver(context.Background(), dto.DeliverWebhookRequest{WebhookID: 4, Attempt: 1})
+	if !errors.Is(err, customerror.ErrDelivery) {
+		t.Fatalf("expected error: %v, got: %v", customerror.ErrDelivery, err)
+	}
+	if calls.Load() != 0 || res.StatusCode != http.StatusTemporaryRedirect {
+		t.Errorf("redirect should not be followed, got %v calls: %+v", calls.Load(), res)
+	}
+}
+
+func TestWebhookStorageErrors(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{webhookErr: errStorageWebhook}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+	ctx := context.Background()
+
+	if _, err := taskService.AddWebhook(ctx, dto.AddWebhookRequest{URL: "http://example.com"}); !errors.Is(err, errStorageWebhook) {
+		t.Errorf("AddWebhook: expected error: %v, got: %v", errStorageWebhook, err)
+	}
+	if err := taskService.DeleteWebhook(ctx, dto.DeleteWebhookRequest{ID: 4}); !errors.Is(err, errStorageWebhook) {
+		t.Errorf("DeleteWebhook: expected error: %v, got: %v", errStorageWebhook, err)
+	}
+	if _, err := taskService.EnableWebhook(ctx, dto.EnableWebhookRequest{ID: 4}); !errors.Is(err, errStorageWebhook) {
+		t.Errorf("EnableWebhook: expected error: %v, got: %v", errStorageWebhook, err)
+	}
+	if _, err := taskService.WebhookDeliveries(ctx, dto.ListWebhookDeliveriesRequest{ID: 4}); !errors.Is(err, errStorageWebhook) {
+		t.Errorf("WebhookDeliveries: expected error: %v, got: %v", errStorageWebhook, err)
+	}
+	if _, err := taskService.Deliver(ctx, dto.DeliverWebhookRequest{WebhookID: 4}); !errors.Is(err, errStorageWebhook) {
+		t.Errorf("Deliver: expected error: %v, got: %v", errStorageWebhook, err)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := SignWebhook("secret", "1700000000", []byte(`{}`)); got != want {
+		t.Errorf("unexpected signature, want %v got %v", want, got)
+	}
+}
@@ -0,0 +1,93 @@
+package taskservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, private to the
+// network of a provider like the ranges net.IP.IsPrivate reports.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// internalIP reports whether ip is an address webhooks may not target:
+// loopback, private, link-local (cloud metadata services among them),
+// unspecified or multicast.
+func internalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// checkWebhookTarget rejects the webhook URLs whose host is, or resolves
+// to, an internal address. Hosts that cannot be resolved yet are let
+// through: the dialer of deliveries checks every address it connects to.
+func (s *taskService) checkWebhookTarget(ctx context.Context, rawURL string) error {
+	if s.privateWebhooks {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return customerror.ErrTarget.AddData("'" + rawURL + "' is not a valid URL.")
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if internalIP(ip) {
+			return customerror.ErrTarget.AddData("'" + host + "' is an internal address.")
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if internalIP(addr.IP) {
+			return customerror.ErrTarget.AddData("'" + host + "' resolves to the internal address '" + addr.IP.String() + "'.")
+		}
+	}
+	return nil
+}
+
+// errInternalAddress fails the deliveries connecting to an internal
+// address.
+var errInternalAddress = errors.New("connecting to an internal address is not allowed")
+
+// webhookClient returns the client sending deliveries when none is set.
+// Unless allowPrivate, it refuses to connect to internal addresses, which
+// is checked on the address dialed so that a host resolving to another
+// address since the webhook was added is caught too. Proxies are not used,
+// since they would connect for it.
+func webhookClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || internalIP(ip) {
+				return fmt.Errorf("%w: %s", errInternalAddress, host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: DefaultWebhookTimeout, Transport: transport}
+}
+
+// noRedirect keeps deliveries from being redirected to another target
+// than the one checked; the redirect fails the delivery like any other
+// response than 2xx.
+func noRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
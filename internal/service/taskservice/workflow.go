@@ -0,0 +1,60 @@
+package taskservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func (s *taskService) Workflow(ctx context.Context) (dto.WorkflowResponse, error) {
+	select {
+	case <-ctx.Done():
+		return dto.WorkflowResponse{}, ctx.Err()
+	default:
+		return dto.NewWorkflowResponse(s.workflow), nil
+	}
+}
+
+// initialStatus returns the status a new task starts in. Tasks start in
+// the workflow's initial status, which is used when none is requested.
+func (s *taskService) initialStatus(requested string) (string, error) {
+	if strings.TrimSpace(requested) == "" {
+		return s.workflow.Initial, nil
+	}
+	status, err := s.knownStatus(requested)
+	if err != nil {
+		return "", err
+	}
+	if status != s.workflow.Initial {
+		return "", fmt.Errorf("%w", customerror.ErrTransition.AddData("new tasks start in '"+s.workflow.Initial+"', not '"+status+"'."))
+	}
+	return status, nil
+}
+
+// nextStatus checks that a task may move from its current status to the
+// requested one and returns the requested status as the workflow spells it.
+func (s *taskService) nextStatus(current, requested string) (string, error) {
+	status, err := s.knownStatus(requested)
+	if err != nil {
+		return "", err
+	}
+	if !s.workflow.CanTransition(current, status) {
+		allowed := "none, it is terminal"
+		if next := s.workflow.Next(current); len(next) > 0 {
+			allowed = strings.Join(next, ", ")
+		}
+		return "", fmt.Errorf("%w", customerror.ErrTransition.AddData("'"+current+"' cannot move to '"+status+"', allowed: "+allowed+"."))
+	}
+	return status, nil
+}
+
+func (s *taskService) knownStatus(requested string) (string, error) {
+	status, ok := s.workflow.Normalize(requested)
+	if !ok {
+		return "", fmt.Errorf("%w", customerror.ErrStatus.AddData("'"+requested+"' is not one of: "+strings.Join(s.workflow.Statuses, ", ")+"."))
+	}
+	return status, nil
+}
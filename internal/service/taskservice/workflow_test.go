@@ -0,0 +1,110 @@
+package taskservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/workflow"
+)
+
+func TestSetDefaultsToInitialStatus(t *testing.T) {
+	mockTaskStorage := &mockTaskStorage{
+		getErr: errStorageGet,
+	}
+	taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+	req := dto.SetTaskRequest{
+		ID:          1,
+		Title:       "title",
+		Description: "description",
+	}
+	res, err := taskService.Set(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if res.Status != "todo" || mockTaskStorage.setTask.Status != "todo" {
+		t.Errorf("expected status: todo, got: %v", res.Status)
+	}
+}
+
+func TestSetRejectsStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   error
+	}{
+		{"dnoe", customerror.ErrStatus},
+		{"done", customerror.ErrTransition},
+	}
+	for _, tt := range tests {
+		mockTaskStorage := &mockTaskStorage{
+			getErr: errStorageGet,
+		}
+		taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+		req := dto.SetTaskRequest{
+			ID:          1,
+			Title:       "title",
+			Description: "description",
+			Status:      tt.status,
+		}
+		if _, err := taskService.Set(context.Background(), req); !errors.Is(err, tt.want) {
+			t.Errorf("status %v: expected error: %v, got: %v", tt.status, tt.want, err)
+		}
+	}
+}
+
+func TestUpdateTransitions(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     error
+		stored   string
+	}{
+		{from: "todo", to: "In_Progress", stored: "in_progress"},
+		{from: "in_progress", to: "done", stored: "done"},
+		{from: "done", to: "todo", want: customerror.ErrTransition},
+		{from: "todo", to: "Dnoe", want: customerror.ErrStatus},
+	}
+	for _, tt := range tests {
+		mockTaskStorage := &mockTaskStorage{
+			getRes: models.Task{ID: 1, Status: tt.from},
+		}
+		taskService := NewTaskService(WithTaskStorage(mockTaskStorage))
+
+		req := dto.UpdateTaskRequest{
+			ID:          1,
+			Title:       "title",
+			Description: "description",
+			Status:      tt.to,
+		}
+		_, err := taskService.Update(context.Background(), req)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%v -> %v: expected error: %v, got: %v", tt.from, tt.to, tt.want, err)
+		}
+		if tt.want == nil && mockTaskStorage.updTask.Status != tt.stored {
+			t.Errorf("%v -> %v: expected stored status: %v, got: %v", tt.from, tt.to, tt.stored, mockTaskStorage.updTask.Status)
+		}
+	}
+}
+
+func TestWorkflow(t *testing.T) {
+	flow := workflow.Workflow{
+		Statuses:    []string{"open", "closed"},
+		Initial:     "open",
+		Transitions: map[string][]string{"open": {"closed"}},
+		Terminal:    []string{"closed"},
+	}
+	taskService := NewTaskService(WithTaskStorage(&mockTaskStorage{}), WithWorkflow(flow))
+
+	res, err := taskService.Workflow(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if res.Initial != "open" || len(res.Transitions["open"]) != 1 || len(res.Transitions["closed"]) != 0 {
+		t.Errorf("unexpected workflow: %+v", res)
+	}
+}
@@ -0,0 +1,144 @@
+package webhookservice
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// DefaultMaxAttempts is the number of times an event is sent to a webhook
+// before it is given up.
+const DefaultMaxAttempts = 5
+
+// DefaultBackoff is the wait before the first retry. It doubles with every
+// retry after it.
+const DefaultBackoff = time.Second
+
+// DefaultConcurrency is the number of deliveries made at once. A delivery
+// keeps its slot while it waits to be retried.
+const DefaultConcurrency = 16
+
+// DefaultCacheTTL is how long the list of webhooks is reused for the
+// events dispatched after it was read.
+const DefaultCacheTTL = 10 * time.Second
+
+type dispatcher struct {
+	broker      *events.Broker
+	service     taskservice.TaskService
+	maxAttempts int
+	backoff     time.Duration
+	timeout     time.Duration
+	cacheTTL    time.Duration
+	// slots holds a value for every delivery in progress.
+	slots chan struct{}
+	// webhooks is the list read at readAt. Only run uses them.
+	webhooks []dto.WebhookResponse
+	readAt   time.Time
+	logger   *slog.Logger
+	done     chan struct{}
+	Wg       *sync.WaitGroup
+}
+
+type DispatcherOption func(*dispatcher)
+
+// WithBroker sets the broker telling the events to deliver.
+func WithBroker(broker *events.Broker) DispatcherOption {
+	return func(d *dispatcher) {
+		d.broker = broker
+	}
+}
+
+// WithService sets the service listing the webhooks and delivering to
+// them.
+func WithService(service taskservice.TaskService) DispatcherOption {
+	return func(d *dispatcher) {
+		d.service = service
+	}
+}
+
+// WithMaxAttempts sets the number of times an event is sent to a webhook
+// before it is given up.
+func WithMaxAttempts(n int) DispatcherOption {
+	return func(d *dispatcher) {
+		d.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the wait before the first retry of a delivery.
+func WithBackoff(backoff time.Duration) DispatcherOption {
+	return func(d *dispatcher) {
+		d.backoff = backoff
+	}
+}
+
+// WithConcurrency sets the number of deliveries made at once. Events wait
+// for a free slot before they are dispatched.
+func WithConcurrency(n int) DispatcherOption {
+	return func(d *dispatcher) {
+		d.slots = make(chan struct{}, max(n, 1))
+	}
+}
+
+// WithCacheTTL sets how long the list of webhooks is reused. A webhook
+// added is sent the events dispatched once it expires; one deleted or
+// disabled is not sent to, since deliveries check it.
+func WithCacheTTL(ttl time.Duration) DispatcherOption {
+	return func(d *dispatcher) {
+		d.cacheTTL = ttl
+	}
+}
+
+// WithTimeout bounds every attempt.
+func WithTimeout(timeout time.Duration) DispatcherOption {
+	return func(d *dispatcher) {
+		d.timeout = timeout
+	}
+}
+
+func WithLogger(l *slog.Logger) DispatcherOption {
+	return func(d *dispatcher) {
+		d.logger = l
+	}
+}
+
+func WithWaitGroup(wg *sync.WaitGroup) DispatcherOption {
+	return func(d *dispatcher) {
+		d.Wg = wg
+	}
+}
+
+func WithDone(done chan struct{}) DispatcherOption {
+	return func(d *dispatcher) {
+		d.done = done
+	}
+}
+
+// StartWebhookDispatcher sends the events of the broker to the webhooks
+// subscribed to them in the background until done is closed. Failed
+// deliveries are retried with exponential backoff. It does nothing without
+// a broker and a service.
+func StartWebhookDispatcher(opts ...DispatcherOption) {
+	d := &dispatcher{
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+		timeout:     30 * time.Second,
+		cacheTTL:    DefaultCacheTTL,
+		slots:       make(chan struct{}, DefaultConcurrency),
+		logger:      slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		Wg:          &sync.WaitGroup{},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.broker == nil || d.service == nil {
+		d.logger.Info("webhook dispatcher disabled")
+		return
+	}
+	d.Wg.Add(1)
+	go d.run(d.broker.Subscribe(events.Filter{}, 0))
+}
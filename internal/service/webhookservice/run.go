@@ -0,0 +1,155 @@
+package webhookservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// actor is recorded on the requests made by the dispatcher.
+const actor = "system:webhooks"
+
+// run dispatches the events of sub. A subscription dropped for falling
+// behind is made again, starting after the last event dispatched.
+func (d *dispatcher) run(sub *events.Subscription) {
+	defer d.Wg.Done()
+	defer func() { sub.Close() }()
+
+	var last uint64
+	for {
+		select {
+		case <-d.done:
+			return
+		case e, ok := <-sub.Events():
+			if ok {
+				d.dispatch(e)
+				last = e.ID
+				continue
+			}
+			sub = d.broker.Subscribe(events.Filter{}, last)
+			if sub.Missed || last == 0 {
+				d.logger.Error("webhook dispatcher missed events", "after", last)
+			}
+			for _, e := range sub.Replay {
+				d.dispatch(e)
+				last = e.ID
+			}
+		}
+	}
+}
+
+// dispatch starts the delivery of e to every enabled webhook subscribed to
+// its type, waiting for a free slot for each.
+func (d *dispatcher) dispatch(e events.Event) {
+	webhooks, err := d.listWebhooks()
+	if err != nil {
+		d.logger.Error("webhook dispatcher service.Webhooks", "event_id", e.ID, "err", err)
+		return
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		d.logger.Error("webhook dispatcher json.Marshal", "event_id", e.ID, "err", err)
+		return
+	}
+	for _, webhook := range webhooks {
+		if !webhook.Enabled || len(webhook.Events) > 0 && !slices.Contains(webhook.Events, e.Type) {
+			continue
+		}
+		select {
+		case <-d.done:
+			return
+		case d.slots <- struct{}{}:
+		}
+		d.Wg.Add(1)
+		go d.deliver(dto.DeliverWebhookRequest{
+			WebhookID: webhook.ID,
+			EventID:   e.ID,
+			EventType: e.Type,
+			TaskID:    e.Task.ID,
+			Payload:   payload,
+		})
+	}
+}
+
+// listWebhooks returns the webhooks, read again once the list read last is
+// older than the TTL. The list read last is kept when they cannot be.
+func (d *dispatcher) listWebhooks() ([]dto.WebhookResponse, error) {
+	if d.webhooks != nil && time.Since(d.readAt) < d.cacheTTL {
+		return d.webhooks, nil
+	}
+	ctx, cancel := context.WithTimeout(util.WithActor(context.Background(), actor), d.timeout)
+	defer cancel()
+	webhooks, err := d.service.Webhooks(ctx)
+	if err != nil {
+		if d.webhooks != nil {
+			d.logger.Warn("webhook dispatcher service.Webhooks, the list read last is used", "err", err)
+			return d.webhooks, nil
+		}
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []dto.WebhookResponse{}
+	}
+	d.webhooks, d.readAt = webhooks, time.Now()
+	return webhooks, nil
+}
+
+// deliver sends req until it succeeds, the webhook cannot be
+// sent to any more or the attempts run out, waiting twice as long before
+// every retry. It frees the slot taken for it by dispatch.
+func (d *dispatcher) deliver(req dto.DeliverWebhookRequest) {
+	defer d.Wg.Done()
+	defer func() { <-d.slots }()
+
+	wait := d.backoff
+	for req.Attempt = 1; ; req.Attempt++ {
+		err := d.send(req)
+		if err == nil {
+			return
+		}
+		if !retryable(err) {
+			d.logger.Info("webhook delivery stopped", "webhook_id", req.WebhookID, "event_id", req.EventID, "attempt", req.Attempt, "err", err)
+			return
+		}
+		if req.Attempt >= d.maxAttempts {
+			d.logger.Error("webhook delivery given up", "webhook_id", req.WebhookID, "event_id", req.EventID, "attempt", req.Attempt, "err", err)
+			return
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-d.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// send makes one attempt of req through the service. Deliveries do not go
+// through the worker pool, whose results are not told apart by job: the
+// slot taken by dispatch bounds them instead.
+func (d *dispatcher) send(req dto.DeliverWebhookRequest) error {
+	select {
+	case <-d.done:
+		return context.Canceled
+	default:
+	}
+	ctx, cancel := context.WithTimeout(util.WithActor(context.Background(), actor), d.timeout)
+	defer cancel()
+	_, err := d.service.Deliver(ctx, req)
+	return err
+}
+
+// retryable reports whether a delivery failing with err may succeed when
+// sent again: the endpoint failed or did not answer in time.
+func retryable(err error) bool {
+	return errors.Is(err, customerror.ErrDelivery) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,255 @@
+package webhookservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/repository/taskstorage"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/service/webhookservice"
+)
+
+var logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+// webhookStorage keeps webhooks and their deliveries in memory. Other
+// methods of the storage are not used by the dispatcher.
+type webhookStorage struct {
+	taskstorage.TaskStorer
+
+	mu         sync.Mutex
+	webhooks   []models.Webhook
+	deliveries []models.WebhookDelivery
+	lists      int
+}
+
+func (s *webhookStorage) ListWebhooks(context.Context) ([]models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists++
+	return append([]models.Webhook(nil), s.webhooks...), nil
+}
+
+func (s *webhookStorage) GetWebhook(_ context.Context, id uint) (models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.webhooks[id-1], nil
+}
+
+func (s *webhookStorage) AddWebhookDelivery(_ context.Context, d models.WebhookDelivery, maxFailures int) (models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, d)
+	w := &s.webhooks[d.WebhookID-1]
+	if d.Success {
+		w.Failures = 0
+	} else {
+		w.Failures++
+		w.Enabled = w.Enabled && w.Failures < maxFailures
+	}
+	return *w, nil
+}
+
+func (s *webhookStorage) delivered() []models.WebhookDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.WebhookDelivery(nil), s.deliveries...)
+}
+
+// receiver is a webhook endpoint answering with the statuses in turn, the
+// last one from then on.
+func receiver(t *testing.T, secret string, statuses ...int) *httptest.Server {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := taskservice.SignWebhook(secret, r.Header.Get(taskservice.WebhookTimestampHeader), body)
+		if got := r.Header.Get(taskservice.WebhookSignatureHeader); got != want {
+			t.Errorf("wrong signature, want %v got %v", want, got)
+		}
+		var e events.Event
+		if err := json.Unmarshal(body, &e); err != nil || e.Task.ID != 7 {
+			t.Errorf("wrong payload: %s", body)
+		}
+		n := int(calls.Add(1)) - 1
+		w.WriteHeader(statuses[min(n, len(statuses)-1)])
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// start runs a dispatcher delivering through a task service backed by
+// storage, until the test ends.
+func start(t *testing.T, storage *webhookStorage, broker *events.Broker, opts ...taskservice.TaskServiceOption) {
+	startWith(t, storage, broker, nil, opts...)
+}
+
+// startWith runs the dispatcher of start with the options of dopts too.
+func startWith(t *testing.T, storage *webhookStorage, broker *events.Broker, dopts []DispatcherOption, opts ...taskservice.TaskServiceOption) {
+	t.Helper()
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	service := taskservice.NewTaskService(append([]taskservice.TaskServiceOption{taskservice.WithTaskStorage(storage), taskservice.WithLogger(logger), taskservice.WithPrivateWebhooks(true)}, opts...)...)
+	StartWebhookDispatcher(append([]DispatcherOption{
+		WithBroker(broker),
+		WithService(service),
+		WithBackoff(10 * time.Millisecond),
+		WithLogger(logger),
+		WithWaitGroup(wg),
+		WithDone(doneCh),
+	}, dopts...)...)
+	t.Cleanup(func() {
+		close(doneCh)
+		wg.Wait()
+	})
+}
+
+// waitFor polls cond until it holds or a few seconds have passed.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcherRetries(t *testing.T) {
+	server := receiver(t, "secret", http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK)
+	storage := &webhookStorage{webhooks: []models.Webhook{
+		{ID: 1, URL: server.URL, Secret: "secret", Enabled: true},
+		{ID: 2, URL: server.URL, Secret: "other", Events: []string{events.Deleted}, Enabled: true},
+	}}
+	broker := events.NewBroker()
+	start(t, storage, broker)
+
+	broker.Publish(events.Created, dto.TaskResponse{ID: 7})
+
+	waitFor(t, func() bool { return len(storage.delivered()) == 3 })
+	for i, d := range storage.delivered() {
+		if d.WebhookID != 1 || d.EventID != 1 || d.EventType != events.Created || d.TaskID != 7 || d.Attempt != i+1 {
+			t.Errorf("unexpected delivery %v: %+v", i, d)
+		}
+		if d.Success != (i == 2) {
+			t.Errorf("delivery %v: want success %v got %+v", i, i == 2, d)
+		}
+	}
+}
+
+func TestDispatcherDisablesFailingWebhook(t *testing.T) {
+	server := receiver(t, "secret", http.StatusInternalServerError)
+	storage := &webhookStorage{webhooks: []models.Webhook{
+		{ID: 1, URL: server.URL, Secret: "secret", Enabled: true},
+	}}
+	broker := events.NewBroker()
+	start(t, storage, broker, taskservice.WithWebhookFailures(2))
+
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 7})
+
+	waitFor(t, func() bool { return len(storage.delivered()) == 2 })
+	// Retries stop once the webhook is disabled.
+	time.Sleep(100 * time.Millisecond)
+	if n := len(storage.delivered()); n != 2 {
+		t.Errorf("expected %v deliveries, got: %v", 2, n)
+	}
+	if w, _ := storage.GetWebhook(context.Background(), 1); w.Enabled {
+		t.Errorf("webhook should be disabled: %+v", w)
+	}
+
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 7})
+	time.Sleep(50 * time.Millisecond)
+	if n := len(storage.delivered()); n != 2 {
+		t.Errorf("disabled webhook should not be sent to, got %v deliveries", n)
+	}
+}
+
+func TestDispatcherGivesUp(t *testing.T) {
+	server := receiver(t, "secret", http.StatusServiceUnavailable)
+	storage := &webhookStorage{webhooks: []models.Webhook{
+		{ID: 1, URL: server.URL, Secret: "secret", Enabled: true},
+	}}
+	broker := events.NewBroker()
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	service := taskservice.NewTaskService(taskservice.WithTaskStorage(storage), taskservice.WithLogger(logger), taskservice.WithPrivateWebhooks(true))
+	StartWebhookDispatcher(
+		WithBroker(broker),
+		WithService(service),
+		WithMaxAttempts(3),
+		WithBackoff(time.Millisecond),
+		WithLogger(logger),
+		WithWaitGroup(wg),
+		WithDone(doneCh),
+	)
+	defer func() {
+		close(doneCh)
+		wg.Wait()
+	}()
+
+	broker.Publish(events.Created, dto.TaskResponse{ID: 7})
+
+	waitFor(t, func() bool { return len(storage.delivered()) == 3 })
+	time.Sleep(50 * time.Millisecond)
+	if n := len(storage.delivered()); n != 3 {
+		t.Errorf("expected %v deliveries, got: %v", 3, n)
+	}
+}
+
+func TestDispatcherConcurrency(t *testing.T) {
+	var inFlight, most atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for m := most.Load(); n > m && !most.CompareAndSwap(m, n); m = most.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	t.Cleanup(server.Close)
+	storage := &webhookStorage{webhooks: []models.Webhook{
+		{ID: 1, URL: server.URL, Enabled: true},
+		{ID: 2, URL: server.URL, Enabled: true},
+	}}
+	broker := events.NewBroker()
+	startWith(t, storage, broker, []DispatcherOption{WithConcurrency(1)})
+
+	for i := 0; i < 3; i++ {
+		broker.Publish(events.Created, dto.TaskResponse{ID: 7})
+	}
+
+	waitFor(t, func() bool { return len(storage.delivered()) == 6 })
+	if n := most.Load(); n != 1 {
+		t.Errorf("expected one delivery at a time, got: %v", n)
+	}
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	if storage.lists != 1 {
+		t.Errorf("expected the webhooks to be listed once, got: %v", storage.lists)
+	}
+}
+
+func TestDispatcherDisabled(t *testing.T) {
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+
+	StartWebhookDispatcher(
+		WithBroker(events.NewBroker()),
+		WithLogger(logger),
+		WithWaitGroup(wg),
+		WithDone(doneCh),
+	)
+	close(doneCh)
+	wg.Wait()
+}
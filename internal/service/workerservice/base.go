@@ -45,6 +45,12 @@ func WithService(service taskservice.TaskService) TaskWorkerOption {
 	}
 }
 
+func WithLogger(l *slog.Logger) TaskWorkerOption {
+	return func(t *taskWorker) {
+		t.logger = l
+	}
+}
+
 func WithChannel(reqChan chan models.TaskJobModel, resChan chan any, errChan chan error, done chan struct{}) TaskWorkerOption {
 	return func(t *taskWorker) {
 		t.ReqChan = reqChan
@@ -8,19 +8,46 @@ import (
 )
 
 var (
-	errServiceDelete = errors.New("service delete error")
-	errServiceGet    = errors.New("service get error")
-	errServiceList   = errors.New("service list error")
-	errServiceSet    = errors.New("service set error")
-	errServiceUpdate = errors.New("service update error")
+	errServiceDelete  = errors.New("service delete error")
+	errServiceGet     = errors.New("service get error")
+	errServiceList    = errors.New("service list error")
+	errServiceSet     = errors.New("service set error")
+	errServiceUpdate  = errors.New("service update error")
+	errServiceRestore = errors.New("service restore error")
+	errServicePurge   = errors.New("service purge error")
+	errServiceHistory = errors.New("service history error")
+	errServiceSearch  = errors.New("service search error")
+	errServiceFlow    = errors.New("service workflow error")
+	errServiceTag     = errors.New("service tag error")
+	errServiceLink    = errors.New("service link error")
+	errServiceComment = errors.New("service comment error")
+	errServiceAttach  = errors.New("service attach error")
+	errServiceBulk    = errors.New("service bulk error")
+	errServiceExport  = errors.New("service export error")
+	errServicePatch   = errors.New("service patch error")
+	errServiceWebhook = errors.New("service webhook error")
 )
 
 type mockTaskService struct {
-	deleteErr error
-	getErr    error
-	listErr   error
-	setErr    error
-	updateErr error
+	deleteErr  error
+	getErr     error
+	listErr    error
+	setErr     error
+	updateErr  error
+	restoreErr error
+	purgeErr   error
+	historyErr error
+	searchErr  error
+	flowErr    error
+	tagErr     error
+	linkErr    error
+	commentErr error
+	attachErr  error
+	bulkErr    error
+	exportErr  error
+	patchErr   error
+	webhookErr error
+	listReq    dto.ListTaskRequest
 }
 
 func (m *mockTaskService) Delete(context.Context, dto.DeleteTaskRequest) error {
@@ -31,8 +58,9 @@ func (m *mockTaskService) Get(context.Context, dto.GetTaskRequest) (dto.TaskResp
 	return dto.TaskResponse{}, m.getErr
 }
 
-func (m *mockTaskService) List(context.Context, dto.ListTaskRequest) ([]dto.TaskResponse, error) {
-	return []dto.TaskResponse{}, m.listErr
+func (m *mockTaskService) List(_ context.Context, req dto.ListTaskRequest) (dto.TaskListResponse, error) {
+	m.listReq = req
+	return dto.TaskListResponse{}, m.listErr
 }
 
 func (m *mockTaskService) Set(context.Context, dto.SetTaskRequest) (dto.TaskResponse, error) {
@@ -42,3 +70,130 @@ func (m *mockTaskService) Set(context.Context, dto.SetTaskRequest) (dto.TaskResp
 func (m *mockTaskService) Update(context.Context, dto.UpdateTaskRequest) (dto.TaskResponse, error) {
 	return dto.TaskResponse{}, m.updateErr
 }
+
+func (m *mockTaskService) Restore(context.Context, dto.RestoreTaskRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, m.restoreErr
+}
+
+func (m *mockTaskService) Purge(context.Context, dto.PurgeTaskRequest) error {
+	return m.purgeErr
+}
+
+func (m *mockTaskService) PurgeTrash(context.Context, dto.PurgeTrashRequest) (int64, error) {
+	return 0, m.purgeErr
+}
+
+func (m *mockTaskService) History(context.Context, dto.TaskHistoryRequest) ([]dto.TaskEventResponse, error) {
+	return []dto.TaskEventResponse{}, m.historyErr
+}
+
+func (m *mockTaskService) Snapshot(context.Context, dto.TaskSnapshotRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, m.historyErr
+}
+
+func (m *mockTaskService) Search(context.Context, dto.SearchTaskRequest) (dto.TaskSearchResponse, error) {
+	return dto.TaskSearchResponse{}, m.searchErr
+}
+
+func (m *mockTaskService) Workflow(context.Context) (dto.WorkflowResponse, error) {
+	return dto.WorkflowResponse{}, m.flowErr
+}
+
+func (m *mockTaskService) Tag(context.Context, dto.TagTaskRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, m.tagErr
+}
+
+func (m *mockTaskService) Untag(context.Context, dto.TagTaskRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, m.tagErr
+}
+
+func (m *mockTaskService) Tags(context.Context) ([]dto.TagCountResponse, error) {
+	return []dto.TagCountResponse{}, m.tagErr
+}
+
+func (m *mockTaskService) SetParent(context.Context, dto.SetParentRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, m.linkErr
+}
+
+func (m *mockTaskService) Block(context.Context, dto.TaskDependencyRequest) (dto.TaskGraphResponse, error) {
+	return dto.TaskGraphResponse{}, m.linkErr
+}
+
+func (m *mockTaskService) Unblock(context.Context, dto.TaskDependencyRequest) (dto.TaskGraphResponse, error) {
+	return dto.TaskGraphResponse{}, m.linkErr
+}
+
+func (m *mockTaskService) Graph(context.Context, dto.TaskGraphRequest) (dto.TaskGraphResponse, error) {
+	return dto.TaskGraphResponse{}, m.linkErr
+}
+
+func (m *mockTaskService) AddComment(context.Context, dto.AddCommentRequest) (dto.CommentResponse, error) {
+	return dto.CommentResponse{}, m.commentErr
+}
+
+func (m *mockTaskService) EditComment(context.Context, dto.EditCommentRequest) (dto.CommentResponse, error) {
+	return dto.CommentResponse{}, m.commentErr
+}
+
+func (m *mockTaskService) DeleteComment(context.Context, dto.DeleteCommentRequest) error {
+	return m.commentErr
+}
+
+func (m *mockTaskService) Comments(context.Context, dto.ListCommentsRequest) (dto.CommentListResponse, error) {
+	return dto.CommentListResponse{}, m.commentErr
+}
+
+func (m *mockTaskService) Attach(context.Context, dto.AttachRequest) (dto.AttachmentResponse, error) {
+	return dto.AttachmentResponse{}, m.attachErr
+}
+
+func (m *mockTaskService) Download(context.Context, dto.GetAttachmentRequest) (dto.AttachmentDownload, error) {
+	return dto.AttachmentDownload{}, m.attachErr
+}
+
+func (m *mockTaskService) Detach(context.Context, dto.DeleteAttachmentRequest) error {
+	return m.attachErr
+}
+
+func (m *mockTaskService) Attachments(context.Context, dto.ListAttachmentsRequest) ([]dto.AttachmentResponse, error) {
+	return nil, m.attachErr
+}
+
+func (m *mockTaskService) Bulk(context.Context, dto.BulkRequest) (dto.BulkResponse, error) {
+	return dto.BulkResponse{}, m.bulkErr
+}
+
+func (m *mockTaskService) Export(_ context.Context, req dto.ExportTaskRequest) error {
+	if m.exportErr != nil {
+		return m.exportErr
+	}
+	return req.Emit(dto.TaskResponse{ID: 1})
+}
+
+func (m *mockTaskService) Patch(context.Context, dto.PatchTaskRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, m.patchErr
+}
+
+func (m *mockTaskService) AddWebhook(context.Context, dto.AddWebhookRequest) (dto.WebhookResponse, error) {
+	return dto.WebhookResponse{}, m.webhookErr
+}
+
+func (m *mockTaskService) Webhooks(context.Context) ([]dto.WebhookResponse, error) {
+	return nil, m.webhookErr
+}
+
+func (m *mockTaskService) DeleteWebhook(context.Context, dto.DeleteWebhookRequest) error {
+	return m.webhookErr
+}
+
+func (m *mockTaskService) EnableWebhook(context.Context, dto.EnableWebhookRequest) (dto.WebhookResponse, error) {
+	return dto.WebhookResponse{}, m.webhookErr
+}
+
+func (m *mockTaskService) WebhookDeliveries(context.Context, dto.ListWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, error) {
+	return nil, m.webhookErr
+}
+
+func (m *mockTaskService) Deliver(context.Context, dto.DeliverWebhookRequest) (dto.WebhookDeliveryResponse, error) {
+	return dto.WebhookDeliveryResponse{}, m.webhookErr
+}
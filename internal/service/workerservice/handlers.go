@@ -18,7 +18,7 @@ func (t *taskWorker) Submit(f models.TaskJobModel) (any, error) {
 		case res := <-t.ResChan:
 			return res, nil
 		case <-t.done:
-			t.logger.Info("worker closed while processing the request", "job: ", f.JOB)
+			t.logger.InfoContext(f.Context, "worker closed while processing the request", "job", f.JOB)
 			return nil, fmt.Errorf("worker closed while processing the request")
 		}
 	}
@@ -58,6 +58,9 @@ func (w *taskWorker) set(f models.TaskJobModel) {
 		Title:       f.Title,
 		Description: f.Description,
 		Status:      f.Status,
+		Priority:    f.Priority,
+		DueAt:       f.DueAt,
+		Assignee:    f.Assignee,
 	}
 	resp, err := w.service.Set(f.Context, req)
 	if err != nil {
@@ -117,6 +120,9 @@ func (w *taskWorker) update(f models.TaskJobModel) {
 		Title:       f.Title,
 		Description: f.Description,
 		Status:      f.Status,
+		Priority:    f.Priority,
+		DueAt:       f.DueAt,
+		Assignee:    f.Assignee,
 	}
 	resp, err := w.service.Update(f.Context, req)
 	if err != nil {
@@ -142,9 +148,86 @@ func (w *taskWorker) update(f models.TaskJobModel) {
 	}
 }
 
+func (w *taskWorker) restore(f models.TaskJobModel) {
+	req := dto.RestoreTaskRequest{
+		ID: f.ID,
+	}
+	resp, err := w.service.Restore(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) purge(f models.TaskJobModel) {
+	req := dto.PurgeTaskRequest{
+		ID: f.ID,
+	}
+	err := w.service.Purge(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- nil
+	}
+}
+
 func (w *taskWorker) list(f models.TaskJobModel) {
 	req := dto.ListTaskRequest{
-		Status: f.Status,
+		Statuses:      f.Filter.Statuses,
+		Title:         f.Filter.Title,
+		Description:   f.Filter.Description,
+		IDFrom:        f.Filter.IDFrom,
+		IDTo:          f.Filter.IDTo,
+		Priorities:    f.Filter.Priorities,
+		Assignee:      f.Filter.Assignee,
+		DueAfter:      f.Filter.DueAfter,
+		DueBefore:     f.Filter.DueBefore,
+		Tags:          f.Filter.Tags,
+		TagMode:       f.Filter.TagMode,
+		CreatedBy:     f.Filter.CreatedBy,
+		UpdatedBy:     f.Filter.UpdatedBy,
+		CreatedAfter:  f.Filter.CreatedAfter,
+		CreatedBefore: f.Filter.CreatedBefore,
+		UpdatedAfter:  f.Filter.UpdatedAfter,
+		UpdatedBefore: f.Filter.UpdatedBefore,
+		SortBy:        f.Filter.SortBy,
+		SortOrder:     f.Filter.SortOrder,
+		Deleted:       f.Filter.Deleted,
+		Limit:         f.Filter.Limit,
+		Cursor:        f.Filter.Cursor,
 	}
 	resp, err := w.service.List(f.Context, req)
 	if err != nil {
@@ -170,6 +253,802 @@ func (w *taskWorker) list(f models.TaskJobModel) {
 	}
 }
 
+func (w *taskWorker) history(f models.TaskJobModel) {
+	req := dto.TaskHistoryRequest{
+		ID: f.ID,
+	}
+	resp, err := w.service.History(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) snapshot(f models.TaskJobModel) {
+	req := dto.TaskSnapshotRequest{
+		ID: f.ID,
+		At: f.At,
+	}
+	resp, err := w.service.Snapshot(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) search(f models.TaskJobModel) {
+	req := dto.SearchTaskRequest{
+		Query:  f.Query,
+		Limit:  f.Filter.Limit,
+		Cursor: f.Filter.Cursor,
+	}
+	resp, err := w.service.Search(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) workflow(f models.TaskJobModel) {
+	resp, err := w.service.Workflow(f.Context)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) tag(f models.TaskJobModel) {
+	req := dto.TagTaskRequest{
+		ID:   f.ID,
+		Tags: f.Tags,
+	}
+	resp, err := w.service.Tag(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) untag(f models.TaskJobModel) {
+	req := dto.TagTaskRequest{
+		ID:   f.ID,
+		Tags: f.Tags,
+	}
+	resp, err := w.service.Untag(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) tags(f models.TaskJobModel) {
+	resp, err := w.service.Tags(f.Context)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) parent(f models.TaskJobModel) {
+	req := dto.SetParentRequest{
+		ID:       f.ID,
+		ParentID: f.ParentID,
+	}
+	resp, err := w.service.SetParent(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) block(f models.TaskJobModel) {
+	req := dto.TaskDependencyRequest{
+		ID:        f.ID,
+		BlockerID: f.BlockerID,
+	}
+	resp, err := w.service.Block(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) unblock(f models.TaskJobModel) {
+	req := dto.TaskDependencyRequest{
+		ID:        f.ID,
+		BlockerID: f.BlockerID,
+	}
+	resp, err := w.service.Unblock(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) graph(f models.TaskJobModel) {
+	req := dto.TaskGraphRequest{
+		ID: f.ID,
+	}
+	resp, err := w.service.Graph(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) comment(f models.TaskJobModel) {
+	req := dto.AddCommentRequest{
+		TaskID: f.ID,
+		Body:   f.Body,
+	}
+	resp, err := w.service.AddComment(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) editComment(f models.TaskJobModel) {
+	req := dto.EditCommentRequest{
+		ID:   f.CommentID,
+		Body: f.Body,
+	}
+	resp, err := w.service.EditComment(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) deleteComment(f models.TaskJobModel) {
+	req := dto.DeleteCommentRequest{
+		ID: f.CommentID,
+	}
+	err := w.service.DeleteComment(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- nil
+	}
+}
+
+func (w *taskWorker) comments(f models.TaskJobModel) {
+	req := dto.ListCommentsRequest{
+		TaskID: f.ID,
+		Limit:  f.Filter.Limit,
+		Cursor: f.Filter.Cursor,
+	}
+	resp, err := w.service.Comments(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) attach(f models.TaskJobModel) {
+	req := dto.AttachRequest{
+		TaskID: f.ID,
+	}
+	if f.Upload != nil {
+		req.Name = f.Upload.Name
+		req.Content = f.Upload.Content
+	}
+	resp, err := w.service.Attach(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) download(f models.TaskJobModel) {
+	req := dto.GetAttachmentRequest{
+		ID: f.ID,
+	}
+	resp, err := w.service.Download(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) detach(f models.TaskJobModel) {
+	req := dto.DeleteAttachmentRequest{
+		ID: f.ID,
+	}
+	err := w.service.Detach(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- nil
+	}
+}
+
+func (w *taskWorker) attachments(f models.TaskJobModel) {
+	req := dto.ListAttachmentsRequest{
+		TaskID: f.ID,
+	}
+	resp, err := w.service.Attachments(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) bulk(f models.TaskJobModel) {
+	req := dto.BulkRequest{
+		Mode:       f.Mode,
+		Operations: make([]dto.BulkOperation, len(f.Operations)),
+	}
+	for i, op := range f.Operations {
+		req.Operations[i] = dto.BulkOperation(op)
+	}
+	resp, err := w.service.Bulk(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) export(f models.TaskJobModel) {
+	req := dto.ExportTaskRequest{
+		Filter: dto.ListTaskRequest{
+			Statuses:      f.Filter.Statuses,
+			Title:         f.Filter.Title,
+			Description:   f.Filter.Description,
+			IDFrom:        f.Filter.IDFrom,
+			IDTo:          f.Filter.IDTo,
+			Priorities:    f.Filter.Priorities,
+			Assignee:      f.Filter.Assignee,
+			DueAfter:      f.Filter.DueAfter,
+			DueBefore:     f.Filter.DueBefore,
+			Tags:          f.Filter.Tags,
+			TagMode:       f.Filter.TagMode,
+			CreatedBy:     f.Filter.CreatedBy,
+			UpdatedBy:     f.Filter.UpdatedBy,
+			CreatedAfter:  f.Filter.CreatedAfter,
+			CreatedBefore: f.Filter.CreatedBefore,
+			UpdatedAfter:  f.Filter.UpdatedAfter,
+			UpdatedBefore: f.Filter.UpdatedBefore,
+			SortBy:        f.Filter.SortBy,
+			SortOrder:     f.Filter.SortOrder,
+			Deleted:       f.Filter.Deleted,
+		},
+		Emit: func(task dto.TaskResponse) error {
+			return f.Emit(task)
+		},
+	}
+	err := w.service.Export(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- nil
+	}
+}
+
+func (w *taskWorker) patch(f models.TaskJobModel) {
+	req := dto.PatchTaskRequest{
+		ID:    f.ID,
+		Type:  f.PatchType,
+		Patch: f.Patch,
+	}
+	resp, err := w.service.Patch(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) addWebhook(f models.TaskJobModel) {
+	req := dto.AddWebhookRequest{
+		URL:    f.URL,
+		Events: f.EventTypes,
+		Secret: f.Secret,
+	}
+	resp, err := w.service.AddWebhook(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) webhooks(f models.TaskJobModel) {
+	resp, err := w.service.Webhooks(f.Context)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) deleteWebhook(f models.TaskJobModel) {
+	req := dto.DeleteWebhookRequest{
+		ID: f.ID,
+	}
+	err := w.service.DeleteWebhook(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- nil
+	}
+}
+
+func (w *taskWorker) enableWebhook(f models.TaskJobModel) {
+	req := dto.EnableWebhookRequest{
+		ID: f.ID,
+	}
+	resp, err := w.service.EnableWebhook(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
+func (w *taskWorker) webhookDeliveries(f models.TaskJobModel) {
+	req := dto.ListWebhookDeliveriesRequest{
+		ID:    f.ID,
+		Limit: f.Filter.Limit,
+	}
+	resp, err := w.service.WebhookDeliveries(f.Context, req)
+	if err != nil {
+		select {
+		case <-w.done:
+			close(w.ResChan)
+			close(w.ErrChan)
+			close(w.ReqChan)
+			return
+		default:
+			w.ErrChan <- err
+			return
+		}
+	}
+	select {
+	case <-w.done:
+		close(w.ResChan)
+		close(w.ErrChan)
+		close(w.ReqChan)
+		return
+	default:
+		w.ResChan <- resp
+	}
+}
+
 func (w *taskWorker) worker() {
 	defer w.Wg.Done()
 
@@ -184,6 +1063,7 @@ func (w *taskWorker) worker() {
 			w.mu.Unlock()
 			return
 		case f := <-w.ReqChan:
+			w.logger.DebugContext(f.Context, "worker processing the request", "job", f.JOB)
 			switch f.JOB {
 			case "GET":
 				w.get(f)
@@ -195,6 +1075,64 @@ func (w *taskWorker) worker() {
 				w.update(f)
 			case "LIST":
 				w.list(f)
+			case "SEARCH":
+				w.search(f)
+			case "RESTORE":
+				w.restore(f)
+			case "PURGE":
+				w.purge(f)
+			case "HISTORY":
+				w.history(f)
+			case "SNAPSHOT":
+				w.snapshot(f)
+			case "WORKFLOW":
+				w.workflow(f)
+			case "TAG":
+				w.tag(f)
+			case "UNTAG":
+				w.untag(f)
+			case "TAGS":
+				w.tags(f)
+			case "PARENT":
+				w.parent(f)
+			case "BLOCK":
+				w.block(f)
+			case "UNBLOCK":
+				w.unblock(f)
+			case "GRAPH":
+				w.graph(f)
+			case "COMMENT":
+				w.comment(f)
+			case "EDIT_COMMENT":
+				w.editComment(f)
+			case "DELETE_COMMENT":
+				w.deleteComment(f)
+			case "COMMENTS":
+				w.comments(f)
+			case "ATTACH":
+				w.attach(f)
+			case "DOWNLOAD":
+				w.download(f)
+			case "DETACH":
+				w.detach(f)
+			case "ATTACHMENTS":
+				w.attachments(f)
+			case "BULK":
+				w.bulk(f)
+			case "EXPORT":
+				w.export(f)
+			case "PATCH":
+				w.patch(f)
+			case "WEBHOOK":
+				w.addWebhook(f)
+			case "WEBHOOKS":
+				w.webhooks(f)
+			case "DELETE_WEBHOOK":
+				w.deleteWebhook(f)
+			case "ENABLE_WEBHOOK":
+				w.enableWebhook(f)
+			case "WEBHOOK_DELIVERIES":
+				w.webhookDeliveries(f)
 			}
 		}
 	}
@@ -180,6 +180,749 @@ func TestTaskWorkerWithList(t *testing.T) {
 	close(doneCh)
 }
 
+func TestTaskWorkerWithListTags(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "LIST",
+		Filter:  models.TaskFilter{Tags: []string{"api"}, TagMode: models.TagModeAll},
+	}
+	if _, err := worker.Submit(job); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+	if got := mockService.listReq; len(got.Tags) != 1 || got.Tags[0] != "api" || got.TagMode != models.TagModeAll {
+		t.Errorf("unexpected list request: %+v", got)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithRestore(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		restoreErr: errServiceRestore,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		ID:      1,
+		Context: ctx,
+		JOB:     "RESTORE",
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceRestore) {
+		t.Errorf("expected error: %v, got: %v", errServiceRestore, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithPurge(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		purgeErr: errServicePurge,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		ID:      1,
+		Context: ctx,
+		JOB:     "PURGE",
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServicePurge) {
+		t.Errorf("expected error: %v, got: %v", errServicePurge, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithHistory(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		historyErr: errServiceHistory,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		ID:      1,
+		Context: ctx,
+		JOB:     "HISTORY",
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceHistory) {
+		t.Errorf("expected error: %v, got: %v", errServiceHistory, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithSnapshot(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		historyErr: errServiceHistory,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		ID:      1,
+		At:      time.Now(),
+		Context: ctx,
+		JOB:     "SNAPSHOT",
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceHistory) {
+		t.Errorf("expected error: %v, got: %v", errServiceHistory, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithSearch(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		searchErr: errServiceSearch,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Query:   "invoice 4711",
+		Context: ctx,
+		JOB:     "SEARCH",
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceSearch) {
+		t.Errorf("expected error: %v, got: %v", errServiceSearch, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithWorkflow(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		flowErr: errServiceFlow,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "WORKFLOW",
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceFlow) {
+		t.Errorf("expected error: %v, got: %v", errServiceFlow, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithTag(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		tagErr: errServiceTag,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "TAG",
+		ID:      1,
+		Tags:    []string{"backend"},
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceTag) {
+		t.Errorf("expected error: %v, got: %v", errServiceTag, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithUntag(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		tagErr: errServiceTag,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "UNTAG",
+		ID:      1,
+		Tags:    []string{"backend"},
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceTag) {
+		t.Errorf("expected error: %v, got: %v", errServiceTag, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithTags(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		tagErr: errServiceTag,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "TAGS",
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceTag) {
+		t.Errorf("expected error: %v, got: %v", errServiceTag, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithParent(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		linkErr: errServiceLink,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "PARENT",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceLink) {
+		t.Errorf("expected error: %v, got: %v", errServiceLink, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithBlock(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		linkErr: errServiceLink,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "BLOCK",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceLink) {
+		t.Errorf("expected error: %v, got: %v", errServiceLink, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithUnblock(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		linkErr: errServiceLink,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "UNBLOCK",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceLink) {
+		t.Errorf("expected error: %v, got: %v", errServiceLink, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithGraph(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		linkErr: errServiceLink,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "GRAPH",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceLink) {
+		t.Errorf("expected error: %v, got: %v", errServiceLink, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithComment(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		commentErr: errServiceComment,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "COMMENT",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceComment) {
+		t.Errorf("expected error: %v, got: %v", errServiceComment, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithEditComment(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		commentErr: errServiceComment,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "EDIT_COMMENT",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceComment) {
+		t.Errorf("expected error: %v, got: %v", errServiceComment, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithDeleteComment(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		commentErr: errServiceComment,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "DELETE_COMMENT",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceComment) {
+		t.Errorf("expected error: %v, got: %v", errServiceComment, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithComments(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		commentErr: errServiceComment,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "COMMENTS",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceComment) {
+		t.Errorf("expected error: %v, got: %v", errServiceComment, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithAttach(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		attachErr: errServiceAttach,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "ATTACH",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceAttach) {
+		t.Errorf("expected error: %v, got: %v", errServiceAttach, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithDownload(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		attachErr: errServiceAttach,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "DOWNLOAD",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceAttach) {
+		t.Errorf("expected error: %v, got: %v", errServiceAttach, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithDetach(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		attachErr: errServiceAttach,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "DETACH",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceAttach) {
+		t.Errorf("expected error: %v, got: %v", errServiceAttach, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithAttachments(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		attachErr: errServiceAttach,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "ATTACHMENTS",
+		ID:      1,
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceAttach) {
+		t.Errorf("expected error: %v, got: %v", errServiceAttach, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithBulk(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		bulkErr: errServiceBulk,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "BULK",
+		Mode:    "atomic",
+		Operations: []models.BulkOperation{
+			{Op: "delete", ID: 1},
+		},
+	}
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceBulk) {
+		t.Errorf("expected error: %v, got: %v", errServiceBulk, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithExport(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var emitted []any
+	job := models.TaskJobModel{
+		Context: ctx,
+		JOB:     "EXPORT",
+		Emit: func(task any) error {
+			emitted = append(emitted, task)
+			return nil
+		},
+	}
+	if _, err := worker.Submit(job); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+	if len(emitted) != 1 {
+		t.Errorf("expected %d emitted tasks, got: %d", 1, len(emitted))
+	}
+	mockService.exportErr = errServiceExport
+	if _, err := worker.Submit(job); !errors.Is(err, errServiceExport) {
+		t.Errorf("expected error: %v, got: %v", errServiceExport, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithPatch(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job := models.TaskJobModel{
+		ID:        1,
+		Context:   ctx,
+		JOB:       "PATCH",
+		PatchType: "application/merge-patch+json",
+		Patch:     []byte(`{"status":"done"}`),
+	}
+	if _, err := worker.Submit(job); err != nil {
+		t.Errorf("expected error: %v, got: %v", nil, err)
+	}
+	mockService.patchErr = errServicePatch
+	if _, err := worker.Submit(job); !errors.Is(err, errServicePatch) {
+		t.Errorf("expected error: %v, got: %v", errServicePatch, err)
+	}
+	close(doneCh)
+}
+
+func TestTaskWorkerWithWebhooks(t *testing.T) {
+	reqChan := make(chan models.TaskJobModel, WokerCount)
+	resChan := make(chan any, WokerCount)
+	errChan := make(chan error, WokerCount)
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	mockService := &mockTaskService{
+		webhookErr: errServiceWebhook,
+	}
+	worker := StartTaskWorker(
+		WithWorkerCount(WokerCount),
+		WithWaitGroup(wg),
+		WithService(mockService),
+		WithChannel(reqChan, resChan, errChan, doneCh),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, job := range []string{"WEBHOOK", "WEBHOOKS", "DELETE_WEBHOOK", "ENABLE_WEBHOOK", "WEBHOOK_DELIVERIES"} {
+		if _, err := worker.Submit(models.TaskJobModel{Context: ctx, JOB: job, ID: 1}); !errors.Is(err, errServiceWebhook) {
+			t.Errorf("%v: expected error: %v, got: %v", job, errServiceWebhook, err)
+		}
+	}
+	close(doneCh)
+}
+
 func TestTaskWorkerWithInvalidCRUD(t *testing.T) {
 	reqChan := make(chan models.TaskJobModel, WokerCount)
 	resChan := make(chan any, WokerCount)
@@ -0,0 +1,52 @@
+package graphqlhandler
+
+import "time"
+
+// The values of arguments as coerced by the executor: ints are int, lists
+// are []any and DateTimes are time.Time. A missing or null argument reads
+// as the zero value.
+
+func intArg(args map[string]any, name string) int {
+	n, _ := args[name].(int)
+	return n
+}
+
+func stringArg(args map[string]any, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func timeArg(args map[string]any, name string) time.Time {
+	t, _ := args[name].(time.Time)
+	return t
+}
+
+func timePtrArg(args map[string]any, name string) *time.Time {
+	t, ok := args[name].(time.Time)
+	if !ok {
+		return nil
+	}
+	return &t
+}
+
+func stringsArg(args map[string]any, name string) []string {
+	values, _ := args[name].([]any)
+	var res []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func intsArg(args map[string]any, name string) []int {
+	values, _ := args[name].([]any)
+	var res []int
+	for _, v := range values {
+		if n, ok := v.(int); ok {
+			res = append(res, n)
+		}
+	}
+	return res
+}
@@ -0,0 +1,274 @@
+package graphqlhandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/workerservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+)
+
+// Path is the path the GraphQL endpoint is served at.
+const Path = "/graphql"
+
+// MaxQuerySize is the largest request body in bytes, and the longest query
+// in bytes, sent with GET or POST.
+const MaxQuerySize = 64 << 10
+
+type GraphQLHandler interface {
+	GraphQL(http.ResponseWriter, *http.Request)
+}
+
+type graphqlHandler struct {
+	service       taskservice.TaskService
+	pool          workerservice.TaskWorker
+	schema        graphql.Schema
+	maxDepth      int
+	maxComplexity int
+	basehttphandler.Handler
+}
+
+type GraphQLHandlerOption func(*graphqlHandler)
+
+func WithPool(pool workerservice.TaskWorker) GraphQLHandlerOption {
+	return func(handler *graphqlHandler) {
+		handler.pool = pool
+	}
+}
+
+func WithService(service taskservice.TaskService) GraphQLHandlerOption {
+	return func(handler *graphqlHandler) {
+		handler.service = service
+	}
+}
+
+func WithContextTimeout(d time.Duration) GraphQLHandlerOption {
+	return func(handler *graphqlHandler) {
+		handler.CancelTimeout = d
+	}
+}
+
+func WithLogger(l *slog.Logger) GraphQLHandlerOption {
+	return func(handler *graphqlHandler) {
+		handler.Logger = l
+	}
+}
+
+// WithMaxDepth sets how deeply the selections of a query may nest.
+// DefaultMaxDepth is used when it is not positive.
+func WithMaxDepth(n int) GraphQLHandlerOption {
+	return func(handler *graphqlHandler) {
+		if n > 0 {
+			handler.maxDepth = n
+		}
+	}
+}
+
+// WithMaxComplexity sets the largest cost of a query, as computed by
+// Complexity. DefaultMaxComplexity is used when it is not positive.
+func WithMaxComplexity(n int) GraphQLHandlerOption {
+	return func(handler *graphqlHandler) {
+		if n > 0 {
+			handler.maxComplexity = n
+		}
+	}
+}
+
+func New(opts ...GraphQLHandlerOption) GraphQLHandler {
+	handler := &graphqlHandler{
+		maxDepth:      DefaultMaxDepth,
+		maxComplexity: DefaultMaxComplexity,
+		Handler:       basehttphandler.Handler{},
+	}
+	for _, opt := range opts {
+		opt(handler)
+	}
+	handler.schema = newSchema(handler)
+	return handler
+}
+
+// Request is a GraphQL request as sent in the body of a POST.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// @Tags GraphQL
+// @Summary Query and change tasks with GraphQL.
+// @Description This endpoint runs a GraphQL query or mutation over the tasks. Queries read a task by ID or a filtered page of tasks; mutations create, update and delete tasks. Queries longer than 64 KiB, nesting deeper than the depth limit or costing more than the complexity limit are rejected before they are validated. A query may also be sent with GET in the query, operationName and variables parameters. Errors of fields carry the code of their problem in extensions.code.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body Request true "GraphQL request"
+// @Success 200 {object} map[string]interface{} "Success Response Body. The data asked for, with the errors of the fields that failed."
+// @Failure 400 {object} map[string]interface{} "Error Bad Request Response. The query is invalid or exceeds a limit."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body or the query is too large."
+// @Failure 415 {object} basehttphandler.Problem "Error Unsupported Media Type Response. The body is not JSON."
+// @Router /graphql [post]
+func (h *graphqlHandler) GraphQL(w http.ResponseWriter, r *http.Request) {
+	var (
+		req Request
+	)
+	switch r.Method {
+	case http.MethodPost:
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != "application/json" {
+			h.Fail(w, r, http.StatusUnsupportedMediaType, "body must be application/json")
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, MaxQuerySize)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				h.Fail(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+			if errors.Is(err, io.EOF) {
+				h.Fail(w, r, http.StatusBadRequest, "request body is empty")
+				return
+			}
+			h.Fail(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				h.Fail(w, r, http.StatusBadRequest, "invalid variables: must be a JSON object")
+				return
+			}
+		}
+	default:
+		h.MethodNotAllowed(w, r, http.MethodGet, http.MethodPost)
+		return
+	}
+	if req.Query == "" {
+		h.Fail(w, r, http.StatusBadRequest, "query required")
+		return
+	}
+	if len(req.Query) > MaxQuerySize {
+		h.Fail(w, r, http.StatusRequestEntityTooLarge, "query too large")
+		return
+	}
+
+	// @Step: Validate Query
+	doc, errs := h.prepare(req)
+	if len(errs) > 0 {
+		h.JSON(w, http.StatusBadRequest, &graphql.Result{Errors: errs})
+		return
+	}
+	if r.Method == http.MethodGet && isMutation(doc, req.OperationName) {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+
+	// @Step: Execute Query
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	res := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	h.JSON(w, http.StatusOK, res)
+}
+
+// prepare parses the query of req, checks it against the depth and
+// complexity limits and validates it. The limits come first, so that
+// validation, whose cost grows faster than the size of the query, only
+// sees queries within them. They are checked for the operation to run, or
+// for every operation when it is not found.
+func (h *graphqlHandler) prepare(req Request) (*ast.Document, []gqlerrors.FormattedError) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return nil, gqlerrors.FormatErrors(err)
+	}
+	op := operation(doc, req.OperationName)
+	ops := []*ast.OperationDefinition{op}
+	if op == nil {
+		ops = operations(doc)
+	}
+	for _, op := range ops {
+		if errs := h.checkLimits(doc, op, req.Variables); len(errs) > 0 {
+			return nil, errs
+		}
+	}
+	if res := graphql.ValidateDocument(&h.schema, doc, nil); !res.IsValid {
+		return nil, res.Errors
+	}
+	if op == nil {
+		return nil, gqlerrors.FormatErrors(errors.New("unknown operation " + req.OperationName))
+	}
+	return doc, nil
+}
+
+// checkLimits checks op against the depth and complexity limits.
+func (h *graphqlHandler) checkLimits(doc *ast.Document, op *ast.OperationDefinition, vars map[string]any) []gqlerrors.FormattedError {
+	if depth := Depth(doc, op); depth > h.maxDepth {
+		return []gqlerrors.FormattedError{limitError(errQueryDepth, depth, h.maxDepth)}
+	}
+	if depth := IntrospectionDepth(doc, op); depth > MaxIntrospectionDepth {
+		return []gqlerrors.FormattedError{limitError(errQueryDepth, depth, MaxIntrospectionDepth)}
+	}
+	if complexity := Complexity(h.schema, doc, op, vars); complexity > h.maxComplexity {
+		return []gqlerrors.FormattedError{limitError(errQueryComplexity, complexity, h.maxComplexity)}
+	}
+	return nil
+}
+
+// operation returns the operation of doc named name, the only one when
+// name is empty.
+func operation(doc *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = op
+			continue
+		}
+		if op.Name != nil && op.Name.Value == name {
+			return op
+		}
+	}
+	return found
+}
+
+func operations(doc *ast.Document) []*ast.OperationDefinition {
+	var ops []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func isMutation(doc *ast.Document, name string) bool {
+	op := operation(doc, name)
+	return op != nil && op.Operation == ast.OperationTypeMutation
+}
@@ -0,0 +1,123 @@
+package graphqlhandler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/graphql/graphqlhandler"
+)
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+type mockTaskWorker struct {
+	submitErr error
+	response  any
+	onSubmit  func(models.TaskJobModel)
+	// respond, when set, answers the jobs instead of submitErr and response.
+	respond func(models.TaskJobModel) (any, error)
+}
+
+func (m *mockTaskWorker) Submit(job models.TaskJobModel) (any, error) {
+	if m.onSubmit != nil {
+		m.onSubmit(job)
+	}
+	if m.respond != nil {
+		return m.respond(job)
+	}
+	return m.response, m.submitErr
+}
+
+// result is a GraphQL response as read by a client.
+type result struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// code returns the code of the first error of r.
+func (r result) code() string {
+	if len(r.Errors) == 0 {
+		return ""
+	}
+	code, _ := r.Errors[0].Extensions["code"].(string)
+	return code
+}
+
+func newHandler(pool *mockTaskWorker, opts ...graphqlhandler.GraphQLHandlerOption) graphqlhandler.GraphQLHandler {
+	return graphqlhandler.New(append([]graphqlhandler.GraphQLHandlerOption{
+		graphqlhandler.WithPool(pool),
+		graphqlhandler.WithLogger(logger),
+		graphqlhandler.WithContextTimeout(5 * time.Second),
+	}, opts...)...)
+}
+
+// post sends query with vars to handler and returns the status and result.
+func post(t *testing.T, handler graphqlhandler.GraphQLHandler, query string, vars map[string]any) (int, result) {
+	t.Helper()
+	body, _ := json.Marshal(graphqlhandler.Request{Query: query, Variables: vars})
+	req := httptest.NewRequest(http.MethodPost, graphqlhandler.Path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.GraphQL(w, req)
+
+	var res result
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("invalid response %s: %v", w.Body, err)
+	}
+	return w.Code, res
+}
+
+func TestGraphQLRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		target      string
+		body        string
+		status      int
+		want        string
+	}{
+		{"get query", http.MethodGet, "", graphqlhandler.Path + "?query=" + url.QueryEscape("{task(id:1){id}}"), "", http.StatusOK, `"task":{"id":1}`},
+		{"get variables", http.MethodGet, "", graphqlhandler.Path + "?query=" + url.QueryEscape("query($id:Int!){task(id:$id){id}}") + "&variables=" + url.QueryEscape(`{"id":1}`), "", http.StatusOK, `"id":1`},
+		{"get mutation", http.MethodGet, "", graphqlhandler.Path + "?query=" + url.QueryEscape("mutation{deleteTask(id:1)}"), "", http.StatusMethodNotAllowed, `"code":"request.method_not_allowed"`},
+		{"put", http.MethodPut, "application/json", graphqlhandler.Path, `{"query":"{task(id:1){id}}"}`, http.StatusMethodNotAllowed, `"code":"request.method_not_allowed"`},
+		{"not json", http.MethodPost, "text/plain", graphqlhandler.Path, `{task(id:1){id}}`, http.StatusUnsupportedMediaType, `"code":"request.unsupported_media_type"`},
+		{"empty body", http.MethodPost, "application/json", graphqlhandler.Path, ``, http.StatusBadRequest, `request body is empty`},
+		{"no query", http.MethodPost, "application/json", graphqlhandler.Path, `{}`, http.StatusBadRequest, `query required`},
+		{"syntax error", http.MethodPost, "application/json", graphqlhandler.Path, `{"query":"{task(id:1){id}"}`, http.StatusBadRequest, `"errors"`},
+		{"get query too large", http.MethodGet, "", graphqlhandler.Path + "?query=" + url.QueryEscape("{task(id:1){"+strings.Repeat("id ", graphqlhandler.MaxQuerySize/3)+"}}"), "", http.StatusRequestEntityTooLarge, `"code":"request.too_large"`},
+		{"fragment cycle", http.MethodPost, "application/json", graphqlhandler.Path, `{"query":"{task(id:1){...a}} fragment a on Task {parent{...a}}"}`, http.StatusBadRequest, `Cannot spread fragment \"a\" within itself`},
+		{"unknown field", http.MethodPost, "application/json", graphqlhandler.Path, `{"query":"{task(id:1){name}}"}`, http.StatusBadRequest, `Cannot query field \"name\"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newHandler(&mockTaskWorker{response: taskResponse(1)})
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			handler.GraphQL(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("wrong status code, want %v got %v: %s", tt.status, w.Code, w.Body)
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("wrong body, want %v got %s", tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package graphqlhandler
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+)
+
+func (h *graphqlHandler) createTask(p graphql.ResolveParams) (any, error) {
+	var (
+		req models.TaskJobModel
+	)
+	// @Step: Validate Request
+	input, _ := p.Args["input"].(map[string]any)
+	setReq := dto.SetTaskRequest{
+		ID:          uint(max(intArg(input, "id"), 0)),
+		Title:       stringArg(input, "title"),
+		Description: stringArg(input, "description"),
+		Status:      stringArg(input, "status"),
+		Priority:    intArg(input, "priority"),
+		DueAt:       timePtrArg(input, "dueAt"),
+		Assignee:    stringArg(input, "assignee"),
+	}
+	if err := basehttphandler.NewValidator().Struct(setReq); err != nil {
+		return nil, invalid(basehttphandler.ValidationError(setReq, err).Error())
+	}
+	setReq.TaskJobMapper(&req)
+	req.JOB = "SET"
+	req.Context = p.Context
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		return nil, h.fail(p.Context, "graphqlhandler createTask service.Set", err)
+	}
+	task, ok := res.(dto.TaskResponse)
+	if !ok {
+		return nil, h.fail(p.Context, "graphqlhandler createTask", customerror.ErrUnknown)
+	}
+	return task, nil
+}
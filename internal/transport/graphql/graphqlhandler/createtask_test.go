@@ -0,0 +1,46 @@
+package graphqlhandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+func TestCreateTask(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+		code  string
+	}{
+		{"created", `{id: 1, title: "title", description: "description", priority: 2, dueAt: "2026-05-01T00:00:00Z"}`, nil, ""},
+		{"invalid", `{id: 1, title: "t", description: "description"}`, nil, "request.invalid"},
+		{"exists", `{id: 1, title: "title", description: "description"}`, customerror.ErrIDExists, "task.id_exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job models.TaskJobModel
+			handler := newHandler(&mockTaskWorker{
+				submitErr: tt.err,
+				response:  taskResponse(1),
+				onSubmit:  func(j models.TaskJobModel) { job = j },
+			})
+
+			_, res := post(t, handler, `mutation{createTask(input: `+tt.input+`){id title}}`, nil)
+
+			if res.code() != tt.code {
+				t.Fatalf("wrong code, want %v got %+v", tt.code, res.Errors)
+			}
+			if tt.code != "" {
+				return
+			}
+			if got := string(res.Data["createTask"]); got != `{"id":1,"title":"title"}` {
+				t.Errorf("wrong task, got %v", got)
+			}
+			if job.JOB != "SET" || job.ID != 1 || job.Priority != 2 || job.DueAt == nil {
+				t.Errorf("wrong job, got %+v", job)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package graphqlhandler
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+func (h *graphqlHandler) deleteTask(p graphql.ResolveParams) (any, error) {
+	var (
+		req models.TaskJobModel
+	)
+	id := intArg(p.Args, "id")
+	if id <= 0 {
+		return nil, invalid("invalid id: must be a positive integer")
+	}
+	req.ID = uint(id)
+	req.JOB = "DELETE"
+	req.Context = p.Context
+
+	// @Step: Submit to Pool
+	if _, err := h.pool.Submit(req); err != nil {
+		return nil, h.fail(p.Context, "graphqlhandler deleteTask service.Delete", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,39 @@
+package graphqlhandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+func TestDeleteTask(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		err  error
+		code string
+	}{
+		{"deleted", "3", nil, ""},
+		{"zero id", "0", nil, "request.invalid"},
+		{"not found", "3", customerror.ErrIDNotFound, "task.not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job models.TaskJobModel
+			handler := newHandler(&mockTaskWorker{
+				submitErr: tt.err,
+				onSubmit:  func(j models.TaskJobModel) { job = j },
+			})
+
+			_, res := post(t, handler, `mutation{deleteTask(id: `+tt.id+`)}`, nil)
+
+			if res.code() != tt.code {
+				t.Fatalf("wrong code, want %v got %+v", tt.code, res.Errors)
+			}
+			if tt.code == "" && (job.JOB != "DELETE" || job.ID != 3) {
+				t.Errorf("wrong job, got %v %v", job.JOB, job.ID)
+			}
+		})
+	}
+}
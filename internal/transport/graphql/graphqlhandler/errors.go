@@ -0,0 +1,68 @@
+package graphqlhandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// fieldError is the error of a field, telling the code and status of its
+// problem, and the ID of the request it failed in, in the extensions of the
+// error.
+type fieldError struct {
+	message   string
+	code      string
+	status    int
+	requestID string
+}
+
+func (e *fieldError) Error() string {
+	return e.message
+}
+
+func (e *fieldError) Extensions() map[string]any {
+	extensions := map[string]any{"code": e.code, "status": e.status}
+	if e.requestID != "" {
+		extensions["request_id"] = e.requestID
+	}
+	return extensions
+}
+
+// invalid returns the error of a field whose arguments are invalid.
+func invalid(msg string) error {
+	return &fieldError{message: msg, code: basehttphandler.CodeInvalidRequest, status: 400}
+}
+
+// fail returns the error of a field reporting err, an error returned by the
+// service for op, and logs the ones the client cannot fix. The problem is
+// reported as it is by the REST endpoints.
+func (h *graphqlHandler) fail(ctx context.Context, op string, err error) error {
+	status := basehttphandler.StatusOf(err)
+	requestID := util.RequestIDFromContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &fieldError{message: constant.ErrContextDeadline, code: basehttphandler.CodeTimeout, status: status, requestID: requestID}
+	}
+	var cusErr *customerror.Error
+	if !errors.As(err, &cusErr) {
+		h.log(ctx, op, err, basehttphandler.CodeInternal)
+		return &fieldError{message: err.Error(), code: basehttphandler.CodeInternal, status: status, requestID: requestID}
+	}
+	if cusErr.Loggable {
+		h.log(ctx, op, err, cusErr.Code)
+	}
+	msg, _ := cusErr.Data.(string)
+	if msg == "" {
+		msg = cusErr.Message
+	}
+	return &fieldError{message: msg, code: cusErr.Code, status: status, requestID: requestID}
+}
+
+func (h *graphqlHandler) log(ctx context.Context, op string, err error, code string) {
+	if h.Logger != nil {
+		h.Logger.ErrorContext(ctx, op, "err", err.Error(), "code", code)
+	}
+}
@@ -0,0 +1,312 @@
+package graphqlhandler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+const (
+	// DefaultMaxDepth lets a query reach the parent of the parent of a
+	// task in a page: tasks > tasks > parent > parent > id.
+	DefaultMaxDepth = 5
+	// MaxIntrospectionDepth is how deeply introspection fields may nest,
+	// from the operation down. The introspection query of graphql-go, like
+	// those of most GraphQL clients, nests 13 fields deep.
+	MaxIntrospectionDepth = 15
+	// DefaultMaxComplexity lets a query read every field of a full page
+	// of tasks, but not their parents too.
+	DefaultMaxComplexity = 2000
+	// FetchCost is the cost of a field read with a call of its own to the
+	// service, like the parent of a task.
+	FetchCost = 20
+)
+
+// Codes of the errors of queries exceeding a limit.
+const (
+	errQueryDepth      = "query.too_deep"
+	errQueryComplexity = "query.too_complex"
+)
+
+// fieldCosts is the cost of the fields costing more than one, by type
+// and field name.
+var fieldCosts = map[string]int{
+	"Task.parent": FetchCost,
+}
+
+func limitError(code string, got, max int) gqlerrors.FormattedError {
+	what := "depth"
+	if code == errQueryComplexity {
+		what = "complexity"
+	}
+	return gqlerrors.FormattedError{
+		Message:    fmt.Sprintf("query %s %d exceeds the limit of %d", what, got, max),
+		Extensions: map[string]any{"code": code},
+	}
+}
+
+// Depth returns how deeply the selections of op nest, the fields of the
+// operation being at depth one. Introspection fields, which nest deeper
+// than the fields of tasks ever need to, are left to IntrospectionDepth.
+// doc need not be valid: a fragment spread within itself counts as empty.
+func Depth(doc *ast.Document, op *ast.OperationDefinition) int {
+	return newDepthWalker(doc).depth(op.SelectionSet, depthFields)
+}
+
+// IntrospectionDepth returns how deeply the selections of op nest on the
+// paths leading to an introspection field, every field of the path being
+// counted, and zero when op has no introspection field.
+func IntrospectionDepth(doc *ast.Document, op *ast.OperationDefinition) int {
+	return newDepthWalker(doc).depth(op.SelectionSet, depthIntrospection)
+}
+
+// The fields counted by a depthWalker.
+const (
+	// depthFields counts the paths without introspection fields.
+	depthFields = iota
+	// depthIntrospection counts the paths leading to an introspection
+	// field.
+	depthIntrospection
+	// depthAll counts every path.
+	depthAll
+)
+
+// depthWalker computes the depth of selections. The depth of every
+// fragment is computed once, so that fragments spreading others many times
+// cost no more than their size.
+type depthWalker struct {
+	frags map[string]*ast.FragmentDefinition
+	// memo is the depth of the fragments, by mode and name.
+	memo [depthAll + 1]map[string]int
+	// visiting is the set of fragments being walked, to cut cycles.
+	visiting map[string]bool
+}
+
+func newDepthWalker(doc *ast.Document) *depthWalker {
+	w := &depthWalker{frags: fragments(doc), visiting: make(map[string]bool)}
+	for i := range w.memo {
+		w.memo[i] = make(map[string]int)
+	}
+	return w
+}
+
+func (w *depthWalker) depth(set *ast.SelectionSet, mode int) int {
+	if set == nil {
+		return 0
+	}
+	max := 0
+	for _, sel := range set.Selections {
+		d := 0
+		switch sel := sel.(type) {
+		case *ast.Field:
+			switch {
+			case mode == depthAll:
+				d = 1 + w.depth(sel.SelectionSet, depthAll)
+			case !strings.HasPrefix(sel.Name.Value, "__"):
+				if sub := w.depth(sel.SelectionSet, mode); sub > 0 || mode == depthFields {
+					d = 1 + sub
+				}
+			case mode == depthIntrospection:
+				d = 1 + w.depth(sel.SelectionSet, depthAll)
+			}
+		case *ast.InlineFragment:
+			d = w.depth(sel.SelectionSet, mode)
+		case *ast.FragmentSpread:
+			d = w.fragment(sel.Name.Value, mode)
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+func (w *depthWalker) fragment(name string, mode int) int {
+	if d, ok := w.memo[mode][name]; ok {
+		return d
+	}
+	frag, ok := w.frags[name]
+	if !ok || w.visiting[name] {
+		return 0
+	}
+	w.visiting[name] = true
+	d := w.depth(frag.SelectionSet, mode)
+	delete(w.visiting, name)
+	w.memo[mode][name] = d
+	return d
+}
+
+// Complexity returns the cost of op: every field costs one, or its cost
+// in fieldCosts, plus the cost of its selections. The selections of a
+// field taking a limit are counted once per task of the page, the limit
+// being DefaultPageSize when it is not given. Introspection fields cost
+// one each, their selections included; the schema being small, their
+// lists are not counted per item. doc need not be valid: unknown fields
+// cost nothing, a fragment spread within itself counts as empty and costs
+// stop growing at MaxCost.
+func Complexity(schema graphql.Schema, doc *ast.Document, op *ast.OperationDefinition, vars map[string]any) int {
+	root := schema.QueryType()
+	if op.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+	c := costWalker{
+		frags:    fragments(doc),
+		vars:     vars,
+		memo:     make(map[string]int),
+		visiting: make(map[string]bool),
+	}
+	return c.selections(root, op.SelectionSet)
+}
+
+// MaxCost is the largest cost Complexity returns, well above any limit.
+// Two costs add up without overflowing an int of 32 bits.
+const MaxCost = math.MaxInt32 / 2
+
+// addCost returns a+b, or MaxCost when it is larger.
+func addCost(a, b int) int {
+	return min(a+b, MaxCost)
+}
+
+// mulCost returns a*b, or MaxCost when it is larger.
+func mulCost(a, b int) int {
+	if b != 0 && a > MaxCost/b {
+		return MaxCost
+	}
+	return a * b
+}
+
+// costWalker computes the cost of selections. The cost of every fragment
+// is computed once per type, so that fragments spreading others many times
+// cost no more than their size to walk.
+type costWalker struct {
+	frags map[string]*ast.FragmentDefinition
+	vars  map[string]any
+	// memo is the cost of the fragments, by type and name; the type of
+	// untyped walks is empty.
+	memo map[string]int
+	// visiting is the set of fragments being walked, to cut cycles.
+	visiting map[string]bool
+}
+
+// fragment returns the cost of the fragment name, computed by walk.
+func (c costWalker) fragment(typeName, name string, walk func(*ast.SelectionSet) int) int {
+	key := typeName + "." + name
+	if cost, ok := c.memo[key]; ok {
+		return cost
+	}
+	frag, ok := c.frags[name]
+	if !ok || c.visiting[key] {
+		return 0
+	}
+	c.visiting[key] = true
+	cost := walk(frag.SelectionSet)
+	delete(c.visiting, key)
+	c.memo[key] = cost
+	return cost
+}
+
+func (c costWalker) selections(t *graphql.Object, set *ast.SelectionSet) int {
+	if t == nil || set == nil {
+		return 0
+	}
+	total := 0
+	for _, sel := range set.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			total = addCost(total, c.field(t, sel))
+		case *ast.InlineFragment:
+			total = addCost(total, c.selections(t, sel.SelectionSet))
+		case *ast.FragmentSpread:
+			total = addCost(total, c.fragment(t.Name(), sel.Name.Value, func(set *ast.SelectionSet) int {
+				return c.selections(t, set)
+			}))
+		}
+	}
+	return total
+}
+
+func (c costWalker) field(t *graphql.Object, f *ast.Field) int {
+	if strings.HasPrefix(f.Name.Value, "__") {
+		return addCost(1, c.untyped(f.SelectionSet))
+	}
+	def, ok := t.Fields()[f.Name.Value]
+	if !ok {
+		return 0
+	}
+	cost, ok := fieldCosts[t.Name()+"."+f.Name.Value]
+	if !ok {
+		cost = 1
+	}
+	child, _ := graphql.GetNamed(def.Type).(*graphql.Object)
+	children := c.selections(child, f.SelectionSet)
+	for _, arg := range def.Args {
+		if arg.Name() == "limit" {
+			return addCost(cost, mulCost(children, c.limit(f)))
+		}
+	}
+	return addCost(cost, children)
+}
+
+// untyped returns the number of fields of set, those of its selections
+// included.
+func (c costWalker) untyped(set *ast.SelectionSet) int {
+	if set == nil {
+		return 0
+	}
+	total := 0
+	for _, sel := range set.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			total = addCost(total, addCost(1, c.untyped(sel.SelectionSet)))
+		case *ast.InlineFragment:
+			total = addCost(total, c.untyped(sel.SelectionSet))
+		case *ast.FragmentSpread:
+			total = addCost(total, c.fragment("", sel.Name.Value, c.untyped))
+		}
+	}
+	return total
+}
+
+// limit returns the page size asked for by the limit argument of f.
+func (c costWalker) limit(f *ast.Field) int {
+	limit := dto.DefaultPageSize
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil {
+				limit = n
+			}
+		case *ast.Variable:
+			switch n := c.vars[v.Name.Value].(type) {
+			case float64:
+				limit = int(n)
+			case int:
+				limit = n
+			}
+		}
+	}
+	if limit < 1 || limit > dto.MaxPageSize {
+		// Such a limit fails the query, which costs a full page at most.
+		limit = dto.MaxPageSize
+	}
+	return limit
+}
+
+func fragments(doc *ast.Document) map[string]*ast.FragmentDefinition {
+	frags := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok {
+			frags[frag.Name.Value] = frag
+		}
+	}
+	return frags
+}
@@ -0,0 +1,83 @@
+package graphqlhandler_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/testutil"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/graphql/graphqlhandler"
+)
+
+// allFields selects every scalar field of a task.
+const allFields = `id title description status priority dueAt assignee tags parentId overdue createdAt updatedAt createdBy updatedBy`
+
+// fragmentChain returns a query spreading n fragments, each of them
+// spreading the one before twice: it reads 2^n ids.
+func fragmentChain(n int) string {
+	var b strings.Builder
+	b.WriteString(`{task(id: 1){...f` + strconv.Itoa(n) + `}} fragment f0 on Task {id}`)
+	for i := 1; i <= n; i++ {
+		prev := strconv.Itoa(i - 1)
+		b.WriteString(` fragment f` + strconv.Itoa(i) + ` on Task {...f` + prev + ` ...f` + prev + `}`)
+	}
+	return b.String()
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		vars  map[string]any
+		opts  []graphqlhandler.GraphQLHandlerOption
+		code  string
+	}{
+		{"full page", `{tasks(limit: 100){tasks{` + allFields + `} total nextCursor}}`, nil, nil, ""},
+		{"parents of a page", `{tasks(limit: 20){tasks{id parent{id parent{id}}}}}`, nil, nil, ""},
+		{"too deep", `{tasks{tasks{parent{parent{parent{id}}}}}}`, nil, nil, "query.too_deep"},
+		{"too deep through fragments", `{task(id: 1){...a}} fragment a on Task {parent{parent{parent{parent{parent{id}}}}}}`, nil, nil, "query.too_deep"},
+		{"parents of a full page", `{tasks(limit: 100){tasks{id parent{id}}}}`, nil, nil, "query.too_complex"},
+		{"limit in a variable", `query($n: Int){tasks(limit: $n){tasks{id parent{id}}}}`, map[string]any{"n": 100}, nil, "query.too_complex"},
+		{"aliases add up", `{a: tasks(limit: 100){tasks{` + allFields + `}} b: tasks(limit: 100){tasks{` + allFields + `}}}`, nil, nil, "query.too_complex"},
+		{"lower depth", `{task(id: 1){parent{id}}}`, nil, []graphqlhandler.GraphQLHandlerOption{graphqlhandler.WithMaxDepth(2)}, "query.too_deep"},
+		{"lower complexity", `{task(id: 1){id title}}`, nil, []graphqlhandler.GraphQLHandlerOption{graphqlhandler.WithMaxComplexity(2)}, "query.too_complex"},
+		{"introspection", `{__schema{types{name fields{name type{name ofType{name ofType{name ofType{name}}}}}}}}`, nil, nil, ""},
+		{"introspection query", testutil.IntrospectionQuery, nil, nil, ""},
+		{"introspection too deep", `{__type(name: "Task"){fields{type{` + strings.Repeat("ofType{", 12) + `name` + strings.Repeat("}", 12) + `}}}}`, nil, nil, "query.too_deep"},
+		{"introspection too deep through fragments", `{__schema{types{...t}}} fragment t on __Type {fields{type{` + strings.Repeat("ofType{", 11) + `name` + strings.Repeat("}", 11) + `}}}`, nil, nil, "query.too_deep"},
+		{"introspection too complex", `{` + strings.Repeat(`__schema{types{name}} `, 2) + `}`, nil, []graphqlhandler.GraphQLHandlerOption{graphqlhandler.WithMaxComplexity(5)}, "query.too_complex"},
+		{"limits before validation", `{tasks{tasks{parent{parent{parent{name}}}}}}`, nil, nil, "query.too_deep"},
+		{"limits of every operation when none is named", `query a {task(id: 1){id}} query b {tasks{tasks{parent{parent{parent{id}}}}}}`, nil, nil, "query.too_deep"},
+		{"fragments spreading others many times", fragmentChain(40), nil, nil, "query.too_complex"},
+		{"introspection counts with tasks", `{task(id: 1){__typename id}}`, nil, []graphqlhandler.GraphQLHandlerOption{graphqlhandler.WithMaxComplexity(2)}, "query.too_complex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newHandler(&mockTaskWorker{
+				respond: func(job models.TaskJobModel) (any, error) {
+					if job.JOB == "LIST" {
+						return dto.TaskListResponse{Tasks: []dto.TaskResponse{taskResponse(1)}}, nil
+					}
+					return taskResponse(job.ID), nil
+				},
+			}, tt.opts...)
+
+			status, res := post(t, handler, tt.query, tt.vars)
+
+			if res.code() != tt.code {
+				t.Fatalf("wrong code, want %q got %+v", tt.code, res.Errors)
+			}
+			want := http.StatusOK
+			if tt.code != "" {
+				want = http.StatusBadRequest
+			}
+			if status != want {
+				t.Errorf("wrong status code, want %v got %v", want, status)
+			}
+		})
+	}
+}
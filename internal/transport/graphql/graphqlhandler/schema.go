@@ -0,0 +1,174 @@
+package graphqlhandler
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// newSchema returns the schema of the tasks, resolved by h.
+func newSchema(h *graphqlHandler) graphql.Schema {
+	var task *graphql.Object
+	task = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          taskField(graphql.NewNonNull(graphql.Int), func(t dto.TaskResponse) any { return t.ID }),
+				"title":       taskField(graphql.NewNonNull(graphql.String), func(t dto.TaskResponse) any { return t.Title }),
+				"description": taskField(graphql.NewNonNull(graphql.String), func(t dto.TaskResponse) any { return t.Description }),
+				"status":      taskField(graphql.NewNonNull(graphql.String), func(t dto.TaskResponse) any { return t.Status }),
+				"priority":    taskField(graphql.Int, func(t dto.TaskResponse) any { return nonZero(t.Priority) }),
+				"dueAt":       taskField(graphql.DateTime, func(t dto.TaskResponse) any { return t.DueAt }),
+				"assignee":    taskField(graphql.String, func(t dto.TaskResponse) any { return nonZero(t.Assignee) }),
+				"tags":        taskField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(t dto.TaskResponse) any { return nonNil(t.Tags) }),
+				"parentId":    taskField(graphql.Int, func(t dto.TaskResponse) any { return nonZero(t.ParentID) }),
+				"overdue":     taskField(graphql.NewNonNull(graphql.Boolean), func(t dto.TaskResponse) any { return t.Overdue }),
+				"createdAt":   taskField(graphql.NewNonNull(graphql.DateTime), func(t dto.TaskResponse) any { return t.CreatedAt }),
+				"updatedAt":   taskField(graphql.NewNonNull(graphql.DateTime), func(t dto.TaskResponse) any { return t.UpdatedAt }),
+				"createdBy":   taskField(graphql.NewNonNull(graphql.String), func(t dto.TaskResponse) any { return t.CreatedBy }),
+				"updatedBy":   taskField(graphql.NewNonNull(graphql.String), func(t dto.TaskResponse) any { return t.UpdatedBy }),
+				"parent": &graphql.Field{
+					Type:        task,
+					Description: "The parent of the task, read with a call of its own.",
+					Resolve:     h.parent,
+				},
+			}
+		}),
+	})
+	page := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TaskPage",
+		Fields: graphql.Fields{
+			"tasks": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(task)))},
+			"total": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"nextCursor": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The cursor of the next page, empty on the last one.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.TaskListResponse).NextCursor, nil
+				},
+			},
+		},
+	})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "TaskFilter",
+		Description: "Tasks matching every given filter.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"statuses":      {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"title":         {Type: graphql.String, Description: "Only tasks whose title contains this text."},
+			"description":   {Type: graphql.String, Description: "Only tasks whose description contains this text."},
+			"idFrom":        {Type: graphql.Int},
+			"idTo":          {Type: graphql.Int},
+			"priorities":    {Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+			"assignee":      {Type: graphql.String},
+			"dueAfter":      {Type: graphql.DateTime},
+			"dueBefore":     {Type: graphql.DateTime},
+			"tags":          {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"tagMode":       {Type: graphql.String, Description: "Whether tasks need any or all of the tags."},
+			"createdBy":     {Type: graphql.String},
+			"updatedBy":     {Type: graphql.String},
+			"createdAfter":  {Type: graphql.DateTime},
+			"createdBefore": {Type: graphql.DateTime},
+			"updatedAfter":  {Type: graphql.DateTime},
+			"updatedBefore": {Type: graphql.DateTime},
+		},
+	})
+	taskInput := func(name string, status graphql.Input) *graphql.InputObject {
+		return graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: name,
+			Fields: graphql.InputObjectConfigFieldMap{
+				"id":          {Type: graphql.NewNonNull(graphql.Int)},
+				"title":       {Type: graphql.NewNonNull(graphql.String)},
+				"description": {Type: graphql.NewNonNull(graphql.String)},
+				"status":      {Type: status},
+				"priority":    {Type: graphql.Int},
+				"dueAt":       {Type: graphql.DateTime},
+				"assignee":    {Type: graphql.String},
+			},
+		})
+	}
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"task": &graphql.Field{
+				Type:        task,
+				Description: "The task with the given ID.",
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: h.task,
+			},
+			"tasks": &graphql.Field{
+				Type:        graphql.NewNonNull(page),
+				Description: "One page of the tasks matching the filter.",
+				Args: graphql.FieldConfigArgument{
+					"filter": {Type: filter},
+					"sort":   {Type: graphql.String, Description: "One of id, created_at, updated_at, created_by, updated_by, priority, due_at."},
+					"order":  {Type: graphql.String, Description: "asc or desc."},
+					"limit":  {Type: graphql.Int, Description: "Page size, at most 100.", DefaultValue: dto.DefaultPageSize},
+					"cursor": {Type: graphql.String, Description: "nextCursor of the previous page."},
+				},
+				Resolve: h.tasks,
+			},
+		},
+	})
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTask": &graphql.Field{
+				Type:        graphql.NewNonNull(task),
+				Description: "Creates a task under the ID given by the client.",
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(taskInput("CreateTaskInput", graphql.String))},
+				},
+				Resolve: h.createTask,
+			},
+			"updateTask": &graphql.Field{
+				Type:        graphql.NewNonNull(task),
+				Description: "Replaces the fields of a task.",
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(taskInput("UpdateTaskInput", graphql.NewNonNull(graphql.String)))},
+				},
+				Resolve: h.updateTask,
+			},
+			"deleteTask": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Moves a task to the trash.",
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: h.deleteTask,
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+	if err != nil {
+		// The schema is fixed; an error is a bug found by any test.
+		panic(err)
+	}
+	return schema
+}
+
+// taskField returns a field of a task read by get.
+func taskField(t graphql.Output, get func(dto.TaskResponse) any) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(p.Source.(dto.TaskResponse)), nil
+		},
+	}
+}
+
+// nonZero returns v, nil when it is the zero value.
+func nonZero[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return v
+}
+
+func nonNil(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,47 @@
+package graphqlhandler
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func (h *graphqlHandler) task(p graphql.ResolveParams) (any, error) {
+	id := intArg(p.Args, "id")
+	if id <= 0 {
+		return nil, invalid("invalid id: must be a positive integer")
+	}
+	return h.get(p.Context, "graphqlhandler task service.Get", uint(id))
+}
+
+// parent resolves the parent of a task, null for a task without one.
+func (h *graphqlHandler) parent(p graphql.ResolveParams) (any, error) {
+	task := p.Source.(dto.TaskResponse)
+	if task.ParentID == 0 {
+		return nil, nil
+	}
+	return h.get(p.Context, "graphqlhandler parent service.Get", task.ParentID)
+}
+
+func (h *graphqlHandler) get(ctx context.Context, op string, id uint) (any, error) {
+	var (
+		req models.TaskJobModel
+	)
+	req.ID = id
+	req.JOB = "GET"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		return nil, h.fail(ctx, op, err)
+	}
+	task, ok := res.(dto.TaskResponse)
+	if !ok {
+		return nil, h.fail(ctx, op, customerror.ErrUnknown)
+	}
+	return task, nil
+}
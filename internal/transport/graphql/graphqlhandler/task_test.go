@@ -0,0 +1,120 @@
+package graphqlhandler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/graphql/graphqlhandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func taskResponse(id uint) dto.TaskResponse {
+	return dto.TaskResponse{
+		ID:          id,
+		Title:       "title",
+		Description: "description",
+		Status:      "todo",
+		CreatedAt:   time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:   time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestTask(t *testing.T) {
+	var job models.TaskJobModel
+	handler := newHandler(&mockTaskWorker{
+		response: taskResponse(7),
+		onSubmit: func(j models.TaskJobModel) { job = j },
+	})
+
+	status, res := post(t, handler, `{task(id:7){id title status priority tags createdAt}}`, nil)
+
+	if status != http.StatusOK || len(res.Errors) > 0 {
+		t.Fatalf("unexpected response %v %+v", status, res)
+	}
+	want := `{"createdAt":"2026-01-02T03:04:05Z","id":7,"priority":null,"status":"todo","tags":[],"title":"title"}`
+	if got := string(res.Data["task"]); got != want {
+		t.Errorf("wrong task, want %v got %v", want, got)
+	}
+	if job.JOB != "GET" || job.ID != 7 {
+		t.Errorf("wrong job, got %v %v", job.JOB, job.ID)
+	}
+}
+
+func TestTaskNotFound(t *testing.T) {
+	handler := newHandler(&mockTaskWorker{
+		submitErr: customerror.ErrIDNotFound.AddData("'7' does not exist in the database."),
+	})
+
+	status, res := post(t, handler, `{task(id:7){id}}`, nil)
+
+	if status != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, status)
+	}
+	if string(res.Data["task"]) != "null" {
+		t.Errorf("wrong task, want null got %s", res.Data["task"])
+	}
+	if res.code() != "task.not_found" || res.Errors[0].Message != "'7' does not exist in the database." {
+		t.Errorf("wrong errors, got %+v", res.Errors)
+	}
+	if res.Errors[0].Extensions["status"] != float64(http.StatusNotFound) {
+		t.Errorf("wrong status extension, got %v", res.Errors[0].Extensions["status"])
+	}
+}
+
+func TestTaskErrorRequestID(t *testing.T) {
+	handler := newHandler(&mockTaskWorker{submitErr: errors.New("boom")})
+	req := httptest.NewRequest(http.MethodPost, graphqlhandler.Path, strings.NewReader(`{"query":"{task(id:7){id}}"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(util.WithRequestID(req.Context(), "req-1"))
+	w := httptest.NewRecorder()
+
+	handler.GraphQL(w, req)
+
+	var res result
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || len(res.Errors) != 1 {
+		t.Fatalf("invalid response %s: %v", w.Body, err)
+	}
+	if res.code() != "internal.error" || res.Errors[0].Extensions["request_id"] != "req-1" {
+		t.Errorf("wrong extensions, got %+v", res.Errors[0].Extensions)
+	}
+}
+
+func TestTaskParent(t *testing.T) {
+	handler := newHandler(&mockTaskWorker{
+		respond: func(job models.TaskJobModel) (any, error) {
+			task := taskResponse(job.ID)
+			if job.ID > 1 {
+				task.ParentID = job.ID - 1
+			}
+			return task, nil
+		},
+	})
+
+	_, res := post(t, handler, `{task(id:3){id parent{id parent{id parent{id}}}}}`, nil)
+
+	if len(res.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", res.Errors)
+	}
+	var task struct {
+		Parent struct {
+			Parent struct {
+				ID     int             `json:"id"`
+				Parent json.RawMessage `json:"parent"`
+			} `json:"parent"`
+		} `json:"parent"`
+	}
+	if err := json.Unmarshal(res.Data["task"], &task); err != nil {
+		t.Fatal(err)
+	}
+	if task.Parent.Parent.ID != 1 || string(task.Parent.Parent.Parent) != "null" {
+		t.Errorf("wrong parents, got %s", res.Data["task"])
+	}
+}
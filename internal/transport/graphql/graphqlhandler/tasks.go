@@ -0,0 +1,63 @@
+package graphqlhandler
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+)
+
+func (h *graphqlHandler) tasks(p graphql.ResolveParams) (any, error) {
+	var (
+		req models.TaskJobModel
+	)
+	// @Step: Validate Request
+	limit := intArg(p.Args, "limit")
+	if limit < 1 || limit > dto.MaxPageSize {
+		return nil, invalid(fmt.Sprintf("invalid limit: must be between 1 and %d", dto.MaxPageSize))
+	}
+	filter, _ := p.Args["filter"].(map[string]any)
+	listReq := dto.ListTaskRequest{
+		Statuses:      stringsArg(filter, "statuses"),
+		Title:         stringArg(filter, "title"),
+		Description:   stringArg(filter, "description"),
+		IDFrom:        uint(max(intArg(filter, "idFrom"), 0)),
+		IDTo:          uint(max(intArg(filter, "idTo"), 0)),
+		Priorities:    intsArg(filter, "priorities"),
+		Assignee:      stringArg(filter, "assignee"),
+		DueAfter:      timeArg(filter, "dueAfter"),
+		DueBefore:     timeArg(filter, "dueBefore"),
+		Tags:          stringsArg(filter, "tags"),
+		TagMode:       stringArg(filter, "tagMode"),
+		CreatedBy:     stringArg(filter, "createdBy"),
+		UpdatedBy:     stringArg(filter, "updatedBy"),
+		CreatedAfter:  timeArg(filter, "createdAfter"),
+		CreatedBefore: timeArg(filter, "createdBefore"),
+		UpdatedAfter:  timeArg(filter, "updatedAfter"),
+		UpdatedBefore: timeArg(filter, "updatedBefore"),
+		SortBy:        stringArg(p.Args, "sort"),
+		SortOrder:     stringArg(p.Args, "order"),
+		Limit:         limit,
+		Cursor:        stringArg(p.Args, "cursor"),
+	}
+	if err := basehttphandler.NewValidator().Struct(listReq); err != nil {
+		return nil, invalid(basehttphandler.ValidationError(listReq, err).Error())
+	}
+	listReq.TaskJobMapper(&req)
+	req.JOB = "LIST"
+	req.Context = p.Context
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		return nil, h.fail(p.Context, "graphqlhandler tasks service.List", err)
+	}
+	page, ok := res.(dto.TaskListResponse)
+	if !ok {
+		return nil, h.fail(p.Context, "graphqlhandler tasks", customerror.ErrUnknown)
+	}
+	return page, nil
+}
@@ -0,0 +1,79 @@
+package graphqlhandler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+func TestTasks(t *testing.T) {
+	var job models.TaskJobModel
+	handler := newHandler(&mockTaskWorker{
+		response: dto.TaskListResponse{Tasks: []dto.TaskResponse{taskResponse(1), taskResponse(2)}, Total: 9, NextCursor: "next"},
+		onSubmit: func(j models.TaskJobModel) { job = j },
+	})
+	query := `query($limit: Int) {
+		tasks(filter: {statuses: ["todo"], tags: ["api"], tagMode: "all", priorities: [1, 2], dueAfter: "2026-01-01T00:00:00Z"}, sort: "priority", order: "desc", limit: $limit, cursor: "abc") {
+			tasks { id }
+			total
+			nextCursor
+		}
+	}`
+
+	status, res := post(t, handler, query, map[string]any{"limit": 10})
+
+	if status != http.StatusOK || len(res.Errors) > 0 {
+		t.Fatalf("unexpected response %v %+v", status, res)
+	}
+	want := `{"nextCursor":"next","tasks":[{"id":1},{"id":2}],"total":9}`
+	if got := string(res.Data["tasks"]); got != want {
+		t.Errorf("wrong page, want %v got %v", want, got)
+	}
+	f := job.Filter
+	if job.JOB != "LIST" || f.Limit != 10 || f.Cursor != "abc" || f.SortBy != "priority" || f.SortOrder != "desc" ||
+		f.TagMode != "all" || len(f.Tags) != 1 || len(f.Statuses) != 1 || len(f.Priorities) != 2 || f.DueAfter.IsZero() {
+		t.Errorf("wrong filter, got %+v", f)
+	}
+}
+
+func TestTasksDefaultLimit(t *testing.T) {
+	var job models.TaskJobModel
+	handler := newHandler(&mockTaskWorker{
+		response: dto.TaskListResponse{},
+		onSubmit: func(j models.TaskJobModel) { job = j },
+	})
+
+	_, res := post(t, handler, `{tasks{total}}`, nil)
+
+	if len(res.Errors) > 0 || job.Filter.Limit != dto.DefaultPageSize {
+		t.Errorf("wrong limit, want %v got %v: %+v", dto.DefaultPageSize, job.Filter.Limit, res.Errors)
+	}
+}
+
+func TestTasksInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		err   error
+		code  string
+	}{
+		{"limit", `{tasks(limit: 101){total}}`, nil, "request.invalid"},
+		{"sort", `{tasks(sort: "title"){total}}`, nil, "request.invalid"},
+		{"tag mode", `{tasks(filter: {tagMode: "some"}){total}}`, nil, "request.invalid"},
+		{"cursor", `{tasks(cursor: "x"){total}}`, customerror.ErrCursor, "list.invalid_cursor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newHandler(&mockTaskWorker{submitErr: tt.err, response: dto.TaskListResponse{}})
+
+			_, res := post(t, handler, tt.query, nil)
+
+			if res.code() != tt.code {
+				t.Errorf("wrong code, want %v got %+v", tt.code, res.Errors)
+			}
+		})
+	}
+}
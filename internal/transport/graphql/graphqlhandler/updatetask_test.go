@@ -0,0 +1,40 @@
+package graphqlhandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+)
+
+func TestUpdateTask(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+		code  string
+	}{
+		{"updated", `{id: 1, title: "title", description: "description", status: "done"}`, nil, ""},
+		{"invalid", `{id: 1, title: "title", description: "description", status: "done", priority: 9}`, nil, "request.invalid"},
+		{"illegal transition", `{id: 1, title: "title", description: "description", status: "done"}`, customerror.ErrTransition, "task.illegal_transition"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job models.TaskJobModel
+			handler := newHandler(&mockTaskWorker{
+				submitErr: tt.err,
+				response:  taskResponse(1),
+				onSubmit:  func(j models.TaskJobModel) { job = j },
+			})
+
+			_, res := post(t, handler, `mutation{updateTask(input: `+tt.input+`){id}}`, nil)
+
+			if res.code() != tt.code {
+				t.Fatalf("wrong code, want %v got %+v", tt.code, res.Errors)
+			}
+			if tt.code == "" && (job.JOB != "UPDATE" || job.Status != "done") {
+				t.Errorf("wrong job, got %+v", job)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package grpchandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// Authenticator checks a bearer token and returns the subject it was
+// issued to, which becomes the actor of the changes made by the call.
+type Authenticator func(token string) (subject string, err error)
+
+// UnaryAuthInterceptor rejects the unary calls lacking an
+// "authorization: Bearer <token>" entry accepted by auth.
+func UnaryAuthInterceptor(auth Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor is UnaryAuthInterceptor for streaming calls.
+func StreamAuthInterceptor(auth Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), auth)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream is a stream whose context was changed by an interceptor.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, auth Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return nil, unauthenticated("authorization metadata required")
+	}
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, unauthenticated("authorization metadata format must be Bearer {token}")
+	}
+	subject, err := auth(parts[1])
+	if err != nil {
+		return nil, unauthenticated("Invalid token")
+	}
+	return util.WithActor(ctx, subject), nil
+}
+
+func unauthenticated(msg string) error {
+	return newStatus(codes.Unauthenticated, basehttphandler.CodeUnauthorized, msg)
+}
@@ -0,0 +1,54 @@
+package grpchandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuth(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		code  codes.Code
+	}{
+		{"authorized", "Bearer " + testToken, codes.OK},
+		{"missing", "", codes.Unauthenticated},
+		{"not bearer", "Basic " + testToken, codes.Unauthenticated},
+		{"invalid token", "Bearer nope", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t,
+				grpchandler.WithPool(&mockTaskWorker{response: dto.TaskResponse{ID: 1}}),
+				grpchandler.WithEvents(events.NewBroker()),
+			)
+			ctx := context.Background()
+			if tt.value != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.value)
+			}
+
+			_, err := client.Get(ctx, &taskpb.GetTaskRequest{Id: 1})
+			if tt.code == codes.OK {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			checkStatus(t, err, tt.code, "request.unauthorized")
+
+			stream, err := client.Watch(ctx, &taskpb.WatchRequest{})
+			if err != nil {
+				t.Fatalf("watch failed: %v", err)
+			}
+			_, err = stream.Recv()
+			checkStatus(t, err, tt.code, "request.unauthorized")
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package grpchandler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/workerservice"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+)
+
+type grpcHandler struct {
+	taskpb.UnimplementedTaskServiceServer
+	service       taskservice.TaskService
+	pool          workerservice.TaskWorker
+	events        *events.Broker
+	cancelTimeout time.Duration
+	logger        *slog.Logger
+	validate      *validator.Validate
+}
+
+type GRPCHandlerOption func(*grpcHandler)
+
+func WithPool(pool workerservice.TaskWorker) GRPCHandlerOption {
+	return func(handler *grpcHandler) {
+		handler.pool = pool
+	}
+}
+
+func WithService(service taskservice.TaskService) GRPCHandlerOption {
+	return func(handler *grpcHandler) {
+		handler.service = service
+	}
+}
+
+// WithEvents sets the broker whose events are streamed by Watch.
+func WithEvents(broker *events.Broker) GRPCHandlerOption {
+	return func(handler *grpcHandler) {
+		handler.events = broker
+	}
+}
+
+// WithContextTimeout bounds the calls handed to the pool. They are only
+// bounded by the deadline of the client when it is not set.
+func WithContextTimeout(d time.Duration) GRPCHandlerOption {
+	return func(handler *grpcHandler) {
+		handler.cancelTimeout = d
+	}
+}
+
+func WithLogger(l *slog.Logger) GRPCHandlerOption {
+	return func(handler *grpcHandler) {
+		handler.logger = l
+	}
+}
+
+// New returns the gRPC task service. Its calls are served by the pool like
+// the ones of the HTTP handler.
+func New(opts ...GRPCHandlerOption) taskpb.TaskServiceServer {
+	handler := &grpcHandler{
+		logger:   slog.Default(),
+		validate: basehttphandler.NewValidator(),
+	}
+	for _, opt := range opts {
+		opt(handler)
+	}
+	return handler
+}
+
+// context returns the context of a call handed to the pool.
+func (h *grpcHandler) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if h.cancelTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, h.cancelTimeout)
+}
+
+// check validates req, reporting its problems as InvalidArgument.
+func (h *grpcHandler) check(req any) error {
+	if err := h.validate.Struct(req); err != nil {
+		return invalidArgument(basehttphandler.ValidationError(req, err).Error())
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package grpchandler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+const testToken = "token"
+
+type mockTaskWorker struct {
+	submitErr error
+	response  any
+	onSubmit  func(models.TaskJobModel)
+}
+
+func (m *mockTaskWorker) Submit(job models.TaskJobModel) (any, error) {
+	if m.onSubmit != nil {
+		m.onSubmit(job)
+	}
+	return m.response, m.submitErr
+}
+
+// authenticate accepts testToken, issued to "tester".
+func authenticate(token string) (string, error) {
+	if token != testToken {
+		return "", errors.New("invalid token")
+	}
+	return "tester", nil
+}
+
+// unlimited allows every call.
+type unlimited struct{}
+
+func (unlimited) Allow() bool {
+	return true
+}
+
+// dial serves the handler over an in-memory connection and returns a
+// client of it.
+func dial(t *testing.T, opts ...grpchandler.GRPCHandlerOption) taskpb.TaskServiceClient {
+	t.Helper()
+	return dialLimited(t, unlimited{}, opts...)
+}
+
+// dialLimited is dial with the calls limited by limiter.
+func dialLimited(t *testing.T, limiter grpchandler.Limiter, opts ...grpchandler.GRPCHandlerOption) taskpb.TaskServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpchandler.UnaryRequestIDInterceptor(), grpchandler.UnaryAuthInterceptor(authenticate), grpchandler.UnaryRateLimitInterceptor(limiter)),
+		grpc.ChainStreamInterceptor(grpchandler.StreamRequestIDInterceptor(), grpchandler.StreamAuthInterceptor(authenticate), grpchandler.StreamRateLimitInterceptor(limiter)),
+	)
+	taskpb.RegisterTaskServiceServer(server, grpchandler.New(append([]grpchandler.GRPCHandlerOption{grpchandler.WithLogger(logger)}, opts...)...))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return taskpb.NewTaskServiceClient(conn)
+}
+
+// authorized returns a context carrying testToken.
+func authorized() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken)
+}
+
+// checkStatus fails t unless err has code and, when reason is set, an
+// ErrorInfo detail with that reason.
+func checkStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, _ := status.FromError(err)
+	if st.Code() != code {
+		t.Fatalf("wrong code, want %v got %v: %v", code, st.Code(), err)
+	}
+	if reason == "" {
+		return
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetReason() == reason {
+			return
+		}
+	}
+	t.Errorf("missing reason %v in %v", reason, st.Details())
+}
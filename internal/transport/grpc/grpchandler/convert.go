@@ -0,0 +1,62 @@
+package grpchandler
+
+import (
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTask(t dto.TaskResponse) *taskpb.Task {
+	return &taskpb.Task{
+		Id:          uint64(t.ID),
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    int32(t.Priority),
+		DueAt:       timestampOrNil(t.DueAt),
+		Assignee:    t.Assignee,
+		Tags:        t.Tags,
+		ParentId:    uint64(t.ParentID),
+		Overdue:     t.Overdue,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+		CreatedBy:   t.CreatedBy,
+		UpdatedBy:   t.UpdatedBy,
+	}
+}
+
+func newTaskEvent(e events.Event) *taskpb.TaskEvent {
+	return &taskpb.TaskEvent{
+		Id:   e.ID,
+		Type: e.Type,
+		At:   timestamppb.New(e.At),
+		Task: newTask(e.Task),
+	}
+}
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// timeOrNil returns the time of ts, nil when it is not set.
+func timeOrNil(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// timeOrZero returns the time of ts, the zero time when it is not set.
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,50 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+)
+
+func (h *grpcHandler) Create(ctx context.Context, in *taskpb.CreateTaskRequest) (*taskpb.Task, error) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := h.context(ctx)
+	defer cancel()
+	// @Step: Validate Request
+	setReq := dto.SetTaskRequest{
+		ID:          uint(in.GetId()),
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		Status:      in.GetStatus(),
+		Priority:    int(in.GetPriority()),
+		DueAt:       timeOrNil(in.GetDueAt()),
+		Assignee:    in.GetAssignee(),
+	}
+	if err := h.check(setReq); err != nil {
+		return nil, err
+	}
+	setReq.TaskJobMapper(&req)
+	req.JOB = "SET"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		return nil, h.fail(ctx, "grpchandler Create service.Set", err)
+	}
+	return h.task(ctx, "grpchandler Create", res)
+}
+
+// task returns the task answered by the pool.
+func (h *grpcHandler) task(ctx context.Context, op string, res any) (*taskpb.Task, error) {
+	task, ok := res.(dto.TaskResponse)
+	if !ok {
+		return nil, h.fail(ctx, op, customerror.ErrUnknown)
+	}
+	return newTask(task), nil
+}
@@ -0,0 +1,53 @@
+package grpchandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *taskpb.CreateTaskRequest
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"created", &taskpb.CreateTaskRequest{Id: 1, Title: "title", Description: "description"}, nil, codes.OK, ""},
+		{"invalid", &taskpb.CreateTaskRequest{Id: 1, Title: "t"}, nil, codes.InvalidArgument, "request.invalid"},
+		{"exists", &taskpb.CreateTaskRequest{Id: 1, Title: "title", Description: "description"}, customerror.ErrIDExists.AddData("'1' already exists."), codes.AlreadyExists, "task.id_exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job models.TaskJobModel
+			client := dial(t, grpchandler.WithPool(&mockTaskWorker{
+				submitErr: tt.err,
+				response:  dto.TaskResponse{ID: 1, Title: "title"},
+				onSubmit:  func(j models.TaskJobModel) { job = j },
+			}))
+
+			task, err := client.Create(authorized(), tt.req)
+
+			if tt.code != codes.OK {
+				checkStatus(t, err, tt.code, tt.reason)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if task.GetId() != 1 || task.GetTitle() != "title" {
+				t.Errorf("wrong task, got %v", task)
+			}
+			if job.JOB != "SET" || job.ID != 1 || util.ActorFromContext(job.Context) != "tester" {
+				t.Errorf("wrong job, got %v %v %v", job.JOB, job.ID, util.ActorFromContext(job.Context))
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+)
+
+func (h *grpcHandler) Delete(ctx context.Context, in *taskpb.DeleteTaskRequest) (*taskpb.DeleteTaskResponse, error) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := h.context(ctx)
+	defer cancel()
+	if in.GetId() == 0 {
+		return nil, invalidArgument("invalid id: is required")
+	}
+	req.ID = uint(in.GetId())
+	req.JOB = "DELETE"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	if _, err := h.pool.Submit(req); err != nil {
+		return nil, h.fail(ctx, "grpchandler Delete service.Delete", err)
+	}
+	return &taskpb.DeleteTaskResponse{}, nil
+}
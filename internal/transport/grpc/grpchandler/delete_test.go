@@ -0,0 +1,39 @@
+package grpchandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+)
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     uint64
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"deleted", 3, nil, codes.OK, ""},
+		{"without id", 0, nil, codes.InvalidArgument, "request.invalid"},
+		{"not found", 3, customerror.ErrIDNotFound, codes.NotFound, "task.not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t, grpchandler.WithPool(&mockTaskWorker{submitErr: tt.err}))
+
+			_, err := client.Delete(authorized(), &taskpb.DeleteTaskRequest{Id: tt.id})
+
+			if tt.code != codes.OK {
+				checkStatus(t, err, tt.code, tt.reason)
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
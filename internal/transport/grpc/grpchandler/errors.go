@@ -0,0 +1,100 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail of failed calls.
+const ErrorDomain = "task-service"
+
+// statusCodes is the code reporting each customerror, by its code. Errors
+// missing here are reported with Internal.
+var statusCodes = map[string]codes.Code{
+	codeOf(customerror.ErrIDNotFound): codes.NotFound,
+	codeOf(customerror.ErrIDExists):   codes.AlreadyExists,
+	codeOf(customerror.ErrCursor):     codes.InvalidArgument,
+	codeOf(customerror.ErrStatus):     codes.InvalidArgument,
+	codeOf(customerror.ErrTransition): codes.FailedPrecondition,
+	codeOf(customerror.ErrTag):        codes.InvalidArgument,
+	codeOf(customerror.ErrCycle):      codes.FailedPrecondition,
+	codeOf(customerror.ErrBlocked):    codes.FailedPrecondition,
+	codeOf(customerror.ErrNotAuthor):  codes.PermissionDenied,
+	codeOf(customerror.ErrFileType):   codes.InvalidArgument,
+	codeOf(customerror.ErrFileSize):   codes.ResourceExhausted,
+	codeOf(customerror.ErrRolledBack): codes.Aborted,
+	codeOf(customerror.ErrPatch):      codes.InvalidArgument,
+	codeOf(customerror.ErrPatchTest):  codes.FailedPrecondition,
+	codeOf(customerror.ErrPatched):    codes.InvalidArgument,
+	codeOf(customerror.ErrDisabled):   codes.FailedPrecondition,
+	codeOf(customerror.ErrTarget):     codes.InvalidArgument,
+	codeOf(customerror.ErrDelivery):   codes.Unavailable,
+}
+
+func codeOf(err customerror.CustomError) string {
+	return err.(*customerror.Error).Code
+}
+
+// CodeOf returns the code reporting err, an error returned by the service.
+func CodeOf(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	}
+	var cusErr *customerror.Error
+	if errors.As(err, &cusErr) {
+		if code, ok := statusCodes[cusErr.Code]; ok {
+			return code
+		}
+	}
+	return codes.Internal
+}
+
+// newStatus returns the status of a failed call whose problem has the
+// code reason.
+func newStatus(code codes.Code, reason, msg string) error {
+	st := status.New(code, msg)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func invalidArgument(msg string) error {
+	return newStatus(codes.InvalidArgument, basehttphandler.CodeInvalidRequest, msg)
+}
+
+// fail returns the status reporting err, an error returned by the service
+// for op, and logs the ones the client cannot fix. The status of a
+// customerror carries its code as the reason and its data as the message.
+func (h *grpcHandler) fail(ctx context.Context, op string, err error) error {
+	code := CodeOf(err)
+	switch code {
+	case codes.DeadlineExceeded:
+		return newStatus(code, basehttphandler.CodeTimeout, constant.ErrContextDeadline)
+	case codes.Canceled:
+		return status.Error(code, err.Error())
+	}
+	var cusErr *customerror.Error
+	if !errors.As(err, &cusErr) {
+		h.logger.ErrorContext(ctx, op, "err", err.Error(), "code", basehttphandler.CodeInternal)
+		return newStatus(code, basehttphandler.CodeInternal, "internal error")
+	}
+	if cusErr.Loggable {
+		h.logger.ErrorContext(ctx, op, "err", err.Error(), "code", cusErr.Code)
+	}
+	detail, _ := cusErr.Data.(string)
+	if detail == "" {
+		detail = cusErr.Message
+	}
+	return newStatus(code, cusErr.Code, detail)
+}
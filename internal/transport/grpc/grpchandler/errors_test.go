@@ -0,0 +1,34 @@
+package grpchandler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("service.Get: %w", customerror.ErrIDNotFound.AddData("'1' does not exist in the database.")), codes.NotFound},
+		{customerror.ErrIDExists, codes.AlreadyExists},
+		{customerror.ErrBlocked, codes.FailedPrecondition},
+		{customerror.ErrNotAuthor, codes.PermissionDenied},
+		{customerror.ErrRolledBack, codes.Aborted},
+		{customerror.ErrSet, codes.Internal},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{context.Canceled, codes.Canceled},
+		{errors.New("boom"), codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := grpchandler.CodeOf(tt.err); got != tt.code {
+			t.Errorf("%v: wrong code, want %v got %v", tt.err, tt.code, got)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+)
+
+func (h *grpcHandler) Get(ctx context.Context, in *taskpb.GetTaskRequest) (*taskpb.Task, error) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := h.context(ctx)
+	defer cancel()
+	if in.GetId() == 0 {
+		return nil, invalidArgument("invalid id: is required")
+	}
+	req.ID = uint(in.GetId())
+	req.JOB = "GET"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		return nil, h.fail(ctx, "grpchandler Get service.Get", err)
+	}
+	return h.task(ctx, "grpchandler Get", res)
+}
@@ -0,0 +1,47 @@
+package grpchandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     uint64
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"found", 5, nil, codes.OK, ""},
+		{"without id", 0, nil, codes.InvalidArgument, "request.invalid"},
+		{"not found", 5, customerror.ErrIDNotFound.AddData("'5' does not exist in the database."), codes.NotFound, "task.not_found"},
+		{"storage failure", 5, customerror.ErrUnknown, codes.Internal, "internal.unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t, grpchandler.WithPool(&mockTaskWorker{
+				submitErr: tt.err,
+				response:  dto.TaskResponse{ID: 5, Tags: []string{"api"}},
+			}))
+
+			task, err := client.Get(authorized(), &taskpb.GetTaskRequest{Id: tt.id})
+
+			if tt.code != codes.OK {
+				checkStatus(t, err, tt.code, tt.reason)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if task.GetId() != 5 || len(task.GetTags()) != 1 {
+				t.Errorf("wrong task, got %v", task)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package grpchandler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+)
+
+func (h *grpcHandler) List(ctx context.Context, in *taskpb.ListTasksRequest) (*taskpb.ListTasksResponse, error) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := h.context(ctx)
+	defer cancel()
+	// @Step: Validate Request
+	if in.GetLimit() < 0 || in.GetLimit() > dto.MaxPageSize {
+		return nil, invalidArgument(fmt.Sprintf("invalid limit: must be between 1 and %d", dto.MaxPageSize))
+	}
+	listReq := dto.ListTaskRequest{
+		Statuses:      in.GetStatuses(),
+		Title:         in.GetTitle(),
+		Description:   in.GetDescription(),
+		IDFrom:        uint(in.GetIdFrom()),
+		IDTo:          uint(in.GetIdTo()),
+		Assignee:      in.GetAssignee(),
+		DueAfter:      timeOrZero(in.GetDueAfter()),
+		DueBefore:     timeOrZero(in.GetDueBefore()),
+		Tags:          in.GetTags(),
+		TagMode:       in.GetTagMode(),
+		CreatedBy:     in.GetCreatedBy(),
+		UpdatedBy:     in.GetUpdatedBy(),
+		CreatedAfter:  timeOrZero(in.GetCreatedAfter()),
+		CreatedBefore: timeOrZero(in.GetCreatedBefore()),
+		UpdatedAfter:  timeOrZero(in.GetUpdatedAfter()),
+		UpdatedBefore: timeOrZero(in.GetUpdatedBefore()),
+		SortBy:        in.GetSort(),
+		SortOrder:     in.GetOrder(),
+		Limit:         int(in.GetLimit()),
+		Cursor:        in.GetCursor(),
+	}
+	for _, p := range in.GetPriorities() {
+		listReq.Priorities = append(listReq.Priorities, int(p))
+	}
+	if err := h.check(listReq); err != nil {
+		return nil, err
+	}
+	listReq.TaskJobMapper(&req)
+	req.JOB = "LIST"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		return nil, h.fail(ctx, "grpchandler List service.List", err)
+	}
+	page, ok := res.(dto.TaskListResponse)
+	if !ok {
+		return nil, h.fail(ctx, "grpchandler List", customerror.ErrUnknown)
+	}
+	tasks := make([]*taskpb.Task, 0, len(page.Tasks))
+	for _, task := range page.Tasks {
+		tasks = append(tasks, newTask(task))
+	}
+	return &taskpb.ListTasksResponse{
+		Tasks:      tasks,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package grpchandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *taskpb.ListTasksRequest
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"listed", &taskpb.ListTasksRequest{Statuses: []string{"todo"}, Tags: []string{"api"}, TagMode: "all", Priorities: []int32{1, 2}, Limit: 10}, nil, codes.OK, ""},
+		{"invalid limit", &taskpb.ListTasksRequest{Limit: 101}, nil, codes.InvalidArgument, "request.invalid"},
+		{"invalid sort", &taskpb.ListTasksRequest{Sort: "title"}, nil, codes.InvalidArgument, "request.invalid"},
+		{"invalid cursor", &taskpb.ListTasksRequest{Cursor: "x"}, customerror.ErrCursor, codes.InvalidArgument, "list.invalid_cursor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job models.TaskJobModel
+			client := dial(t, grpchandler.WithPool(&mockTaskWorker{
+				submitErr: tt.err,
+				response:  dto.TaskListResponse{Tasks: []dto.TaskResponse{{ID: 1}, {ID: 2}}, Total: 7, NextCursor: "next"},
+				onSubmit:  func(j models.TaskJobModel) { job = j },
+			}))
+
+			page, err := client.List(authorized(), tt.req)
+
+			if tt.code != codes.OK {
+				checkStatus(t, err, tt.code, tt.reason)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(page.GetTasks()) != 2 || page.GetTotal() != 7 || page.GetNextCursor() != "next" {
+				t.Errorf("wrong page, got %v", page)
+			}
+			f := job.Filter
+			if job.JOB != "LIST" || f.Limit != 10 || f.TagMode != "all" || len(f.Tags) != 1 || len(f.Priorities) != 2 || len(f.Statuses) != 1 {
+				t.Errorf("wrong filter, got %+v", f)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package grpchandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Limiter tells whether one more call may be served now, like a
+// *rate.Limiter. Sharing it with the HTTP API puts the calls of both under
+// one limit.
+type Limiter interface {
+	Allow() bool
+}
+
+// UnaryRateLimitInterceptor rejects the unary calls made while limiter
+// allows none with ResourceExhausted, as the HTTP API answers 429.
+func UnaryRateLimitInterceptor(limiter Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !limiter.Allow() {
+			return nil, rateLimited()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor is UnaryRateLimitInterceptor for streaming
+// calls. A stream counts as one call, however long it is open.
+func StreamRateLimitInterceptor(limiter Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !limiter.Allow() {
+			return rateLimited()
+		}
+		return handler(srv, ss)
+	}
+}
+
+func rateLimited() error {
+	return newStatus(codes.ResourceExhausted, basehttphandler.CodeRateLimited, http.StatusText(http.StatusTooManyRequests))
+}
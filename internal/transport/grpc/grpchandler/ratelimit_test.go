@@ -0,0 +1,43 @@
+package grpchandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+)
+
+// budget allows as many calls as it holds.
+type budget int
+
+func (b *budget) Allow() bool {
+	if *b == 0 {
+		return false
+	}
+	*b--
+	return true
+}
+
+func TestRateLimit(t *testing.T) {
+	calls := budget(1)
+	client := dialLimited(t, &calls,
+		grpchandler.WithPool(&mockTaskWorker{response: dto.TaskResponse{ID: 1}}),
+		grpchandler.WithEvents(events.NewBroker()),
+	)
+
+	if _, err := client.Get(authorized(), &taskpb.GetTaskRequest{Id: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := client.Get(authorized(), &taskpb.GetTaskRequest{Id: 1})
+	checkStatus(t, err, codes.ResourceExhausted, "request.rate_limited")
+
+	stream, err := client.Watch(authorized(), &taskpb.WatchRequest{})
+	if err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	_, err = stream.Recv()
+	checkStatus(t, err, codes.ResourceExhausted, "request.rate_limited")
+}
@@ -0,0 +1,48 @@
+package grpchandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDKey is the metadata entry carrying the ID of a call, like the
+// X-Request-ID header of the HTTP API.
+var requestIDKey = strings.ToLower(util.RequestIDHeader)
+
+// UnaryRequestIDInterceptor gives every unary call an ID: the one of its
+// "x-request-id" metadata entry when it is a valid one, a new one
+// otherwise. The ID is carried in the context of the call and sent back in
+// its header metadata. It is meant to run before the other interceptors.
+func UnaryRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, id := requestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestIDInterceptor is UnaryRequestIDInterceptor for streaming
+// calls.
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := requestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(requestIDKey, id))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func requestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var id string
+	if values := md.Get(requestIDKey); len(values) > 0 {
+		id = values[0]
+	}
+	if !util.ValidRequestID(id) {
+		id = util.NewRequestID()
+	}
+	return util.WithRequestID(ctx, id), id
+}
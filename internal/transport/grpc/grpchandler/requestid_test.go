@@ -0,0 +1,68 @@
+package grpchandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"supplied", "req-1", "req-1"},
+		{"missing", "", ""},
+		{"invalid", "req 1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			client := dial(t, grpchandler.WithPool(&mockTaskWorker{
+				response: dto.TaskResponse{ID: 1},
+				onSubmit: func(job models.TaskJobModel) { got = util.RequestIDFromContext(job.Context) },
+			}))
+			ctx := authorized()
+			if tt.value != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", tt.value)
+			}
+
+			var header metadata.MD
+			if _, err := client.Get(ctx, &taskpb.GetTaskRequest{Id: 1}, grpc.Header(&header)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			echoed := header.Get("x-request-id")
+			if len(echoed) != 1 || echoed[0] != got {
+				t.Errorf("wrong echoed id, want %q got %v", got, echoed)
+			}
+			if tt.want != "" && got != tt.want {
+				t.Errorf("wrong request id, want %q got %q", tt.want, got)
+			}
+			if tt.want == "" && (got == "" || got == tt.value) {
+				t.Errorf("expected a new request id, got %q", got)
+			}
+		})
+	}
+}
+
+func TestRequestIDOfRejectedCall(t *testing.T) {
+	client := dial(t)
+
+	var header metadata.MD
+	_, err := client.Get(metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1"), &taskpb.GetTaskRequest{Id: 1}, grpc.Header(&header))
+	if err == nil {
+		t.Fatal("expected the call to be rejected")
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "req-1" {
+		t.Errorf("wrong echoed id, got %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+)
+
+func (h *grpcHandler) Update(ctx context.Context, in *taskpb.UpdateTaskRequest) (*taskpb.Task, error) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := h.context(ctx)
+	defer cancel()
+	// @Step: Validate Request
+	updateReq := dto.UpdateTaskRequest{
+		ID:          uint(in.GetId()),
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		Status:      in.GetStatus(),
+		Priority:    int(in.GetPriority()),
+		DueAt:       timeOrNil(in.GetDueAt()),
+		Assignee:    in.GetAssignee(),
+	}
+	if err := h.check(updateReq); err != nil {
+		return nil, err
+	}
+	updateReq.TaskJobMapper(&req)
+	req.JOB = "UPDATE"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		return nil, h.fail(ctx, "grpchandler Update service.Update", err)
+	}
+	return h.task(ctx, "grpchandler Update", res)
+}
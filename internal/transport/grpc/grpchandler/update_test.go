@@ -0,0 +1,54 @@
+package grpchandler_test
+
+import (
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUpdate(t *testing.T) {
+	req := &taskpb.UpdateTaskRequest{Id: 2, Title: "title", Description: "description", Status: "done", DueAt: timestamppb.Now()}
+	tests := []struct {
+		name   string
+		req    *taskpb.UpdateTaskRequest
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"updated", req, nil, codes.OK, ""},
+		{"invalid", &taskpb.UpdateTaskRequest{Id: 2}, nil, codes.InvalidArgument, "request.invalid"},
+		{"illegal transition", req, customerror.ErrTransition.AddData("'todo' cannot become 'done'."), codes.FailedPrecondition, "task.illegal_transition"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job models.TaskJobModel
+			client := dial(t, grpchandler.WithPool(&mockTaskWorker{
+				submitErr: tt.err,
+				response:  dto.TaskResponse{ID: 2, Status: "done"},
+				onSubmit:  func(j models.TaskJobModel) { job = j },
+			}))
+
+			task, err := client.Update(authorized(), tt.req)
+
+			if tt.code != codes.OK {
+				checkStatus(t, err, tt.code, tt.reason)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if task.GetStatus() != "done" {
+				t.Errorf("wrong task, got %v", task)
+			}
+			if job.JOB != "UPDATE" || job.Status != "done" || job.DueAt == nil {
+				t.Errorf("wrong job, got %+v", job)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package grpchandler
+
+import (
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EventReset is the type of the event telling a watcher that events were
+// missed since the last_event_id it asked for.
+const EventReset = "reset"
+
+// Watch streams the events of the broker matching the request. A watcher
+// too slow to keep up is dropped with Unavailable; it may watch again from
+// the last event it received.
+func (h *grpcHandler) Watch(in *taskpb.WatchRequest, stream taskpb.TaskService_WatchServer) error {
+	if h.events == nil {
+		return status.Error(codes.Unimplemented, "events are not configured")
+	}
+	filter := events.Filter{Statuses: in.GetStatuses()}
+	for _, id := range in.GetIds() {
+		if id == 0 {
+			return invalidArgument("invalid ids: must be positive integers")
+		}
+		filter.IDs = append(filter.IDs, uint(id))
+	}
+
+	// @Step: Stream Events
+	sub := h.events.Subscribe(filter, in.GetLastEventId())
+	defer sub.Close()
+	if sub.Missed {
+		if err := stream.Send(&taskpb.TaskEvent{Type: EventReset}); err != nil {
+			return err
+		}
+	}
+	for _, e := range sub.Replay {
+		if err := stream.Send(newTaskEvent(e)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case e, ok := <-sub.Events():
+			if !ok {
+				return status.Error(codes.Unavailable, "watcher fell behind, watch again from the last event")
+			}
+			if err := stream.Send(newTaskEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package grpchandler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/grpchandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/grpc/taskpb"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWatch(t *testing.T) {
+	broker := events.NewBroker()
+	client := dial(t, grpchandler.WithEvents(broker))
+	broker.Publish(events.Created, dto.TaskResponse{ID: 1, Status: "todo"})
+
+	stream, err := client.Watch(authorized(), &taskpb.WatchRequest{Ids: []uint64{2}})
+	if err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	// The subscription exists once the server received the request, which
+	// the client cannot tell; publish until the event arrives.
+	got := make(chan *taskpb.TaskEvent, 1)
+	go func() {
+		e, err := stream.Recv()
+		if err == nil {
+			got <- e
+		}
+		close(got)
+	}()
+	timeout := time.After(5 * time.Second)
+	for {
+		broker.Publish(events.Created, dto.TaskResponse{ID: 1})
+		broker.Publish(events.Updated, dto.TaskResponse{ID: 2, Status: "done"})
+		select {
+		case e, ok := <-got:
+			if !ok {
+				t.Fatal("stream ended")
+			}
+			if e.GetType() != events.Updated || e.GetTask().GetId() != 2 || e.GetTask().GetStatus() != "done" || e.GetId() == 0 {
+				t.Errorf("wrong event, got %v", e)
+			}
+			return
+		case <-timeout:
+			t.Fatal("no event received")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestWatchResume(t *testing.T) {
+	broker := events.NewBroker()
+	client := dial(t, grpchandler.WithEvents(broker))
+	for id := uint(1); id <= 3; id++ {
+		broker.Publish(events.Created, dto.TaskResponse{ID: id})
+	}
+
+	stream, err := client.Watch(authorized(), &taskpb.WatchRequest{LastEventId: 1})
+	if err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	for _, want := range []uint64{2, 3} {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("recv failed: %v", err)
+		}
+		if e.GetId() != want {
+			t.Errorf("wrong event, want %v got %v", want, e.GetId())
+		}
+	}
+}
+
+func TestWatchInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []grpchandler.GRPCHandlerOption
+		req  *taskpb.WatchRequest
+		code codes.Code
+	}{
+		{"zero id", []grpchandler.GRPCHandlerOption{grpchandler.WithEvents(events.NewBroker())}, &taskpb.WatchRequest{Ids: []uint64{0}}, codes.InvalidArgument},
+		{"no events", nil, &taskpb.WatchRequest{}, codes.Unimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dial(t, tt.opts...)
+			stream, err := client.Watch(authorized(), tt.req)
+			if err != nil {
+				t.Fatalf("watch failed: %v", err)
+			}
+			_, err = stream.Recv()
+			checkStatus(t, err, tt.code, "")
+		})
+	}
+}
@@ -0,0 +1,5 @@
+// Package taskpb holds the gRPC definition of the task service and the
+// code generated from it.
+package taskpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative task.proto
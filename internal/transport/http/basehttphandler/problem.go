@@ -0,0 +1,177 @@
+package basehttphandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// ProblemContentType is the media type of error responses.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypePrefix is joined with the code of a problem to form its type.
+const ProblemTypePrefix = "urn:task-service:problem:"
+
+// Codes of the problems found before a request reaches the service. The
+// problems of the service carry the code of their customerror.
+const (
+	CodeInvalidRequest       = "request.invalid"
+	CodeUnauthorized         = "request.unauthorized"
+	CodeNotFound             = "request.not_found"
+	CodeMethodNotAllowed     = "request.method_not_allowed"
+	CodeTooLarge             = "request.too_large"
+	CodeUnsupportedMediaType = "request.unsupported_media_type"
+	CodeRateLimited          = "request.rate_limited"
+	CodeTimeout              = "request.timeout"
+	CodeIdempotencyConflict  = "request.idempotency_in_progress"
+	CodeIdempotencyMismatch  = "request.idempotency_key_reused"
+	CodeInternal             = "internal.error"
+)
+
+// Problem is an error response as defined by RFC 7807. Code identifies the
+// problem like Type does, in a form easier to switch on.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// requestCodes are the codes of the problems reported by status alone.
+var requestCodes = map[int]string{
+	http.StatusBadRequest:            CodeInvalidRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusRequestEntityTooLarge: CodeTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusTooManyRequests:       CodeRateLimited,
+	http.StatusGatewayTimeout:        CodeTimeout,
+}
+
+// statuses is the status reporting each customerror, by its code. Errors
+// missing here are reported with 500.
+var statuses = map[string]int{
+	codeOf(customerror.ErrIDNotFound): http.StatusNotFound,
+	codeOf(customerror.ErrIDExists):   http.StatusConflict,
+	codeOf(customerror.ErrCursor):     http.StatusBadRequest,
+	codeOf(customerror.ErrStatus):     http.StatusBadRequest,
+	codeOf(customerror.ErrTransition): http.StatusConflict,
+	codeOf(customerror.ErrTag):        http.StatusBadRequest,
+	codeOf(customerror.ErrCycle):      http.StatusConflict,
+	codeOf(customerror.ErrBlocked):    http.StatusConflict,
+	codeOf(customerror.ErrNotAuthor):  http.StatusForbidden,
+	codeOf(customerror.ErrFileType):   http.StatusUnsupportedMediaType,
+	codeOf(customerror.ErrFileSize):   http.StatusRequestEntityTooLarge,
+	codeOf(customerror.ErrRolledBack): http.StatusFailedDependency,
+	codeOf(customerror.ErrPatch):      http.StatusBadRequest,
+	codeOf(customerror.ErrPatchTest):  http.StatusConflict,
+	codeOf(customerror.ErrPatched):    http.StatusUnprocessableEntity,
+	codeOf(customerror.ErrDisabled):   http.StatusConflict,
+	codeOf(customerror.ErrDelivery):   http.StatusBadGateway,
+	codeOf(customerror.ErrTarget):     http.StatusUnprocessableEntity,
+}
+
+// NewProblem returns the problem reporting status for r. An empty code is
+// the one of the status.
+func NewProblem(r *http.Request, status int, code, detail string) Problem {
+	if code == "" {
+		code = requestCodes[status]
+	}
+	if code == "" {
+		code = CodeInternal
+	}
+	return Problem{
+		Type:      ProblemTypePrefix + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: util.RequestIDFromContext(r.Context()),
+	}
+}
+
+// StatusOf returns the status reporting err, an error returned by the
+// service.
+func StatusOf(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	var cusErr *customerror.Error
+	if errors.As(err, &cusErr) {
+		if status, ok := statuses[cusErr.Code]; ok {
+			return status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrorProblem returns the problem reporting err, an error returned by the
+// service for r. The problem of a customerror takes its code, its message
+// as the title and its data as the detail. Other errors are told only by
+// their status, since they may carry the text of the database driver.
+// Loggable tells whether err is one the client cannot fix and the server
+// should log.
+func ErrorProblem(r *http.Request, err error) (p Problem, loggable bool) {
+	status := StatusOf(err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NewProblem(r, status, "", constant.ErrContextDeadline), false
+	}
+	var cusErr *customerror.Error
+	if !errors.As(err, &cusErr) {
+		return NewProblem(r, status, "", http.StatusText(status)), true
+	}
+	detail, _ := cusErr.Data.(string)
+	p = NewProblem(r, status, cusErr.Code, detail)
+	p.Title = cusErr.Message
+	return p, cusErr.Loggable
+}
+
+func codeOf(err customerror.CustomError) string {
+	return err.(*customerror.Error).Code
+}
+
+// WriteProblem writes p as the response.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	j, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(j)
+}
+
+// Fail answers r with status and a problem telling detail.
+func (h *Handler) Fail(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	WriteProblem(w, NewProblem(r, status, "", detail))
+}
+
+// MethodNotAllowed answers r with 405, naming the allowed methods in the
+// Allow header.
+func (h *Handler) MethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	h.Fail(w, r, http.StatusMethodNotAllowed, fmt.Sprintf(constant.ErrMethodNotAllowed, r.Method))
+}
+
+// Error answers r with the problem reporting err, an error returned by the
+// service, and logs it as op when the client cannot fix it.
+func (h *Handler) Error(w http.ResponseWriter, r *http.Request, op string, err error) {
+	p, loggable := ErrorProblem(r, err)
+	if loggable && h.Logger != nil {
+		h.Logger.ErrorContext(r.Context(), op, "err", err.Error(), "code", p.Code)
+	}
+	WriteProblem(w, p)
+}
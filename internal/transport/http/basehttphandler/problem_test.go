@@ -0,0 +1,74 @@
+package basehttphandler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestErrorProblem(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		status   int
+		code     string
+		title    string
+		loggable bool
+	}{
+		{"not found", customerror.ErrIDNotFound, http.StatusNotFound, "task.not_found", "ID not found", false},
+		{"exists", customerror.ErrIDExists, http.StatusConflict, "task.id_exists", "ID exists", false},
+		{"wrapped", fmt.Errorf("wrapped: %w", customerror.ErrTransition), http.StatusConflict, "task.illegal_transition", customerror.ErrTransition.Error(), false},
+		{"unmapped", customerror.ErrUnknown, http.StatusInternalServerError, "internal.unknown", customerror.ErrUnknown.Error(), true},
+		{"deadline", context.DeadlineExceeded, http.StatusGatewayTimeout, basehttphandler.CodeTimeout, "Gateway Timeout", false},
+		{"other", errors.New("boom"), http.StatusInternalServerError, basehttphandler.CodeInternal, "Internal Server Error", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/tasks/5", nil)
+			r = r.WithContext(util.WithRequestID(r.Context(), "req-1"))
+
+			p, loggable := basehttphandler.ErrorProblem(r, tt.err)
+
+			if p.Status != tt.status || basehttphandler.StatusOf(tt.err) != tt.status {
+				t.Errorf("wrong status, want %v got %v", tt.status, p.Status)
+			}
+			if p.Code != tt.code || p.Type != basehttphandler.ProblemTypePrefix+tt.code {
+				t.Errorf("wrong code, want %v got %v %v", tt.code, p.Code, p.Type)
+			}
+			if p.Title != tt.title {
+				t.Errorf("wrong title, want %v got %v", tt.title, p.Title)
+			}
+			if p.Instance != "/tasks/5" || p.RequestID != "req-1" {
+				t.Errorf("wrong instance or request id, got %v %v", p.Instance, p.RequestID)
+			}
+			if loggable != tt.loggable {
+				t.Errorf("wrong loggable, want %v got %v", tt.loggable, loggable)
+			}
+		})
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+	w := httptest.NewRecorder()
+
+	basehttphandler.WriteProblem(w, basehttphandler.NewProblem(r, http.StatusTooManyRequests, "", ""))
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("wrong status code, want %v got %v", http.StatusTooManyRequests, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != basehttphandler.ProblemContentType {
+		t.Errorf("wrong content type, want %v got %v", basehttphandler.ProblemContentType, got)
+	}
+	want := `{"type":"urn:task-service:problem:request.rate_limited","title":"Too Many Requests","status":429,"instance":"/tasks","code":"request.rate_limited"}`
+	if w.Body.String() != want {
+		t.Errorf("wrong body, want %v got %v", want, w.Body.String())
+	}
+}
@@ -0,0 +1,84 @@
+package basehttphandler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NewValidator returns a validator reporting fields by their json names.
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonName)
+	return validate
+}
+
+// ValidationError turns the errors reported by a validator created with
+// NewValidator for the struct v into one message a client can act on, e.g.
+// "invalid status[0]: must be at least 3 characters". Other errors are
+// returned unchanged.
+func ValidationError(v any, err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	messages := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		messages = append(messages, "invalid "+fe.Field()+": "+reason(t, fe))
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+func reason(t reflect.Type, fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " values"
+	}
+	switch fe.Tag() {
+	case "required", "required_unless":
+		return "is required"
+	case "min":
+		return "must be at least " + fe.Param() + unit
+	case "max":
+		return "must be at most " + fe.Param() + unit
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "gtfield":
+		return "must be after " + fieldName(t, fe.Param())
+	case "gtefield":
+		return "must not be less than " + fieldName(t, fe.Param())
+	}
+	return fmt.Sprintf("failed on the '%s' rule", fe.Tag())
+}
+
+func fieldName(t reflect.Type, name string) string {
+	if t.Kind() != reflect.Struct {
+		return name
+	}
+	f, ok := t.FieldByName(name)
+	if !ok {
+		return name
+	}
+	if n := jsonName(f); n != "" {
+		return n
+	}
+	return name
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,93 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// multipartOverhead is the room left in an upload body for the multipart
+// headers and boundaries around the file.
+const multipartOverhead = 1 << 20
+
+// @Tags Attachment
+// @Summary Attach File to Task.
+// @Description This endpoint is used for uploading a file as multipart/form-data in the "file" field. Files are limited to 10 MiB and their type is detected from the contents; images, plain text, PDF, zip and gzip files are accepted.
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param task_id query integer true "Task ID to attach the file to"
+// @Param file formData file true "File to attach"
+// @Success 200 {object} dto.AttachmentResponse "Success Response Body. The stored attachment."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters or body."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No task found with the specified ID."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The file is too large."
+// @Failure 415 {object} basehttphandler.Problem "Error Unsupported Media Type Response. The file type is not accepted."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/attachment [post]
+func (h *httpHandler) Attach(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	// @Step: Check Query Params
+	id, err := strconv.Atoi(r.URL.Query().Get("task_id"))
+	if err != nil || id <= 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	// @Step: Find File Part
+	r.Body = http.MaxBytesReader(w, r.Body, dto.MaxAttachmentSize+multipartOverhead)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, "multipart/form-data body required")
+		return
+	}
+	attachReq := dto.AttachRequest{
+		TaskID: uint(id),
+	}
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+		if part.FormName() == "file" {
+			attachReq.Name = part.FileName()
+			attachReq.Content = part
+			break
+		}
+	}
+	if attachReq.Content == nil {
+		h.Fail(w, r, http.StatusBadRequest, "file field required")
+		return
+	}
+	if err := basehttphandler.NewValidator().Struct(attachReq); err != nil {
+		h.Fail(w, r, http.StatusBadRequest, basehttphandler.ValidationError(attachReq, err).Error())
+		return
+	}
+	attachReq.TaskJobMapper(&req)
+	req.JOB = "ATTACH"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Attach service.Attach", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
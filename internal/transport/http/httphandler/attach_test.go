@@ -0,0 +1,136 @@
+package httphandler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func multipartBody(t *testing.T, field, name, content string) (io.Reader, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	if err := mw.WriteField("note", "ignored"); err != nil {
+		t.Fatal(err)
+	}
+	fw, err := mw.CreateFormFile(field, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(fw, content); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return body, mw.FormDataContentType()
+}
+
+func TestAttachInvalidTaskID(t *testing.T) {
+	handler := httphandler.New()
+	body, contentType := multipartBody(t, "file", "log.txt", "log")
+	req := httptest.NewRequest(http.MethodPost, "/attachment?task_id=abc", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+
+	handler.Attach(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAttachNotMultipart(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/attachment?task_id=1", strings.NewReader(`{"file":"log"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.Attach(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAttachMissingFile(t *testing.T) {
+	handler := httphandler.New()
+	body, contentType := multipartBody(t, "upload", "log.txt", "log")
+	req := httptest.NewRequest(http.MethodPost, "/attachment?task_id=1", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+
+	handler.Attach(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAttachErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"task not found", customerror.ErrIDNotFound, http.StatusNotFound},
+		{"file too large", customerror.ErrFileSize, http.StatusRequestEntityTooLarge},
+		{"unsupported file type", customerror.ErrFileType, http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New(
+				httphandler.WithPool(&mockTaskWorker{
+					submitErr: tt.err,
+				}),
+				httphandler.WithLogger(logger),
+			)
+			body, contentType := multipartBody(t, "file", "log.txt", "log")
+			req := httptest.NewRequest(http.MethodPost, "/attachment?task_id=1", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			handler.Attach(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("wrong status code, want %v got %v", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestAttachSuccess(t *testing.T) {
+	var name, content string
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.AttachmentResponse{ID: 3, TaskID: 1, Name: "log.txt"},
+			onSubmit: func(job models.TaskJobModel) {
+				b, _ := io.ReadAll(job.Upload.Content)
+				name, content = job.Upload.Name, string(b)
+			},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	body, contentType := multipartBody(t, "file", "log.txt", "line one")
+	req := httptest.NewRequest(http.MethodPost, "/attachment?task_id=1", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+
+	handler.Attach(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if name != "log.txt" || content != "line one" {
+		t.Errorf("wrong upload, want %q %q got %q %q", "log.txt", "line one", name, content)
+	}
+}
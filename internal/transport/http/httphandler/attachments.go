@@ -0,0 +1,65 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Attachment
+// @Summary List Attachments of Task.
+// @Description This endpoint is used for listing the files attached to a task, oldest first. Attachments of a task in the trash are not listed.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param task_id query integer true "Task ID to list the attachments of"
+// @Success 200 {array} dto.AttachmentResponse "Success Response Body. Files attached to the task."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No task found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/attachments [get]
+func (h *httpHandler) Attachments(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	// @Step: Check Query Params
+	if len(r.URL.Query()) == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters required")
+		return
+	}
+	_id := r.URL.Query().Get("task_id")
+	id, err := strconv.Atoi(_id)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	if id == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	req.ID = uint(id)
+	req.JOB = "ATTACHMENTS"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Attachments service.Attachments", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
@@ -0,0 +1,39 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestAttachmentsInvalidTaskID(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/attachments?task_id=abc", nil)
+	w := httptest.NewRecorder()
+
+	handler.Attachments(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAttachmentsSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: []dto.AttachmentResponse{{ID: 3, TaskID: 1}},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/attachments?task_id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Attachments(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+}
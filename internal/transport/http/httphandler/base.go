@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/workerservice"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
@@ -16,11 +17,49 @@ type HTTPHandler interface {
 	Update(http.ResponseWriter, *http.Request)
 	Delete(http.ResponseWriter, *http.Request)
 	List(http.ResponseWriter, *http.Request)
+	Search(http.ResponseWriter, *http.Request)
+	Trash(http.ResponseWriter, *http.Request)
+	Restore(http.ResponseWriter, *http.Request)
+	Purge(http.ResponseWriter, *http.Request)
+	History(http.ResponseWriter, *http.Request)
+	Snapshot(http.ResponseWriter, *http.Request)
+	Workflow(http.ResponseWriter, *http.Request)
+	Tag(http.ResponseWriter, *http.Request)
+	Untag(http.ResponseWriter, *http.Request)
+	Tags(http.ResponseWriter, *http.Request)
+	SetParent(http.ResponseWriter, *http.Request)
+	Block(http.ResponseWriter, *http.Request)
+	Unblock(http.ResponseWriter, *http.Request)
+	Graph(http.ResponseWriter, *http.Request)
+	AddComment(http.ResponseWriter, *http.Request)
+	EditComment(http.ResponseWriter, *http.Request)
+	DeleteComment(http.ResponseWriter, *http.Request)
+	Comments(http.ResponseWriter, *http.Request)
+	Attach(http.ResponseWriter, *http.Request)
+	Download(http.ResponseWriter, *http.Request)
+	Detach(http.ResponseWriter, *http.Request)
+	Attachments(http.ResponseWriter, *http.Request)
+	Bulk(http.ResponseWriter, *http.Request)
+	Export(http.ResponseWriter, *http.Request)
+	Import(http.ResponseWriter, *http.Request)
+	Patch(http.ResponseWriter, *http.Request)
+	Tasks(http.ResponseWriter, *http.Request)
+	Events(http.ResponseWriter, *http.Request)
+	Socket(http.ResponseWriter, *http.Request)
+	AddWebhook(http.ResponseWriter, *http.Request)
+	Webhooks(http.ResponseWriter, *http.Request)
+	DeleteWebhook(http.ResponseWriter, *http.Request)
+	EnableWebhook(http.ResponseWriter, *http.Request)
+	WebhookDeliveries(http.ResponseWriter, *http.Request)
 }
 
 type httpHandler struct {
 	service taskservice.TaskService
 	pool    workerservice.TaskWorker
+	events  *events.Broker
+	// origins are the origins of the pages allowed to open a socket
+	// besides the one of the server, "*" allowing any.
+	origins []string
 	basehttphandler.Handler
 }
 
@@ -38,6 +77,22 @@ func WithService(service taskservice.TaskService) StoreHandlerOption {
 	}
 }
 
+// WithEvents sets the broker whose events are streamed by Events.
+func WithEvents(broker *events.Broker) StoreHandlerOption {
+	return func(handler *httpHandler) {
+		handler.events = broker
+	}
+}
+
+// WithAllowedOrigins sets the origins of the pages allowed to open a
+// socket besides the one of the server, e.g. "https://app.example.com".
+// "*" allows any origin.
+func WithAllowedOrigins(origins ...string) StoreHandlerOption {
+	return func(handler *httpHandler) {
+		handler.origins = origins
+	}
+}
+
 func WithContextTimeout(d time.Duration) StoreHandlerOption {
 	return func(handler *httpHandler) {
 		handler.CancelTimeout = d
@@ -2,24 +2,29 @@ package httphandler_test
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
+	"net/http/httptest"
 	"os"
+	"testing"
 
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
-	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
 )
 
 var logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
 type mockTaskService struct {
-	baseRes   dto.TaskResponse
-	listRes   []dto.TaskResponse
-	deleteErr error
-	getErr    error
-	listErr   error
-	setErr    error
-	updateErr error
+	baseRes    dto.TaskResponse
+	listRes    dto.TaskListResponse
+	deleteErr  error
+	getErr     error
+	listErr    error
+	setErr     error
+	updateErr  error
+	restoreErr error
+	purgeErr   error
 }
 
 func (m *mockTaskService) Delete(context.Context, dto.DeleteTaskRequest) error {
@@ -30,7 +35,7 @@ func (m *mockTaskService) Get(context.Context, dto.GetTaskRequest) (dto.TaskResp
 	return m.baseRes, m.getErr
 }
 
-func (m *mockTaskService) List(context.Context, dto.ListTaskRequest) ([]dto.TaskResponse, error) {
+func (m *mockTaskService) List(context.Context, dto.ListTaskRequest) (dto.TaskListResponse, error) {
 	return m.listRes, m.listErr
 }
 
@@ -42,11 +47,161 @@ func (m *mockTaskService) Update(context.Context, dto.UpdateTaskRequest) (dto.Ta
 	return m.baseRes, m.updateErr
 }
 
+func (m *mockTaskService) Restore(context.Context, dto.RestoreTaskRequest) (dto.TaskResponse, error) {
+	return m.baseRes, m.restoreErr
+}
+
+func (m *mockTaskService) Purge(context.Context, dto.PurgeTaskRequest) error {
+	return m.purgeErr
+}
+
+func (m *mockTaskService) PurgeTrash(context.Context, dto.PurgeTrashRequest) (int64, error) {
+	return 0, m.purgeErr
+}
+
+func (m *mockTaskService) History(context.Context, dto.TaskHistoryRequest) ([]dto.TaskEventResponse, error) {
+	return []dto.TaskEventResponse{}, nil
+}
+
+func (m *mockTaskService) Snapshot(context.Context, dto.TaskSnapshotRequest) (dto.TaskResponse, error) {
+	return m.baseRes, nil
+}
+
 type mockTaskWorker struct {
 	submitErr error
-	response  util.ResponseData
+	response  any
+	onSubmit  func(models.TaskJobModel)
 }
 
-func (m *mockTaskWorker) Submit(models.TaskJobModel) (any, error) {
+func (m *mockTaskWorker) Submit(job models.TaskJobModel) (any, error) {
+	if m.onSubmit != nil {
+		m.onSubmit(job)
+	}
 	return m.response, m.submitErr
 }
+
+func (m *mockTaskService) Search(context.Context, dto.SearchTaskRequest) (dto.TaskSearchResponse, error) {
+	return dto.TaskSearchResponse{}, nil
+}
+
+func (m *mockTaskService) Workflow(context.Context) (dto.WorkflowResponse, error) {
+	return dto.WorkflowResponse{}, nil
+}
+
+func (m *mockTaskService) Tag(context.Context, dto.TagTaskRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, nil
+}
+
+func (m *mockTaskService) Untag(context.Context, dto.TagTaskRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, nil
+}
+
+func (m *mockTaskService) Tags(context.Context) ([]dto.TagCountResponse, error) {
+	return []dto.TagCountResponse{}, nil
+}
+
+func (m *mockTaskService) SetParent(context.Context, dto.SetParentRequest) (dto.TaskResponse, error) {
+	return dto.TaskResponse{}, nil
+}
+
+func (m *mockTaskService) Block(context.Context, dto.TaskDependencyRequest) (dto.TaskGraphResponse, error) {
+	return dto.TaskGraphResponse{}, nil
+}
+
+func (m *mockTaskService) Unblock(context.Context, dto.TaskDependencyRequest) (dto.TaskGraphResponse, error) {
+	return dto.TaskGraphResponse{}, nil
+}
+
+func (m *mockTaskService) Graph(context.Context, dto.TaskGraphRequest) (dto.TaskGraphResponse, error) {
+	return dto.TaskGraphResponse{}, nil
+}
+
+func (m *mockTaskService) AddComment(context.Context, dto.AddCommentRequest) (dto.CommentResponse, error) {
+	return dto.CommentResponse{}, nil
+}
+
+func (m *mockTaskService) EditComment(context.Context, dto.EditCommentRequest) (dto.CommentResponse, error) {
+	return dto.CommentResponse{}, nil
+}
+
+func (m *mockTaskService) DeleteComment(context.Context, dto.DeleteCommentRequest) error {
+	return nil
+}
+
+func (m *mockTaskService) Comments(context.Context, dto.ListCommentsRequest) (dto.CommentListResponse, error) {
+	return dto.CommentListResponse{}, nil
+}
+
+func (m *mockTaskService) Attach(context.Context, dto.AttachRequest) (dto.AttachmentResponse, error) {
+	return dto.AttachmentResponse{}, nil
+}
+
+func (m *mockTaskService) Download(context.Context, dto.GetAttachmentRequest) (dto.AttachmentDownload, error) {
+	return dto.AttachmentDownload{}, nil
+}
+
+func (m *mockTaskService) Detach(context.Context, dto.DeleteAttachmentRequest) error {
+	return nil
+}
+
+func (m *mockTaskService) Attachments(context.Context, dto.ListAttachmentsRequest) ([]dto.AttachmentResponse, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) Bulk(context.Context, dto.BulkRequest) (dto.BulkResponse, error) {
+	return dto.BulkResponse{}, nil
+}
+
+func (m *mockTaskService) Export(context.Context, dto.ExportTaskRequest) error {
+	return nil
+}
+
+func (m *mockTaskService) Patch(context.Context, dto.PatchTaskRequest) (dto.TaskResponse, error) {
+	return m.baseRes, m.updateErr
+}
+
+func (m *mockTaskService) AddWebhook(context.Context, dto.AddWebhookRequest) (dto.WebhookResponse, error) {
+	return dto.WebhookResponse{}, nil
+}
+
+func (m *mockTaskService) Webhooks(context.Context) ([]dto.WebhookResponse, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) DeleteWebhook(context.Context, dto.DeleteWebhookRequest) error {
+	return nil
+}
+
+func (m *mockTaskService) EnableWebhook(context.Context, dto.EnableWebhookRequest) (dto.WebhookResponse, error) {
+	return dto.WebhookResponse{}, nil
+}
+
+func (m *mockTaskService) WebhookDeliveries(context.Context, dto.ListWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, error) {
+	return nil, nil
+}
+
+func (m *mockTaskService) Deliver(context.Context, dto.DeliverWebhookRequest) (dto.WebhookDeliveryResponse, error) {
+	return dto.WebhookDeliveryResponse{}, nil
+}
+
+// assertProblem fails unless w holds a problem with status and code whose
+// title or detail is message.
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, status int, code, message string) {
+	t.Helper()
+	if w.Code != status {
+		t.Errorf("wrong status code, want %v got %v", status, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != basehttphandler.ProblemContentType {
+		t.Errorf("wrong content type, want %v got %v", basehttphandler.ProblemContentType, got)
+	}
+	var p basehttphandler.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("invalid problem %v: %v", w.Body.String(), err)
+	}
+	if p.Status != status || p.Code != code || p.Type != basehttphandler.ProblemTypePrefix+code {
+		t.Errorf("wrong problem, want status %v and code %v got %+v", status, code, p)
+	}
+	if p.Title != message && p.Detail != message {
+		t.Errorf("wrong problem message, want %v got %+v", message, p)
+	}
+}
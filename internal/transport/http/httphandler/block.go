@@ -0,0 +1,61 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Task
+// @Summary Add Task Dependency.
+// @Description This endpoint is used for recording that a task is blocked by another one. A task cannot move to a terminal status while any of its blockers is open.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TaskDependencyRequest true "Block Request Body. Take ID and the ID of the blocking task"
+// @Success 200 {object} dto.TaskGraphResponse "Success Response Body. The dependency graph of the task."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request body."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. One of the tasks does not exist."
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. The link would create a cycle."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/block [post]
+func (h *httpHandler) Block(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Validate Request
+	resp, err := basehttphandler.Validate[dto.TaskDependencyRequest](r)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp.(dto.TaskDependencyRequest).TaskJobMapper(&req)
+	req.JOB = "BLOCK"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Block service.Block", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
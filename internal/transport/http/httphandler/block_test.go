@@ -0,0 +1,102 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestBlockInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/block", nil)
+	w := httptest.NewRecorder()
+
+	handler.Block(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestBlockMissingBlocker(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/block", strings.NewReader(`{"id":1}`))
+	w := httptest.NewRecorder()
+
+	handler.Block(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestBlockErrCycle(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrCycle,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/block", strings.NewReader(`{"id":1,"blocker_id":2}`))
+	w := httptest.NewRecorder()
+
+	handler.Block(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("wrong status code, want %v got %v", http.StatusConflict, w.Code)
+	}
+}
+
+func TestBlockErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/block", strings.NewReader(`{"id":1,"blocker_id":2}`))
+	w := httptest.NewRecorder()
+
+	handler.Block(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestBlockSuccess(t *testing.T) {
+	resp := dto.TaskGraphResponse{
+		ID:           1,
+		Blocked:      true,
+		Tasks:        []dto.TaskResponse{{ID: 1}, {ID: 2}},
+		Dependencies: []dto.TaskDependencyResponse{{TaskID: 1, BlockerID: 2}},
+	}
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: resp,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/block", strings.NewReader(`{"id":1,"blocker_id":2}`))
+	w := httptest.NewRecorder()
+
+	handler.Block(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.Response(http.StatusOK, resp))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	}
+}
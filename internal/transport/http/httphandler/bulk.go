@@ -0,0 +1,123 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Task
+// @Summary Bulk Set, Update and Delete.
+// @Description This endpoint is used for applying up to 500 set, update and delete operations in order. Each operation takes the body of its single task endpoint plus "op". In atomic mode, the default, either every operation is applied or none is; in best_effort mode each operation that succeeds is kept. Every operation reports its own status; 207 is returned when any of them failed. Bodies are limited to 1 MiB.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key making retries safe. A retry with the same key and body replays the first response."
+// @Param request body dto.BulkRequest true "Bulk Request Body. Mode and operations to apply"
+// @Success 200 {object} dto.BulkResponse "Success Response Body. Every operation was applied."
+// @Success 207 {object} dto.BulkResponse "Multi-Status Response Body. Some operations failed; an atomic request applied none of them."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request body or operation."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body is too large."
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. A request with the same Idempotency-Key is in progress."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The Idempotency-Key was used for another request."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server Response"
+// @Router /task/bulk [post]
+func (h *httpHandler) Bulk(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Validate Request
+	r.Body = http.MaxBytesReader(w, r.Body, dto.MaxBulkSize)
+	bulkReq, status, err := validateBulk(r)
+	if err != nil {
+		h.Fail(w, r, status, err.Error())
+		return
+	}
+	bulkReq.TaskJobMapper(&req)
+	req.JOB = "BULK"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Bulk service.Bulk", err)
+		return
+	}
+	// @Step: Return Success Response
+	resp, _ := res.(dto.BulkResponse)
+	for i := range resp.Results {
+		h.bulkProblem(r, &resp.Results[i])
+	}
+	status = http.StatusOK
+	if resp.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	h.JSON(w,
+		status,
+		util.Response(status, resp),
+	)
+}
+
+// validateBulk decodes and validates a bulk request. Each operation is
+// validated against the request of its single task endpoint. The returned
+// status is the one to report the error with.
+func validateBulk(r *http.Request) (dto.BulkRequest, int, error) {
+	var req dto.BulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return req, http.StatusRequestEntityTooLarge, errors.New("request body too large")
+		}
+		if errors.Is(err, io.EOF) {
+			return req, http.StatusBadRequest, errors.New("request body is empty")
+		}
+		return req, http.StatusBadRequest, err
+	}
+	validate := basehttphandler.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return req, http.StatusBadRequest, basehttphandler.ValidationError(req, err)
+	}
+	for i, op := range req.Operations {
+		err := validate.Struct(op)
+		if err == nil {
+			err = validate.Struct(op.Request())
+		}
+		if err != nil {
+			return req, http.StatusBadRequest, fmt.Errorf("operations[%d]: %w", i, basehttphandler.ValidationError(op, err))
+		}
+	}
+	return req, http.StatusOK, nil
+}
+
+// bulkProblem sets the status and code of a bulk operation to the ones its
+// single task endpoint would have answered with, logging the failures
+// clients cannot fix.
+func (h *httpHandler) bulkProblem(r *http.Request, result *dto.BulkResult) {
+	if result.Err == nil {
+		result.Status = http.StatusOK
+		return
+	}
+	p, loggable := basehttphandler.ErrorProblem(r, result.Err)
+	if loggable {
+		h.Logger.ErrorContext(r.Context(), "httphandler Bulk service.Bulk", "op", result.Op, "index", result.Index, "err", result.Err.Error(), "code", p.Code)
+	}
+	result.Status, result.Code = p.Status, p.Code
+}
@@ -0,0 +1,213 @@
+package httphandler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+const bulkBody = `{"mode":"best_effort","operations":[{"op":"set","id":1,"title":"title","description":"description"},{"op":"delete","id":2}]}`
+
+func TestBulkInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/bulk", nil)
+	w := httptest.NewRecorder()
+
+	handler.Bulk(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestBulkInvalidBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		shouldContain string
+	}{
+		{"empty body", ``, "request body is empty"},
+		{"no operations", `{"operations":[]}`, "invalid operations: must be at least 1 values"},
+		{"unknown mode", `{"mode":"some","operations":[{"op":"delete","id":1}]}`, "invalid mode: must be one of atomic, best_effort"},
+		{"unknown op", `{"operations":[{"op":"purge","id":1}]}`, "operations[0]: invalid op: must be one of set, update, delete"},
+		{"invalid operation", `{"operations":[{"op":"delete","id":1},{"op":"set","id":2,"title":"ab","description":"description"}]}`, "operations[1]: invalid title: must be at least 3 characters"},
+		{"update without status", `{"operations":[{"op":"update","id":1,"title":"title","description":"description"}]}`, "operations[0]: invalid status: is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New()
+			req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.Bulk(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.shouldContain) {
+				t.Errorf("wrong body message, want %v got %v", tt.shouldContain, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBulkTooManyOperations(t *testing.T) {
+	handler := httphandler.New()
+	ops := make([]string, dto.MaxBulkOperations+1)
+	for i := range ops {
+		ops[i] = fmt.Sprintf(`{"op":"delete","id":%d}`, i+1)
+	}
+	body := `{"operations":[` + strings.Join(ops, ",") + `]}`
+	req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Bulk(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "invalid operations: must be at most 500 values"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestBulkBodyTooLarge(t *testing.T) {
+	handler := httphandler.New()
+	body := `{"operations":[{"op":"set","id":1,"title":"` + strings.Repeat("a", dto.MaxBulkSize) + `"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Bulk(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("wrong status code, want %v got %v", http.StatusRequestEntityTooLarge, w.Code)
+	}
+}
+
+func TestBulkWithTimeout(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*-1),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: context.DeadlineExceeded,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(bulkBody))
+	w := httptest.NewRecorder()
+
+	handler.Bulk(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("wrong status code, want %v got %v", http.StatusGatewayTimeout, w.Code)
+	}
+}
+
+func TestBulkErrInternal(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: errors.New("transaction could not be started"),
+		}),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(bulkBody))
+	w := httptest.NewRecorder()
+
+	handler.Bulk(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
+	}
+}
+
+func TestBulkSuccess(t *testing.T) {
+	var job models.TaskJobModel
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.BulkResponse{
+				Mode:      dto.BulkBestEffort,
+				Committed: true,
+				Succeeded: 2,
+				Results: []dto.BulkResult{
+					{Index: 0, Op: dto.BulkSet, ID: 1, Task: &dto.TaskResponse{ID: 1}},
+					{Index: 1, Op: dto.BulkDelete, ID: 2},
+				},
+			},
+			onSubmit: func(j models.TaskJobModel) { job = j },
+		}),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(bulkBody))
+	w := httptest.NewRecorder()
+
+	handler.Bulk(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if job.JOB != "BULK" || job.Mode != dto.BulkBestEffort || len(job.Operations) != 2 || job.Operations[1].Op != dto.BulkDelete {
+		t.Errorf("wrong job submitted, got %+v", job)
+	}
+	var body struct {
+		Data dto.BulkResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range body.Data.Results {
+		if result.Status != http.StatusOK {
+			t.Errorf("wrong operation status, want %v got %v", http.StatusOK, result.Status)
+		}
+	}
+}
+
+func TestBulkPartialFailure(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.BulkResponse{
+				Mode:   dto.BulkAtomic,
+				Failed: 3,
+				Results: []dto.BulkResult{
+					{Index: 0, Op: dto.BulkSet, ID: 1, Err: customerror.ErrRolledBack},
+					{Index: 1, Op: dto.BulkUpdate, ID: 2, Err: fmt.Errorf("service.Update: %w", customerror.ErrTransition)},
+					{Index: 2, Op: dto.BulkDelete, ID: 3, Err: customerror.ErrRolledBack},
+				},
+			},
+		}),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(bulkBody))
+	w := httptest.NewRecorder()
+
+	handler.Bulk(w, req)
+
+	if w.Code != http.StatusMultiStatus {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMultiStatus, w.Code)
+	}
+	var body struct {
+		Data dto.BulkResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := []int{http.StatusFailedDependency, http.StatusConflict, http.StatusFailedDependency}
+	for i, result := range body.Data.Results {
+		if result.Status != want[i] {
+			t.Errorf("wrong status of operation %d, want %v got %v", i, want[i], result.Status)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Comment
+// @Summary Add Comment to Task.
+// @Description This endpoint is used for commenting on a task. The author is the subject of the caller's token.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AddCommentRequest true "Comment Request Body. Take the task ID and the comment"
+// @Success 200 {object} dto.CommentResponse "Success Response Body. The stored comment."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request body."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No task found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/comment [post]
+func (h *httpHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Validate Request
+	resp, err := basehttphandler.Validate[dto.AddCommentRequest](r)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp.(dto.AddCommentRequest).TaskJobMapper(&req)
+	req.JOB = "COMMENT"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler AddComment service.AddComment", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
@@ -0,0 +1,70 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestAddCommentInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/comment", nil)
+	w := httptest.NewRecorder()
+
+	handler.AddComment(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestAddCommentEmptyBody(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/comment", strings.NewReader(`{"task_id":1,"body":""}`))
+	w := httptest.NewRecorder()
+
+	handler.AddComment(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAddCommentErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/comment", strings.NewReader(`{"task_id":1,"body":"looks good"}`))
+	w := httptest.NewRecorder()
+
+	handler.AddComment(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestAddCommentSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.CommentResponse{ID: 7, TaskID: 1, Body: "looks good"},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/comment", strings.NewReader(`{"task_id":1,"body":"looks good"}`))
+	w := httptest.NewRecorder()
+
+	handler.AddComment(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+}
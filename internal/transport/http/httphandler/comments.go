@@ -0,0 +1,83 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Comment
+// @Summary List Comments of Task.
+// @Description This endpoint is used for listing the comments of a task, oldest first. Comments of a task in the trash are not listed.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param 	task_id query integer true "Task ID to list the comments of"
+// @Param 	limit query integer false "Page size, at most 100" default(50)
+// @Param 	cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} util.PageResponseData{data=[]dto.CommentResponse} "Success Response Body. One page of the comments."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No task found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/comments [get]
+func (h *httpHandler) Comments(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	// @Step: Check Query Params
+	if len(r.URL.Query()) == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters required")
+		return
+	}
+	_id := r.URL.Query().Get("task_id")
+	id, err := strconv.Atoi(_id)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	if id == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	limit, err := parseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req.ID = uint(id)
+	req.Filter = models.TaskFilter{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+	req.JOB = "COMMENTS"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Comments service.Comments", err)
+		return
+	}
+	// @Step: Return Success Response
+	page, ok := res.(dto.CommentListResponse)
+	if !ok {
+		h.Error(w, r, "httphandler Comments", customerror.ErrUnknown)
+		return
+	}
+	h.JSON(w,
+		http.StatusOK,
+		util.PageResponse(http.StatusOK, page.Comments, page.Total, page.NextCursor),
+	)
+}
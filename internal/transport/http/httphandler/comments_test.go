@@ -0,0 +1,86 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestCommentsMissingTaskID(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/comments", nil)
+	w := httptest.NewRecorder()
+
+	handler.Comments(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCommentsInvalidLimit(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/comments?task_id=1&limit=1000", nil)
+	w := httptest.NewRecorder()
+
+	handler.Comments(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCommentsErrCursor(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrCursor,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/comments?task_id=1&cursor=abc", nil)
+	w := httptest.NewRecorder()
+
+	handler.Comments(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCommentsErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/comments?task_id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Comments(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestCommentsSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.CommentListResponse{Comments: []dto.CommentResponse{{ID: 7}}, Total: 1},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/comments?task_id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Comments(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+}
@@ -2,12 +2,9 @@ package httphandler
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 
-	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
 	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
@@ -19,12 +16,16 @@ import (
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Key making retries safe. A retry with the same key and body replays the first response."
 // @Param id query integer true "Task ID required to delete"
 // @Success 200 {object} string "Success Response Body Delete Successfully."
-// @Failure 400 {object} util.ErrorResponse "Bad Request Response. Invalid request parameters."
-// @Failure 404 {object} util.ErrorResponse "Not Found Response. No task found with the specified ID."
-// @Failure 500 {object} util.ErrorResponse "Internal Server Error. Server encountered an error."
-// @Router /delete [delete]
+// @Failure 400 {object} basehttphandler.Problem "Bad Request Response. Invalid request parameters."
+// @Failure 404 {object} basehttphandler.Problem "Not Found Response. No task found with the specified ID."
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. A request with the same Idempotency-Key is in progress."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The Idempotency-Key was used for another request."
+// @Failure 500 {object} basehttphandler.Problem "Internal Server Error. Server encountered an error."
+// @Deprecated
+// @Router /task/delete [delete]
 func (h *httpHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	var (
 		req models.TaskJobModel
@@ -32,35 +33,22 @@ func (h *httpHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
 	defer cancel()
 	if r.Method != http.MethodDelete {
-		h.JSON(
-			w,
-			http.StatusMethodNotAllowed,
-			fmt.Sprintf(constant.ErrMethodNotAllowed, r.Method),
-		)
+		h.MethodNotAllowed(w, r, http.MethodDelete)
 		return
 	}
 	// @Step: Check Query Params
 	if len(r.URL.Query()) == 0 {
-		h.JSON(w,
-			http.StatusBadRequest,
-			util.BasicError("query parameters required", http.StatusBadRequest),
-		)
+		h.Fail(w, r, http.StatusBadRequest, "query parameters required")
 		return
 	}
 	_id := r.URL.Query().Get("id")
 	id, err := strconv.Atoi(_id)
 	if err != nil {
-		h.JSON(w,
-			http.StatusBadRequest,
-			util.BasicError("invalid query parameters", http.StatusBadRequest),
-		)
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
 		return
 	}
 	if id == 0 {
-		h.JSON(w,
-			http.StatusBadRequest,
-			util.BasicError("invalid query parameters", http.StatusBadRequest),
-		)
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
 		return
 	}
 
@@ -70,45 +58,7 @@ func (h *httpHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	// @Step: Submit to Pool
 	if _, err = h.pool.Submit(req); err != nil {
-		// @Step: Handle Errors
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.JSON(w,
-				http.StatusGatewayTimeout,
-				util.BasicError(constant.ErrContextDeadline, http.StatusGatewayTimeout),
-			)
-			return
-		}
-		var cusErr *customerror.Error
-		if errors.As(err, &cusErr) {
-			clientMessage := cusErr.Message
-			if cusErr.Data != nil {
-				data, ok := cusErr.Data.(string)
-				if ok {
-					clientMessage = clientMessage + ", " + data
-				}
-			}
-			if cusErr.Loggable {
-				h.Logger.Error("httphandler Delete service.Delete", "err", clientMessage)
-			}
-			if cusErr == customerror.ErrIDNotFound {
-				h.JSON(w,
-					http.StatusNotFound,
-					util.BasicError(clientMessage, http.StatusNotFound),
-				)
-				return
-			}
-			if cusErr == customerror.ErrDelete {
-				h.JSON(w,
-					http.StatusNotFound,
-					util.BasicError(clientMessage, http.StatusNotFound),
-				)
-				return
-			}
-		}
-		h.JSON(w,
-			http.StatusInternalServerError,
-			util.BasicError(err.Error(), http.StatusInternalServerError),
-		)
+		h.Error(w, r, "httphandler Delete service.Delete", err)
 		return
 	}
 	// @Step: Return Success Response
@@ -139,13 +139,7 @@ func TestDeleteErrUnknown(t *testing.T) {
 	if w.Code != http.StatusInternalServerError {
 		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrUnknown.Error(), http.StatusInternalServerError))
-	if err != nil {
-		t.Errorf("error while response casting error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
-	}
+	assertProblem(t, w, http.StatusInternalServerError, "internal.unknown", customerror.ErrUnknown.Error())
 }
 
 func TestDeleteErrIDNotFound(t *testing.T) {
@@ -164,13 +158,7 @@ func TestDeleteErrIDNotFound(t *testing.T) {
 	if w.Code != http.StatusNotFound {
 		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrIDNotFound.Error(), http.StatusNotFound))
-	if err != nil {
-		t.Errorf("error while response casting error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
-	}
+	assertProblem(t, w, http.StatusNotFound, "task.not_found", customerror.ErrIDNotFound.Error())
 }
 
 func TestDeleteSuccess(t *testing.T) {
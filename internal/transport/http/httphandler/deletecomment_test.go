@@ -0,0 +1,56 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestDeleteCommentInvalidID(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodDelete, "/comment/delete?id=abc", nil)
+	w := httptest.NewRecorder()
+
+	handler.DeleteComment(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestDeleteCommentErrNotAuthor(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrNotAuthor,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodDelete, "/comment/delete?id=7", nil)
+	w := httptest.NewRecorder()
+
+	handler.DeleteComment(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("wrong status code, want %v got %v", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestDeleteCommentSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: nil,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodDelete, "/comment/delete?id=7", nil)
+	w := httptest.NewRecorder()
+
+	handler.DeleteComment(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+}
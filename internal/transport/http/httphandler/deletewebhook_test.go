@@ -0,0 +1,56 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestDeleteWebhookInvalidID(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodDelete, "/webhook/delete?id=abc", nil)
+	w := httptest.NewRecorder()
+
+	handler.DeleteWebhook(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestDeleteWebhookErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodDelete, "/webhook/delete?id=4", nil)
+	w := httptest.NewRecorder()
+
+	handler.DeleteWebhook(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestDeleteWebhookSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: nil,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodDelete, "/webhook/delete?id=4", nil)
+	w := httptest.NewRecorder()
+
+	handler.DeleteWebhook(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+}
@@ -0,0 +1,74 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Webhook
+// @Summary List Deliveries of Webhook.
+// @Description This endpoint is used for listing the latest delivery attempts of a webhook, newest first, with the status code of the response or the error that failed them.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param 	id query integer true "Webhook ID to list the deliveries of"
+// @Param 	limit query integer false "Number of deliveries, at most 100" default(50)
+// @Success 200 {object} []dto.WebhookDeliveryResponse "Success Response Body. The latest deliveries."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No webhook found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/webhook/deliveries [get]
+func (h *httpHandler) WebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	// @Step: Check Query Params
+	if len(r.URL.Query()) == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters required")
+		return
+	}
+	_id := r.URL.Query().Get("id")
+	id, err := strconv.Atoi(_id)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	if id == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	limit, err := parseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req.ID = uint(id)
+	req.Filter = models.TaskFilter{
+		Limit: limit,
+	}
+	req.JOB = "WEBHOOK_DELIVERIES"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler WebhookDeliveries service.WebhookDeliveries", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
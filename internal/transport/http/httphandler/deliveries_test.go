@@ -0,0 +1,76 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestWebhookDeliveriesInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		status int
+	}{
+		{"method", http.MethodPost, "/webhook/deliveries?id=4", http.StatusMethodNotAllowed},
+		{"missing id", http.MethodGet, "/webhook/deliveries", http.StatusBadRequest},
+		{"invalid id", http.MethodGet, "/webhook/deliveries?id=abc", http.StatusBadRequest},
+		{"invalid limit", http.MethodGet, "/webhook/deliveries?id=4&limit=1000", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New()
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			handler.WebhookDeliveries(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("wrong status code, want %v got %v", tt.status, w.Code)
+			}
+		})
+	}
+}
+
+func TestWebhookDeliveriesErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/webhook/deliveries?id=4", nil)
+	w := httptest.NewRecorder()
+
+	handler.WebhookDeliveries(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestWebhookDeliveriesSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: []dto.WebhookDeliveryResponse{{ID: 2, WebhookID: 4, Attempt: 2, StatusCode: 200, Success: true}},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/webhook/deliveries?id=4&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	handler.WebhookDeliveries(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"status_code":200`) {
+		t.Errorf("wrong body, got %v", w.Body.String())
+	}
+}
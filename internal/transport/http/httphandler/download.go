@@ -0,0 +1,69 @@
+package httphandler
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// @Tags Attachment
+// @Summary Download Attachment by ID.
+// @Description This endpoint is used for streaming the contents of an attachment with its detected content type.
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id query integer true "Attachment ID to download"
+// @Success 200 {file} file "Success Response Body. The contents of the attachment."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No attachment found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/attachment/download [get]
+func (h *httpHandler) Download(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	// @Step: Check Query Params
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil || id <= 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	req.ID = uint(id)
+	req.JOB = "DOWNLOAD"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Download service.Download", err)
+		return
+	}
+	download, ok := res.(dto.AttachmentDownload)
+	if !ok || download.Content == nil {
+		h.Error(w, r, "httphandler Download", customerror.ErrUnknown)
+		return
+	}
+	defer download.Content.Close()
+	// @Step: Stream Contents
+	header := w.Header()
+	header.Set("Content-Type", download.Attachment.ContentType)
+	header.Set("Content-Length", strconv.FormatInt(download.Attachment.Size, 10))
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": download.Attachment.Name}))
+	header.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, download.Content); err != nil {
+		h.Logger.ErrorContext(r.Context(), "httphandler Download io.Copy", "err", err)
+	}
+}
@@ -0,0 +1,71 @@
+package httphandler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestDownloadInvalidID(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/attachment/download?id=0", nil)
+	w := httptest.NewRecorder()
+
+	handler.Download(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestDownloadErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/attachment/download?id=3", nil)
+	w := httptest.NewRecorder()
+
+	handler.Download(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestDownloadSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.AttachmentDownload{
+				Attachment: dto.AttachmentResponse{ID: 3, Name: "shot one.png", ContentType: "image/png", Size: 4},
+				Content:    io.NopCloser(strings.NewReader("\x89PNG")),
+			},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/attachment/download?id=3", nil)
+	w := httptest.NewRecorder()
+
+	handler.Download(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("wrong content type, want %v got %v", "image/png", got)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="shot one.png"` {
+		t.Errorf("wrong content disposition, got %v", got)
+	}
+	if w.Body.String() != "\x89PNG" {
+		t.Errorf("wrong body, got %q", w.Body.String())
+	}
+}
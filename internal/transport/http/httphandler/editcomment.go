@@ -0,0 +1,61 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Comment
+// @Summary Edit Comment.
+// @Description This endpoint is used for replacing the body of a comment. Only the author of a comment may edit it.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.EditCommentRequest true "Edit Comment Request Body. Take the comment ID and the new comment"
+// @Success 200 {object} dto.CommentResponse "Success Response Body. The edited comment."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request body."
+// @Failure 403 {object} basehttphandler.Problem "Error Forbidden Response. The comment was written by someone else."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No comment found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/comment/edit [put]
+func (h *httpHandler) EditComment(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPut {
+		h.MethodNotAllowed(w, r, http.MethodPut)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Validate Request
+	resp, err := basehttphandler.Validate[dto.EditCommentRequest](r)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp.(dto.EditCommentRequest).TaskJobMapper(&req)
+	req.JOB = "EDIT_COMMENT"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler EditComment service.EditComment", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
@@ -0,0 +1,57 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestEditCommentInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/comment/edit", nil)
+	w := httptest.NewRecorder()
+
+	handler.EditComment(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestEditCommentErrNotAuthor(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrNotAuthor,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPut, "/comment/edit", strings.NewReader(`{"id":7,"body":"looks bad"}`))
+	w := httptest.NewRecorder()
+
+	handler.EditComment(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("wrong status code, want %v got %v", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestEditCommentErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPut, "/comment/edit", strings.NewReader(`{"id":7,"body":"looks bad"}`))
+	w := httptest.NewRecorder()
+
+	handler.EditComment(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,65 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Webhook
+// @Summary Enable Webhook by ID.
+// @Description This endpoint is used for enabling a webhook again after it was disabled for failing too many times in a row. Its failures are cleared; the events told while it was disabled are not sent.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id query integer true "Webhook ID required to enable"
+// @Success 200 {object} dto.WebhookResponse "Success Response Body. The enabled webhook."
+// @Failure 400 {object} basehttphandler.Problem "Bad Request Response. Invalid request parameters."
+// @Failure 404 {object} basehttphandler.Problem "Not Found Response. No webhook found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Internal Server Error. Server encountered an error."
+// @Router /task/webhook/enable [post]
+func (h *httpHandler) EnableWebhook(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	// @Step: Check Query Params
+	if len(r.URL.Query()) == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters required")
+		return
+	}
+	_id := r.URL.Query().Get("id")
+	id, err := strconv.Atoi(_id)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	if id == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	req.ID = uint(id)
+	req.JOB = "ENABLE_WEBHOOK"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler EnableWebhook service.EnableWebhook", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
@@ -0,0 +1,57 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestEnableWebhookInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		status int
+	}{
+		{"method", http.MethodGet, "/webhook/enable?id=4", http.StatusMethodNotAllowed},
+		{"missing id", http.MethodPost, "/webhook/enable", http.StatusBadRequest},
+		{"invalid id", http.MethodPost, "/webhook/enable?id=0", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New()
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			handler.EnableWebhook(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("wrong status code, want %v got %v", tt.status, w.Code)
+			}
+		})
+	}
+}
+
+func TestEnableWebhookSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.WebhookResponse{ID: 4, Enabled: true},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/webhook/enable?id=4", nil)
+	w := httptest.NewRecorder()
+
+	handler.EnableWebhook(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"enabled":true`) {
+		t.Errorf("wrong body, got %v", w.Body.String())
+	}
+}
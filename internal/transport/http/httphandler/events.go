@@ -0,0 +1,145 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+)
+
+// eventsHeartbeat is how often a comment is sent on an idle event stream,
+// so that proxies keep it open and gone clients are noticed.
+const eventsHeartbeat = 15 * time.Second
+
+// @Tags Task
+// @Summary Task Events.
+// @Description This endpoint is used for streaming the tasks created, updated and deleted as Server-Sent Events. Each event is named after its type, carries its number as the id and the event with the task as the data. The status filter matches the status of the task after the change. A client reconnecting with the Last-Event-ID header is sent the events it missed first; when they are no longer kept, a reset event tells it to list the tasks again. A client falling too far behind is disconnected and may resume the same way. Browsers, whose EventSource cannot set the Authorization header, pass a ticket from /task/ticket as the ticket query parameter instead; since a ticket expires in a minute, a stream is resumed with a fresh ticket and the last_event_id query parameter.
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param 	status query []string false "Only events of tasks having one of these statuses after the change, or before it for deletions, repeated or comma separated. A task moving out of these statuses is not matched." collectionFormat(multi)
+// @Param 	id query []integer false "Only events of these tasks, repeated or comma separated" collectionFormat(multi)
+// @Param 	Last-Event-ID header integer false "ID of the last event received"
+// @Param 	last_event_id query integer false "ID of the last event received, when the Last-Event-ID header is not sent"
+// @Param 	ticket query string false "Ticket from /task/ticket, when the Authorization header is not sent"
+// @Success 200 {object} events.Event "Success Response Body. A stream of events."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 405 {object} basehttphandler.Problem "Error Method Not Allowed Response"
+// @Failure 503 {object} basehttphandler.Problem "Error Service Unavailable Response. Events are not configured."
+// @Router /task/events [get]
+func (h *httpHandler) Events(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	if h.events == nil {
+		h.Fail(w, r, http.StatusServiceUnavailable, "events are not configured")
+		return
+	}
+	// @Step: Check Query Params
+	filter, err := parseEventFilter(r.URL.Query())
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	var lastID uint64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		if lastID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			h.Fail(w, r, http.StatusBadRequest, "invalid Last-Event-ID: must be a non-negative integer")
+			return
+		}
+	} else if v := r.URL.Query().Get("last_event_id"); v != "" {
+		// An EventSource opened anew with a fresh ticket cannot set the
+		// header.
+		if lastID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			h.Fail(w, r, http.StatusBadRequest, "invalid last_event_id: must be a non-negative integer")
+			return
+		}
+	}
+
+	// @Step: Stream Events
+	sub := h.events.Subscribe(filter, lastID)
+	defer sub.Close()
+	rc := http.NewResponseController(w)
+	// The stream outlives the write timeout of the server.
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if sub.Missed {
+		if _, err := io.WriteString(w, "event: reset\ndata: {}\n\n"); err != nil {
+			return
+		}
+	}
+	for _, e := range sub.Replay {
+		if err := writeEvent(w, e); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes e in the event stream format.
+func writeEvent(w io.Writer, e events.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+// parseEventFilter reads the status and id filters of an event stream.
+func parseEventFilter(q url.Values) (events.Filter, error) {
+	var filter events.Filter
+	for _, v := range q["status"] {
+		for _, status := range strings.Split(v, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filter.Statuses = append(filter.Statuses, status)
+			}
+		}
+	}
+	for _, v := range q["id"] {
+		for _, id := range strings.Split(v, ",") {
+			if id = strings.TrimSpace(id); id == "" {
+				continue
+			}
+			n, err := strconv.ParseUint(id, 10, 0)
+			if err != nil || n == 0 {
+				return events.Filter{}, fmt.Errorf("invalid id: must be a positive integer")
+			}
+			filter.IDs = append(filter.IDs, uint(n))
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,138 @@
+package httphandler_test
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+// readEvent reads the next event of an event stream as its lines.
+func readEvent(t *testing.T, r *bufio.Reader) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("event stream ended: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestEventsStream(t *testing.T) {
+	broker := events.NewBroker()
+	broker.Publish(events.Created, dto.TaskResponse{ID: 1, Status: "todo"})
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 1, Status: "done"})
+	handler := httphandler.New(httphandler.WithEvents(broker))
+	server := httptest.NewServer(http.HandlerFunc(handler.Events))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/task/events?id=1,2&status=done", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("wrong status code, want %v got %v", http.StatusOK, resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("wrong content type, want text/event-stream got %v", got)
+	}
+	body := bufio.NewReader(resp.Body)
+
+	// The missed event is replayed first.
+	lines := readEvent(t, body)
+	if len(lines) != 3 || lines[0] != "id: 2" || lines[1] != "event: updated" || !strings.Contains(lines[2], `"status":"done"`) {
+		t.Errorf("wrong replayed event, got %v", lines)
+	}
+
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 3, Status: "done"})
+	broker.Publish(events.Deleted, dto.TaskResponse{ID: 2, Status: "todo"})
+	broker.Publish(events.Deleted, dto.TaskResponse{ID: 2, Status: "done"})
+
+	lines = readEvent(t, body)
+	if len(lines) != 3 || lines[0] != "id: 5" || lines[1] != "event: deleted" {
+		t.Errorf("wrong live event, want the only one matching the filters got %v", lines)
+	}
+}
+
+func TestEventsReset(t *testing.T) {
+	broker := events.NewBroker()
+	handler := httphandler.New(httphandler.WithEvents(broker))
+	server := httptest.NewServer(http.HandlerFunc(handler.Events))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/task/events", nil)
+	req.Header.Set("Last-Event-ID", "7")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if lines := readEvent(t, bufio.NewReader(resp.Body)); len(lines) != 2 || lines[0] != "event: reset" {
+		t.Errorf("wrong event, want a reset got %v", lines)
+	}
+}
+
+func TestEventsResumeFromQuery(t *testing.T) {
+	broker := events.NewBroker()
+	broker.Publish(events.Created, dto.TaskResponse{ID: 1})
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 1})
+	handler := httphandler.New(httphandler.WithEvents(broker))
+	server := httptest.NewServer(http.HandlerFunc(handler.Events))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/task/events?ticket=ticket&last_event_id=1")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if lines := readEvent(t, bufio.NewReader(resp.Body)); len(lines) != 3 || lines[0] != "id: 2" {
+		t.Errorf("wrong event, want the one after last_event_id got %v", lines)
+	}
+}
+
+func TestEventsInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		lastID string
+		status int
+	}{
+		{"method", http.MethodPost, "/task/events", "", http.StatusMethodNotAllowed},
+		{"id", http.MethodGet, "/task/events?id=abc", "", http.StatusBadRequest},
+		{"last event id", http.MethodGet, "/task/events", "abc", http.StatusBadRequest},
+		{"last event id query", http.MethodGet, "/task/events?last_event_id=abc", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New(httphandler.WithEvents(events.NewBroker()))
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.lastID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastID)
+			}
+			w := httptest.NewRecorder()
+
+			handler.Events(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("wrong status code, want %v got %v", tt.status, w.Code)
+			}
+		})
+	}
+}
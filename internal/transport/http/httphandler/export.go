@@ -0,0 +1,241 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// csvColumns are the columns of a CSV export. Import reads the ones
+// SetTaskRequest takes and ignores the others.
+var csvColumns = []string{
+	"id", "title", "description", "status", "priority", "due_at", "assignee", "tags",
+	"parent_id", "created_at", "updated_at", "created_by", "updated_by",
+}
+
+// @Tags Task
+// @Summary Export Tasks.
+// @Description This endpoint is used for streaming every task matching the filters of /list, as newline delimited JSON or as CSV with a header row. Tasks are read a page at a time, so exports of any size are not held in memory. The X-Export-Status trailer tells whether the export is complete, and X-Export-Count how many tasks were sent; an export interrupted by an error ends early with X-Export-Status failed.
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Security BearerAuth
+// @Param 	format query string false "Export format" Enums(ndjson, csv) default(ndjson)
+// @Param 	status query []string false "Only tasks having one of these statuses, repeated or comma separated" collectionFormat(multi)
+// @Param 	title query string false "Only tasks whose title contains this text"
+// @Param 	description query string false "Only tasks whose description contains this text"
+// @Param 	id_from query integer false "Only tasks with an ID greater than or equal to this"
+// @Param 	id_to query integer false "Only tasks with an ID less than or equal to this"
+// @Param 	priority query []integer false "Only tasks having one of these priorities from 1 to 5, repeated or comma separated" collectionFormat(multi)
+// @Param 	assignee query string false "Only tasks assigned to this subject"
+// @Param 	tag query []string false "Only tasks having these tags, repeated or comma separated" collectionFormat(multi)
+// @Param 	tag_mode query string false "Whether tasks need any or all of the tags" Enums(any, all) default(any)
+// @Param 	due_after query string false "Only tasks due at or after this RFC3339 time"
+// @Param 	due_before query string false "Only tasks due before this RFC3339 time"
+// @Param 	created_by query string false "Only tasks created by this subject"
+// @Param 	updated_by query string false "Only tasks last updated by this subject"
+// @Param 	created_after query string false "Only tasks created at or after this RFC3339 time"
+// @Param 	created_before query string false "Only tasks created before this RFC3339 time"
+// @Param 	updated_after query string false "Only tasks updated at or after this RFC3339 time"
+// @Param 	updated_before query string false "Only tasks updated before this RFC3339 time"
+// @Param 	sort query string false "Sort key" Enums(id, created_at, updated_at, created_by, updated_by, priority, due_at)
+// @Param 	order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {array} dto.TaskResponse "Success Response Body. One task per line."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/export [get]
+func (h *httpHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	// An export takes as long as there are tasks to send; it ends when the
+	// client goes away.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	// @Step: Check Query Params
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = dto.FormatNDJSON
+	}
+	if format != dto.FormatNDJSON && format != dto.FormatCSV {
+		h.Fail(w, r, http.StatusBadRequest, "invalid format: must be one of ndjson, csv")
+		return
+	}
+	if q.Has("limit") || q.Has("cursor") {
+		h.Fail(w, r, http.StatusBadRequest, "limit and cursor are not supported by exports")
+		return
+	}
+	listReq, err := parseListRequest(q, false)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	// The stream outlives the write timeout of the server.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	exp := &exporter{w: w, format: format}
+	listReq.TaskJobMapper(&req)
+	req.JOB = "EXPORT"
+	req.Context = ctx
+	req.Emit = exp.emit
+
+	// @Step: Submit to Pool
+	_, err = h.pool.Submit(req)
+	started := exp.close()
+	if err != nil && started {
+		// The status is sent, all that is left is to tell the client in
+		// the trailer that the stream is cut short.
+		exp.end(ExportFailed)
+		h.Logger.ErrorContext(r.Context(), "httphandler Export service.Export", "err", err.Error(), "count", exp.count)
+		return
+	}
+	if err != nil {
+		h.Error(w, r, "httphandler Export service.Export", err)
+		return
+	}
+	// @Step: Return Success Response
+	if !started {
+		// Nothing matched; send the empty export.
+		exp.start()
+		exp.flush()
+	}
+	exp.end(ExportComplete)
+}
+
+// Trailers of an export, and the values of ExportStatusTrailer.
+const (
+	ExportStatusTrailer = "X-Export-Status"
+	ExportCountTrailer  = "X-Export-Count"
+	ExportComplete      = "complete"
+	ExportFailed        = "failed"
+)
+
+// exporter writes the tasks emitted by the worker running an export. The
+// response is started with the first task, so failures before it can still
+// be reported with an error status. Once closed it drops further tasks,
+// since the handler may return while the worker is still emitting.
+type exporter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	format  string
+	csv     *csv.Writer
+	enc     *json.Encoder
+	started bool
+	closed  bool
+	// count is the number of tasks written.
+	count int
+}
+
+func (e *exporter) emit(v any) error {
+	task, ok := v.(dto.TaskResponse)
+	if !ok {
+		return customerror.ErrUnknown
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return context.Canceled
+	}
+	if !e.started {
+		e.start()
+	}
+	var err error
+	if e.format == dto.FormatCSV {
+		err = e.csv.Write(csvRecord(task))
+	} else {
+		err = e.enc.Encode(task)
+	}
+	if err == nil {
+		e.count++
+	}
+	return err
+}
+
+// close stops further writes and flushes the ones made, reporting whether
+// the response was started.
+func (e *exporter) close() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	if e.started {
+		e.flush()
+	}
+	return e.started
+}
+
+func (e *exporter) start() {
+	e.started = true
+	name := "tasks." + e.format
+	if e.format == dto.FormatCSV {
+		e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		e.w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	e.w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+	e.w.Header().Set("Trailer", ExportStatusTrailer+", "+ExportCountTrailer)
+	e.w.WriteHeader(http.StatusOK)
+	e.enc = json.NewEncoder(e.w)
+	e.csv = csv.NewWriter(e.w)
+	if e.format == dto.FormatCSV {
+		_ = e.csv.Write(csvColumns)
+	}
+}
+
+// end sends the trailers of a started export once it is closed.
+func (e *exporter) end(status string) {
+	e.w.Header().Set(ExportStatusTrailer, status)
+	e.w.Header().Set(ExportCountTrailer, strconv.Itoa(e.count))
+}
+
+func (e *exporter) flush() {
+	if e.format == dto.FormatCSV {
+		e.csv.Flush()
+	}
+	if f, ok := e.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// csvRecord returns task as a row of csvColumns.
+func csvRecord(task dto.TaskResponse) []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	priority, parentID := "", ""
+	if task.Priority != 0 {
+		priority = strconv.Itoa(task.Priority)
+	}
+	if task.ParentID != 0 {
+		parentID = strconv.FormatUint(uint64(task.ParentID), 10)
+	}
+	return []string{
+		strconv.FormatUint(uint64(task.ID), 10),
+		task.Title,
+		task.Description,
+		task.Status,
+		priority,
+		formatTime(task.DueAt),
+		task.Assignee,
+		strings.Join(task.Tags, ","),
+		parentID,
+		formatTime(&task.CreatedAt),
+		formatTime(&task.UpdatedAt),
+		task.CreatedBy,
+		task.UpdatedBy,
+	}
+}
@@ -0,0 +1,224 @@
+package httphandler_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+// emitTasks returns a worker hook emitting tasks like the export job does.
+func emitTasks(tasks ...dto.TaskResponse) func(models.TaskJobModel) {
+	return func(job models.TaskJobModel) {
+		for _, task := range tasks {
+			if err := job.Emit(task); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestExportInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/export", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestExportInvalidQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		shouldContain string
+	}{
+		{"unknown format", "?format=xml", "invalid format: must be one of ndjson, csv"},
+		{"paged", "?limit=10", "limit and cursor are not supported by exports"},
+		{"invalid filter", "?priority=high", "invalid priority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New()
+			req := httptest.NewRequest(http.MethodGet, "/export"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.Export(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.shouldContain) {
+				t.Errorf("wrong body message, want %v got %v", tt.shouldContain, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestExportErrBeforeFirstTask(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrGetAll,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
+	}
+}
+
+func TestExportWithTimeout(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*-1),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: context.DeadlineExceeded,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("wrong status code, want %v got %v", http.StatusGatewayTimeout, w.Code)
+	}
+}
+
+func TestExportNDJSON(t *testing.T) {
+	var job models.TaskJobModel
+	emit := emitTasks(dto.TaskResponse{ID: 1, Title: "first"}, dto.TaskResponse{ID: 2, Title: "second"})
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			onSubmit: func(j models.TaskJobModel) {
+				job = j
+				emit(j)
+			},
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/export?status=todo&tag=ops", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("wrong content type, want %v got %v", "application/x-ndjson", got)
+	}
+	if job.JOB != "EXPORT" || len(job.Filter.Statuses) != 1 || len(job.Filter.Tags) != 1 {
+		t.Errorf("wrong job submitted, got %+v", job)
+	}
+	if _, ok := job.Context.Deadline(); ok {
+		t.Errorf("want an export without deadline")
+	}
+	trailer := w.Result().Trailer
+	if trailer.Get(httphandler.ExportStatusTrailer) != httphandler.ExportComplete || trailer.Get(httphandler.ExportCountTrailer) != "2" {
+		t.Errorf("want the trailer to tell the export of 2 tasks is complete, got %v", trailer)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("wrong number of lines, want %v got %v", 2, len(lines))
+	}
+	var task dto.TaskResponse
+	if err := json.Unmarshal([]byte(lines[1]), &task); err != nil || task.ID != 2 {
+		t.Errorf("wrong second line, got %v: %v", lines[1], err)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	due := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			onSubmit: emitTasks(dto.TaskResponse{ID: 1, Title: "a, quoted \"title\"", Priority: 2, DueAt: &due, Tags: []string{"ops", "db"}}),
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/export?format=csv", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0][0] != "id" {
+		t.Fatalf("want a header and one row, got %v", records)
+	}
+	row := records[1]
+	if row[1] != "a, quoted \"title\"" || row[4] != "2" || row[5] != "2024-01-02T03:04:05Z" || row[7] != "ops,db" {
+		t.Errorf("wrong row, got %v", row)
+	}
+}
+
+func TestExportEmpty(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/export?format=csv", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if got := strings.TrimSpace(w.Body.String()); !strings.HasPrefix(got, "id,title") || strings.Contains(got, "\n") {
+		t.Errorf("want only the header row, got %v", got)
+	}
+}
+
+func TestExportErrAfterFirstTask(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: errors.New("connection lost"),
+			onSubmit:  emitTasks(dto.TaskResponse{ID: 1}),
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); len(lines) != 1 {
+		t.Errorf("want the stream to end after the first task, got %v", lines)
+	}
+	trailer := w.Result().Trailer
+	if trailer.Get(httphandler.ExportStatusTrailer) != httphandler.ExportFailed || trailer.Get(httphandler.ExportCountTrailer) != "1" {
+		t.Errorf("want the trailer to tell the export failed after 1 task, got %v", trailer)
+	}
+}
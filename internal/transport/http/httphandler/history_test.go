@@ -0,0 +1,104 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestHistoryInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/history?id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.History(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+	shouldContain := "method POST not allowed"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestHistoryInvalidQueryParam(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/history?id=invalid", nil)
+	w := httptest.NewRecorder()
+
+	handler.History(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "invalid query parameters"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestHistoryErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/history?id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.History(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+	assertProblem(t, w, http.StatusNotFound, "task.not_found", customerror.ErrIDNotFound.Error())
+}
+
+func TestHistorySuccess(t *testing.T) {
+	resp := util.ResponseData{
+		Data: []dto.TaskEventResponse{
+			{
+				ID:     1,
+				TaskID: 1,
+				Type:   "created",
+				NewValue: &dto.TaskResponse{
+					ID:     1,
+					Title:  "test",
+					Status: "active",
+				},
+				Actor: "alice",
+			},
+		},
+		Status: http.StatusOK,
+	}
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: resp,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/history?id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.History(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.Response(http.StatusOK, resp))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
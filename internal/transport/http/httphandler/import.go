@@ -0,0 +1,361 @@
+package httphandler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+const (
+	// importBatchSize is the number of lines applied by one bulk job.
+	importBatchSize = 100
+	// maxImportLine is the longest NDJSON line an import reads.
+	maxImportLine = 64 << 10
+)
+
+// @Tags Task
+// @Summary Import Tasks.
+// @Description This endpoint is used for creating tasks from a newline delimited JSON body, or from a CSV body with a header row when sent as text/csv. Every line is validated like the body of /set and lines are applied in batches of 100; a failed line does not stop the others. Lines are the tasks of /export: a task may be in any status of the workflow, the initial one when none is given, and keeps its tags, parent_id, created_at and updated_at. Parents must exist already or be imported on an earlier line. created_by and updated_by are not kept, the tasks being recorded as created by the caller. CSV columns are matched by name, tags being comma separated, so exports can be imported again. Bodies are limited to 32 MiB and each batch to the request timeout; when reading the body or applying a batch fails, the batches before it are kept.
+// @Accept application/x-ndjson
+// @Accept text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param request body string true "Tasks, one per line"
+// @Success 200 {object} dto.ImportResponse "Success Response Body. The number of imported lines and the errors of the failed ones."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. The body could not be read."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body is too large."
+// @Failure 415 {object} basehttphandler.Problem "Error Unsupported Media Type Response. The body is neither NDJSON nor CSV."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server Response"
+// @Router /task/import [post]
+func (h *httpHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Pick Format
+	// Reading and applying a large body outlives the timeouts of the
+	// server; every batch is still bounded by the context timeout.
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+	r.Body = http.MaxBytesReader(w, r.Body, dto.MaxImportSize)
+	var (
+		lines importReader
+		err   error
+	)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		lines, err = newCSVImport(r.Body)
+	case "", "application/x-ndjson", "application/json":
+		lines = newNDJSONImport(r.Body)
+	default:
+		h.Fail(w, r, http.StatusUnsupportedMediaType, "body must be application/x-ndjson or text/csv")
+		return
+	}
+	if err != nil {
+		status := bodyErrorStatus(err)
+		h.Fail(w, r, status, err.Error())
+		return
+	}
+
+	// @Step: Validate And Apply Lines
+	resp := dto.ImportResponse{
+		Errors: make([]dto.ImportLineError, 0),
+	}
+	lineErr := func(line int, id uint, code string, err error) {
+		resp.Failed++
+		if len(resp.Errors) < dto.MaxImportErrors {
+			resp.Errors = append(resp.Errors, dto.ImportLineError{Line: line, ID: id, Code: code, Error: err.Error()})
+		}
+	}
+	validate := basehttphandler.NewValidator()
+	batch := make([]importLine, 0, importBatchSize)
+	for {
+		line, importReq, err := lines.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var invalid *invalidLineError
+		if errors.As(err, &invalid) {
+			lineErr(line, importReq.ID, basehttphandler.CodeInvalidRequest, invalid.err)
+			continue
+		}
+		if err != nil {
+			status := bodyErrorStatus(err)
+			h.Fail(w, r, status, fmt.Sprintf("line %d: %s", line, err.Error()))
+			return
+		}
+		if err := validate.Struct(importReq.SetTaskRequest); err != nil {
+			lineErr(line, importReq.ID, basehttphandler.CodeInvalidRequest, basehttphandler.ValidationError(importReq.SetTaskRequest, err))
+			continue
+		}
+		batch = append(batch, importLine{line: line, req: importReq})
+		if len(batch) < importBatchSize {
+			continue
+		}
+		if status, err := h.importBatch(r, batch, &resp, lineErr); err != nil {
+			h.Fail(w, r, status, err.Error())
+			return
+		}
+		batch = batch[:0]
+	}
+	if len(batch) > 0 {
+		if status, err := h.importBatch(r, batch, &resp, lineErr); err != nil {
+			h.Fail(w, r, status, err.Error())
+			return
+		}
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, resp),
+	)
+}
+
+// importLine is a valid line of an import waiting for its batch.
+type importLine struct {
+	line int
+	req  dto.ImportTaskRequest
+}
+
+// importBatch creates the tasks of batch with a best effort bulk job, counting
+// the imported lines in resp and reporting the failed ones to lineErr. The
+// returned status goes with an error failing the whole batch.
+func (h *httpHandler) importBatch(r *http.Request, batch []importLine, resp *dto.ImportResponse, lineErr func(int, uint, string, error)) (int, error) {
+	var req models.TaskJobModel
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	bulkReq := dto.BulkRequest{
+		Mode:       dto.BulkBestEffort,
+		Operations: make([]dto.BulkOperation, len(batch)),
+	}
+	for i, l := range batch {
+		bulkReq.Operations[i] = dto.BulkOperation{
+			Op:          dto.BulkImport,
+			ID:          l.req.ID,
+			Title:       l.req.Title,
+			Description: l.req.Description,
+			Status:      l.req.Status,
+			Priority:    l.req.Priority,
+			DueAt:       l.req.DueAt,
+			Assignee:    l.req.Assignee,
+			Tags:        l.req.Tags,
+			ParentID:    l.req.ParentID,
+			CreatedAt:   l.req.CreatedAt,
+			UpdatedAt:   l.req.UpdatedAt,
+		}
+	}
+	bulkReq.TaskJobMapper(&req)
+	req.JOB = "BULK"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return http.StatusGatewayTimeout, fmt.Errorf("line %d: %s", batch[0].line, constant.ErrContextDeadline)
+		}
+		h.Logger.ErrorContext(r.Context(), "httphandler Import service.Bulk", "err", err.Error())
+		return http.StatusInternalServerError, fmt.Errorf("line %d: %w", batch[0].line, err)
+	}
+	bulkResp, _ := res.(dto.BulkResponse)
+	for _, result := range bulkResp.Results {
+		if result.Index < 0 || result.Index >= len(batch) {
+			continue
+		}
+		if result.Err != nil {
+			// bulkProblem logs the failures clients cannot fix.
+			h.bulkProblem(r, &result)
+			lineErr(batch[result.Index].line, result.ID, result.Code, errors.New(result.Error))
+			continue
+		}
+		resp.Imported++
+	}
+	return http.StatusOK, nil
+}
+
+// importReader reads the tasks of an import one line at a time. Next
+// returns io.EOF after the last line. An *invalidLineError fails only the
+// returned line; any other error ends the import.
+type importReader interface {
+	Next() (int, dto.ImportTaskRequest, error)
+}
+
+type invalidLineError struct {
+	err error
+}
+
+func (e *invalidLineError) Error() string {
+	return e.err.Error()
+}
+
+func invalidLine(format string, args ...any) error {
+	return &invalidLineError{err: fmt.Errorf(format, args...)}
+}
+
+// bodyErrorStatus is the status reporting an error reading a request body,
+// like an error ending an import.
+func bodyErrorStatus(err error) int {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+type ndjsonImport struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNDJSONImport(body io.Reader) *ndjsonImport {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 4096), maxImportLine)
+	return &ndjsonImport{scanner: scanner}
+}
+
+func (n *ndjsonImport) Next() (int, dto.ImportTaskRequest, error) {
+	for n.scanner.Scan() {
+		n.line++
+		line := strings.TrimSpace(n.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var req dto.ImportTaskRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			return n.line, req, invalidLine("invalid JSON: %s", err.Error())
+		}
+		return n.line, req, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return n.line + 1, dto.ImportTaskRequest{}, fmt.Errorf("longer than %d bytes", maxImportLine)
+		}
+		return n.line + 1, dto.ImportTaskRequest{}, err
+	}
+	return n.line, dto.ImportTaskRequest{}, io.EOF
+}
+
+type csvImport struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// newCSVImport reads the header row of body. It must name the id, title
+// and description columns.
+func newCSVImport(body io.Reader) (*csvImport, error) {
+	reader := csv.NewReader(body)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv header row required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	reader.FieldsPerRecord = len(header)
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"id", "title", "description"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header must name the %s column", name)
+		}
+	}
+	return &csvImport{reader: reader, columns: columns}, nil
+}
+
+func (c *csvImport) Next() (int, dto.ImportTaskRequest, error) {
+	var req dto.ImportTaskRequest
+	record, err := c.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, req, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Line, req, invalidLine("invalid CSV: %s", parseErr.Err.Error())
+	}
+	if err != nil {
+		line, _ := c.reader.FieldPos(0)
+		return line, req, err
+	}
+	line, _ := c.reader.FieldPos(0)
+	field := func(name string) string {
+		if i, ok := c.columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	id, err := strconv.ParseUint(field("id"), 10, 0)
+	if err != nil || id == 0 {
+		return line, req, invalidLine("invalid id: must be a positive integer")
+	}
+	req = dto.ImportTaskRequest{
+		SetTaskRequest: dto.SetTaskRequest{
+			ID:          uint(id),
+			Title:       field("title"),
+			Description: field("description"),
+			Status:      field("status"),
+			Assignee:    field("assignee"),
+		},
+	}
+	if v := field("priority"); v != "" {
+		priority, err := strconv.Atoi(v)
+		if err != nil {
+			return line, req, invalidLine("invalid priority: must be an integer between %d and %d", models.MinPriority, models.MaxPriority)
+		}
+		req.Priority = priority
+	}
+	if v := field("due_at"); v != "" {
+		dueAt, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return line, req, invalidLine("invalid due_at: must be an RFC3339 timestamp")
+		}
+		req.DueAt = &dueAt
+	}
+	if v := field("tags"); v != "" {
+		req.Tags = strings.Split(v, ",")
+	}
+	if v := field("parent_id"); v != "" {
+		parentID, err := strconv.ParseUint(v, 10, 0)
+		if err != nil {
+			return line, req, invalidLine("invalid parent_id: must be a positive integer")
+		}
+		req.ParentID = uint(parentID)
+	}
+	if v := field("created_at"); v != "" {
+		createdAt, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return line, req, invalidLine("invalid created_at: must be an RFC3339 timestamp")
+		}
+		req.CreatedAt = &createdAt
+	}
+	if v := field("updated_at"); v != "" {
+		updatedAt, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return line, req, invalidLine("invalid updated_at: must be an RFC3339 timestamp")
+		}
+		req.UpdatedAt = &updatedAt
+	}
+	return line, req, nil
+}
@@ -0,0 +1,242 @@
+package httphandler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+// bulkWorker answers bulk jobs, failing the operations whose ID is in fail.
+type bulkWorker struct {
+	jobs []models.TaskJobModel
+	fail map[uint]bool
+}
+
+func (b *bulkWorker) Submit(job models.TaskJobModel) (any, error) {
+	b.jobs = append(b.jobs, job)
+	resp := dto.BulkResponse{Mode: job.Mode, Committed: true}
+	for i, op := range job.Operations {
+		result := dto.BulkResult{Index: i, Op: op.Op, ID: op.ID}
+		if b.fail[op.ID] {
+			result.Err = customerror.ErrIDExists
+			result.Error = fmt.Sprintf("ID exists, '%d' already exists in the database.", op.ID)
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+func importResponse(t *testing.T, w *httptest.ResponseRecorder) dto.ImportResponse {
+	t.Helper()
+	var body struct {
+		Data dto.ImportResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	return body.Data
+}
+
+func TestImportInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/import", nil)
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestImportUnsupportedMediaType(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader("<tasks/>"))
+	req.Header.Set("Content-Type", "application/xml")
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("wrong status code, want %v got %v", http.StatusUnsupportedMediaType, w.Code)
+	}
+}
+
+func TestImportNDJSON(t *testing.T) {
+	worker := &bulkWorker{fail: map[uint]bool{3: true}}
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(worker),
+	)
+	body := strings.Join([]string{
+		`{"id":1,"title":"title","description":"description"}`,
+		``,
+		`{"id":2,"title":"ab","description":"description"}`,
+		`{"id":3,"title":"title","description":"description","status":"todo"}`,
+		`{"id":4,`,
+		`{"id":5,"title":"title","description":"description","priority":2,"tags":["ops"],"parent_id":1,"created_at":"2024-01-02T03:04:05Z","overdue":false}`,
+	}, "\n")
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("wrong status code, want %v got %v: %v", http.StatusOK, w.Code, w.Body.String())
+	}
+	resp := importResponse(t, w)
+	if resp.Imported != 2 || resp.Failed != 3 {
+		t.Errorf("want 2 imported and 3 failed lines, got %+v", resp)
+	}
+	wantLines := []int{3, 5, 4}
+	for i, lineErr := range resp.Errors {
+		if lineErr.Line != wantLines[i] {
+			t.Errorf("wrong line of error %d, want %v got %+v", i, wantLines[i], lineErr)
+		}
+	}
+	if !strings.Contains(resp.Errors[0].Error, "invalid title: must be at least 3 characters") {
+		t.Errorf("want the validation error of line 3, got %v", resp.Errors[0].Error)
+	}
+	if !strings.Contains(resp.Errors[2].Error, "'3' already exists") {
+		t.Errorf("want the set error of line 4, got %v", resp.Errors[2].Error)
+	}
+	if len(worker.jobs) != 1 || worker.jobs[0].JOB != "BULK" || worker.jobs[0].Mode != dto.BulkBestEffort {
+		t.Errorf("want one best effort bulk job, got %+v", worker.jobs)
+	}
+	op := worker.jobs[0].Operations[2]
+	if op.Op != dto.BulkImport || op.ID != 5 || len(op.Tags) != 1 || op.ParentID != 1 || op.CreatedAt == nil || op.UpdatedAt != nil {
+		t.Errorf("want the tags, parent and creation time of line 6 carried over, got %+v", op)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	worker := &bulkWorker{}
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(worker),
+	)
+	body := "id,title,description,status,priority,due_at,tags,parent_id,created_at,updated_at,created_by\n" +
+		"1,title,\"a, description\",done,2,2024-01-02T03:04:05Z,\"ops,db\",7,2024-01-01T00:00:00Z,2024-01-02T00:00:00Z,someone\n" +
+		"x,title,description,,,,,,,,\n" +
+		"3,title,description,,,tomorrow,,,,,\n" +
+		"4,title\n"
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("wrong status code, want %v got %v: %v", http.StatusOK, w.Code, w.Body.String())
+	}
+	resp := importResponse(t, w)
+	if resp.Imported != 1 || resp.Failed != 3 {
+		t.Errorf("want 1 imported and 3 failed lines, got %+v", resp)
+	}
+	op := worker.jobs[0].Operations[0]
+	if op.Op != dto.BulkImport || op.ID != 1 || op.Description != "a, description" || op.Status != "done" || op.Priority != 2 || op.DueAt == nil {
+		t.Errorf("wrong operation, got %+v", op)
+	}
+	if len(op.Tags) != 2 || op.ParentID != 7 || op.CreatedAt == nil || op.UpdatedAt == nil || !op.UpdatedAt.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("want the tags, parent and timestamps of the export, got %+v", op)
+	}
+	for i, want := range []string{"invalid id", "invalid due_at", "invalid CSV"} {
+		if !strings.Contains(resp.Errors[i].Error, want) || resp.Errors[i].Line != i+3 {
+			t.Errorf("wrong error %d, want %v on line %d, got %+v", i, want, i+3, resp.Errors[i])
+		}
+	}
+}
+
+func TestImportCSVWithoutHeader(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader("1,title,description\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "csv header must name the id column"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestImportBatches(t *testing.T) {
+	worker := &bulkWorker{}
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(worker),
+	)
+	var body strings.Builder
+	for i := 1; i <= 250; i++ {
+		fmt.Fprintf(&body, `{"id":%d,"title":"title","description":"description"}`+"\n", i)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body.String()))
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if resp := importResponse(t, w); resp.Imported != 250 {
+		t.Errorf("want 250 imported lines, got %+v", resp)
+	}
+	if len(worker.jobs) != 3 || len(worker.jobs[2].Operations) != 50 {
+		t.Errorf("want batches of 100, 100 and 50 lines, got %d jobs", len(worker.jobs))
+	}
+}
+
+func TestImportLineTooLong(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*5),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&bulkWorker{}),
+	)
+	body := `{"id":1,"title":"` + strings.Repeat("a", 70<<10) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "line 1: longer than"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestImportWithTimeout(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*-1),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: context.DeadlineExceeded,
+		}),
+	)
+	body := `{"id":1,"title":"title","description":"description"}`
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("wrong status code, want %v got %v", http.StatusGatewayTimeout, w.Code)
+	}
+}
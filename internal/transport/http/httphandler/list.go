@@ -2,113 +2,193 @@ package httphandler
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
-	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
 	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
 // @Tags Task
-// @Summary List Tasks by Status.
-// @Description This endpoint is used for retrieving a list of tasks based on their status.
+// @Summary List Tasks.
+// @Description This endpoint is used for retrieving a list of tasks matching all of the given filters, every task when none is given.
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param 	status query string true "Task Status to retrieve" ExampleRequest
-// @Success 200 {array} dto.TaskResponse "Success Response Body. List of tasks matching the specified status."
-// @Failure 400 {object} util.ErrorResponse "Error Bad Request Response. Invalid request parameters."
-// @Failure 404 {object} util.ErrorResponse "Error Not Found Response. No tasks found with the specified status."
-// @Failure 500 {object} util.ErrorResponse "Error Internal Server. Server encountered an error."
-// @Router /list [get]
+// @Param 	status query []string false "Only tasks having one of these statuses, repeated or comma separated" collectionFormat(multi)
+// @Param 	title query string false "Only tasks whose title contains this text"
+// @Param 	description query string false "Only tasks whose description contains this text"
+// @Param 	id_from query integer false "Only tasks with an ID greater than or equal to this"
+// @Param 	id_to query integer false "Only tasks with an ID less than or equal to this"
+// @Param 	priority query []integer false "Only tasks having one of these priorities from 1 to 5, repeated or comma separated" collectionFormat(multi)
+// @Param 	assignee query string false "Only tasks assigned to this subject"
+// @Param 	tag query []string false "Only tasks having these tags, repeated or comma separated" collectionFormat(multi)
+// @Param 	tag_mode query string false "Whether tasks need any or all of the tags" Enums(any, all) default(any)
+// @Param 	due_after query string false "Only tasks due at or after this RFC3339 time"
+// @Param 	due_before query string false "Only tasks due before this RFC3339 time"
+// @Param 	created_by query string false "Only tasks created by this subject"
+// @Param 	updated_by query string false "Only tasks last updated by this subject"
+// @Param 	created_after query string false "Only tasks created at or after this RFC3339 time"
+// @Param 	created_before query string false "Only tasks created before this RFC3339 time"
+// @Param 	updated_after query string false "Only tasks updated at or after this RFC3339 time"
+// @Param 	updated_before query string false "Only tasks updated before this RFC3339 time"
+// @Param 	sort query string false "Sort key" Enums(id, created_at, updated_at, created_by, updated_by, priority, due_at)
+// @Param 	order query string false "Sort order" Enums(asc, desc)
+// @Param 	limit query integer false "Page size, at most 100" default(50)
+// @Param 	cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} util.PageResponseData{data=[]dto.TaskResponse} "Success Response Body. One page of the tasks matching the filters."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Deprecated
+// @Router /task/list [get]
 func (h *httpHandler) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	h.list(w, r)
+}
+
+// list serves a listing for the query of r.
+func (h *httpHandler) list(w http.ResponseWriter, r *http.Request) {
 	var (
 		req models.TaskJobModel
 	)
 	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
 	defer cancel()
-	if r.Method != http.MethodGet {
-		h.JSON(
-			w,
-			http.StatusMethodNotAllowed,
-			fmt.Sprintf(constant.ErrMethodNotAllowed, r.Method),
-		)
-		return
-	}
-	if len(r.URL.Query()) == 0 {
-		h.JSON(w,
-			http.StatusBadRequest,
-			util.BasicError("query parameters required", http.StatusBadRequest),
-		)
-		return
-	}
-	stat := r.URL.Query().Get("status")
-	if stat == "" {
-		h.JSON(w,
-			http.StatusBadRequest,
-			util.BasicError("invalid query parameters", http.StatusBadRequest),
-		)
+	listReq, err := parseListRequest(r.URL.Query(), false)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
-	invalidChars := []string{"<", ">", "!", "=", "(", ")", ";", ":", ",", "\"", "/", "\\", "?", "@", "[", "]", "{", "}", " ", "."}
-	for _, c := range invalidChars {
-		if strings.Contains(stat, c) || len(stat) > 255 {
-			h.JSON(w,
-				http.StatusBadRequest,
-				util.BasicError("invalid query parameters", http.StatusBadRequest),
-			)
-			return
-		}
-	}
-	req.Status = stat
+	listReq.TaskJobMapper(&req)
 	req.JOB = "LIST"
 	req.Context = ctx
 	// @Step: Submit to Pool
 	res, err := h.pool.Submit(req)
 	if err != nil {
-		// @Step: Handle Errors
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.JSON(w,
-				http.StatusGatewayTimeout,
-				util.BasicError(constant.ErrContextDeadline, http.StatusGatewayTimeout),
-			)
-			return
-		}
-		var cusErr *customerror.Error
-		if errors.As(err, &cusErr) {
-			clientMessage := cusErr.Message
-			if cusErr.Data != nil {
-				data, ok := cusErr.Data.(string)
-				if ok {
-					clientMessage = clientMessage + ", " + data
-				}
-			}
-
-			if cusErr.Loggable {
-				h.Logger.Error("httphandler List service.List", "err", clientMessage)
-			}
-
-			if cusErr == customerror.ErrGetAll {
-				h.JSON(w,
-					http.StatusNotFound,
-					util.BasicError(clientMessage, http.StatusNotFound),
-				)
-				return
-			}
-		}
-		h.JSON(w,
-			http.StatusInternalServerError,
-			util.BasicError(err.Error(), http.StatusInternalServerError),
-		)
+		h.Error(w, r, "httphandler List service.List", err)
 		return
 	}
 	// @Step: Return Success Response
+	page, ok := res.(dto.TaskListResponse)
+	if !ok {
+		h.Error(w, r, "httphandler List", customerror.ErrUnknown)
+		return
+	}
 	h.JSON(w,
 		http.StatusOK,
-		util.Response(http.StatusOK, res),
+		util.PageResponse(http.StatusOK, page.Tasks, page.Total, page.NextCursor),
 	)
 }
+
+// parseListRequest reads the filters of a list request from the query.
+// Statuses may be repeated or comma separated.
+func parseListRequest(q url.Values, deleted bool) (dto.ListTaskRequest, error) {
+	req := dto.ListTaskRequest{
+		Deleted:     deleted,
+		Title:       q.Get("title"),
+		Description: q.Get("description"),
+		Assignee:    q.Get("assignee"),
+		CreatedBy:   q.Get("created_by"),
+		UpdatedBy:   q.Get("updated_by"),
+		TagMode:     q.Get("tag_mode"),
+		SortBy:      q.Get("sort"),
+		SortOrder:   q.Get("order"),
+		Cursor:      q.Get("cursor"),
+	}
+	for _, v := range q["status"] {
+		for _, status := range strings.Split(v, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				req.Statuses = append(req.Statuses, status)
+			}
+		}
+	}
+	for _, v := range q["tag"] {
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				req.Tags = append(req.Tags, tag)
+			}
+		}
+	}
+	for _, v := range q["priority"] {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p == "" {
+				continue
+			}
+			priority, err := strconv.Atoi(p)
+			if err != nil {
+				return dto.ListTaskRequest{}, fmt.Errorf("invalid priority: must be an integer between %d and %d", models.MinPriority, models.MaxPriority)
+			}
+			req.Priorities = append(req.Priorities, priority)
+		}
+	}
+	limit, err := parseLimit(q.Get("limit"))
+	if err != nil {
+		return dto.ListTaskRequest{}, err
+	}
+	req.Limit = limit
+	ids := []struct {
+		key string
+		dst *uint
+	}{
+		{"id_from", &req.IDFrom},
+		{"id_to", &req.IDTo},
+	}
+	for _, id := range ids {
+		v := q.Get(id.key)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(v, 10, 0)
+		if err != nil || n == 0 {
+			return dto.ListTaskRequest{}, fmt.Errorf("invalid %s: must be a positive integer", id.key)
+		}
+		*id.dst = uint(n)
+	}
+	times := []struct {
+		key string
+		dst *time.Time
+	}{
+		{"created_after", &req.CreatedAfter},
+		{"created_before", &req.CreatedBefore},
+		{"updated_after", &req.UpdatedAfter},
+		{"updated_before", &req.UpdatedBefore},
+		{"due_after", &req.DueAfter},
+		{"due_before", &req.DueBefore},
+	}
+	for _, ts := range times {
+		v := q.Get(ts.key)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return dto.ListTaskRequest{}, fmt.Errorf("invalid %s: must be an RFC3339 timestamp", ts.key)
+		}
+		*ts.dst = t
+	}
+	if err := basehttphandler.NewValidator().Struct(req); err != nil {
+		return dto.ListTaskRequest{}, basehttphandler.ValidationError(req, err)
+	}
+	return req, nil
+}
+
+// parseLimit reads an optional page size, 0 when it is not given.
+func parseLimit(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 1 || limit > dto.MaxPageSize {
+		return 0, fmt.Errorf("invalid limit: must be between 1 and %d", dto.MaxPageSize)
+	}
+	return limit, nil
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
 	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
@@ -53,25 +54,82 @@ func TestListWithTimeout(t *testing.T) {
 	}
 }
 
-func TestListQueryParamRequired(t *testing.T) {
-	handler := httphandler.New()
+func TestListWithoutQuery(t *testing.T) {
+	submitted := false
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.TaskListResponse{},
+			onSubmit: func(models.TaskJobModel) { submitted = true },
+		}),
+	)
 	req := httptest.NewRequest(http.MethodGet, "/list", nil)
 	w := httptest.NewRecorder()
 
 	handler.List(w, req)
 
+	if w.Code != http.StatusOK || !submitted {
+		t.Errorf("want the first page listed, got %v: %v", w.Code, w.Body)
+	}
+}
+
+func TestListInvalidParams(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/list?status=active,ok", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	assertProblem(t, w, http.StatusBadRequest, "request.invalid", "invalid status[1]: must be at least 3 characters")
+}
+
+func TestListInvalidIDRange(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/list?id_from=10&id_to=5", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
 	}
-	shouldContain := "query parameters required"
+	shouldContain := "invalid id_to: must not be less than id_from"
 	if !strings.Contains(w.Body.String(), shouldContain) {
 		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
 	}
 }
 
-func TestListInvalidParams(t *testing.T) {
+func TestListInvalidFilterValues(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"/list?priority=high", "invalid priority: must be an integer between 1 and 5"},
+		{"/list?priority=2,9", "invalid priority[1]: must be at most 5"},
+		{"/list?tag=backend&tag_mode=some", "invalid tag_mode: must be one of any, all"},
+	}
+	for _, tt := range tests {
+		handler := httphandler.New()
+		req := httptest.NewRequest(http.MethodGet, tt.query, nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: wrong status code, want %v got %v", tt.query, http.StatusBadRequest, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: wrong body message, want %v got %v", tt.query, tt.want, w.Body.String())
+		}
+	}
+}
+
+func TestListInvalidDateRange(t *testing.T) {
 	handler := httphandler.New()
-	req := httptest.NewRequest(http.MethodGet, "/list?status=./...", nil)
+	req := httptest.NewRequest(http.MethodGet, "/list?created_after=2024-02-01T00:00:00Z&created_before=2024-01-01T00:00:00Z", nil)
 	w := httptest.NewRecorder()
 
 	handler.List(w, req)
@@ -79,12 +137,26 @@ func TestListInvalidParams(t *testing.T) {
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError("invalid query parameters", http.StatusBadRequest))
-	if err != nil {
-		t.Errorf("error while marshalling error: %v", err)
+	shouldContain := "invalid created_before: must be after created_after"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
 	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+}
+
+func TestListFiltersWithoutStatus(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.TaskListResponse{},
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/list?title=release+notes&description=v1.2&id_from=1&id_to=100", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
 	}
 }
 
@@ -103,13 +175,7 @@ func TestListErrUnknown(t *testing.T) {
 	if w.Code != http.StatusInternalServerError {
 		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrUnknown.Error(), http.StatusInternalServerError))
-	if err != nil {
-		t.Errorf("error while response casting error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
-	}
+	assertProblem(t, w, http.StatusInternalServerError, "internal.unknown", customerror.ErrUnknown.Error())
 }
 
 func TestListErrGetAll(t *testing.T) {
@@ -124,21 +190,15 @@ func TestListErrGetAll(t *testing.T) {
 
 	handler.List(w, req)
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
-	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrGetAll.Error(), http.StatusNotFound))
-	if err != nil {
-		t.Errorf("error while response casting error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
 	}
+	assertProblem(t, w, http.StatusInternalServerError, "task.list_failed", customerror.ErrGetAll.Error())
 }
 
 func TestListSuccess(t *testing.T) {
-	resp := util.ResponseData{
-		Data: []dto.TaskResponse{
+	resp := dto.TaskListResponse{
+		Tasks: []dto.TaskResponse{
 			{
 				ID:          1,
 				Title:       "title",
@@ -152,7 +212,8 @@ func TestListSuccess(t *testing.T) {
 				Status:      "active",
 			},
 		},
-		Status: http.StatusOK,
+		Total:      3,
+		NextCursor: "next",
 	}
 	handler := httphandler.New(
 		httphandler.WithLogger(logger),
@@ -162,7 +223,7 @@ func TestListSuccess(t *testing.T) {
 		),
 	)
 
-	req := httptest.NewRequest(http.MethodGet, "/list?status=success", nil)
+	req := httptest.NewRequest(http.MethodGet, "/list?status=success&limit=2", nil)
 	w := httptest.NewRecorder()
 
 	handler.List(w, req)
@@ -170,7 +231,7 @@ func TestListSuccess(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.Response(http.StatusOK, resp))
+	shouldContain, err := json.Marshal(util.PageResponse(http.StatusOK, resp.Tasks, resp.Total, resp.NextCursor))
 	if err != nil {
 		t.Errorf("error while response casting error: %v", err)
 	}
@@ -178,3 +239,74 @@ func TestListSuccess(t *testing.T) {
 		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
 	}
 }
+
+func TestListInvalidLimit(t *testing.T) {
+	for _, limit := range []string{"0", "101", "ten"} {
+		handler := httphandler.New()
+		req := httptest.NewRequest(http.MethodGet, "/list?status=active&limit="+limit, nil)
+		w := httptest.NewRecorder()
+
+		handler.List(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("wrong status code for limit %v, want %v got %v", limit, http.StatusBadRequest, w.Code)
+		}
+		shouldContain := "invalid limit: must be between 1 and 100"
+		if !strings.Contains(w.Body.String(), shouldContain) {
+			t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+		}
+	}
+}
+
+func TestListErrCursor(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrCursor,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/list?status=active&cursor=stale", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := customerror.ErrCursor.Error()
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestListInvalidSort(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/list?status=active&sort=title", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "invalid sort: must be one of id, created_at, updated_at, created_by, updated_by, deleted_at, priority, due_at"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestListInvalidTimestamp(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/list?status=active&created_after=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "invalid created_after: must be an RFC3339 timestamp"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
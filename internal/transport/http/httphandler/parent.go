@@ -0,0 +1,61 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Task
+// @Summary Set Parent Task.
+// @Description This endpoint is used for making a task a subtask of another one, or a top level task again when parent_id is 0.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SetParentRequest true "Set Parent Request Body. Take ID and the ID of the parent task"
+// @Success 200 {object} dto.TaskResponse "Success Response Body. The task with its new parent."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request body."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. One of the tasks does not exist."
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. The link would create a cycle."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/parent [post]
+func (h *httpHandler) SetParent(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Validate Request
+	resp, err := basehttphandler.Validate[dto.SetParentRequest](r)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp.(dto.SetParentRequest).TaskJobMapper(&req)
+	req.JOB = "PARENT"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler SetParent service.SetParent", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
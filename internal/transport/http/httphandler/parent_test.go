@@ -0,0 +1,40 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestSetParentInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPut, "/parent", nil)
+	w := httptest.NewRecorder()
+
+	handler.SetParent(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestSetParentErrCycle(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrCycle,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/parent", strings.NewReader(`{"id":1,"parent_id":2}`))
+	w := httptest.NewRecorder()
+
+	handler.SetParent(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("wrong status code, want %v got %v", http.StatusConflict, w.Code)
+	}
+}
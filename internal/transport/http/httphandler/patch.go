@@ -0,0 +1,105 @@
+package httphandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Task
+// @Summary Task Patch.
+// @Description This endpoint is used for changing some fields of a task without sending the others. The body is a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902), told apart by the Content-Type, applied to the title, description, status, priority, due_at and assignee of the task. The result is validated like the body of /update. The task is locked while the patch is applied, so concurrent patches do not overwrite each other. Bodies are limited to 64 KiB.
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id query integer true "Task ID to patch"
+// @Param request body object true "Patch Request Body. A merge patch object or an array of patch operations"
+// @Success 200 {object} dto.TaskResponse "Success Response Body. The patched task."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters or patch."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response"
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. A test operation failed, the status change is not allowed by the workflow, or the task is blocked by open tasks."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body is too large."
+// @Failure 415 {object} basehttphandler.Problem "Error Unsupported Media Type Response. The body is not a patch."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The patched task is invalid."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server Response"
+// @Deprecated
+// @Router /task/patch [patch]
+func (h *httpHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPatch {
+		h.MethodNotAllowed(w, r, http.MethodPatch)
+		return
+	}
+	// @Step: Check Query Params
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil || id <= 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	// @Step: Validate Request
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != dto.MergePatch && mediaType != dto.JSONPatch {
+		h.Fail(w, r, http.StatusUnsupportedMediaType, "body must be "+dto.MergePatch+" or "+dto.JSONPatch)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, dto.MaxPatchSize)
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			h.Fail(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(patch) == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "request body is empty")
+		return
+	}
+	patchReq := dto.PatchTaskRequest{
+		ID:    uint(id),
+		Type:  mediaType,
+		Patch: patch,
+	}
+	if err := basehttphandler.NewValidator().Struct(patchReq); err != nil {
+		h.Fail(w, r, http.StatusBadRequest, basehttphandler.ValidationError(patchReq, err).Error())
+		return
+	}
+	patchReq.TaskJobMapper(&req)
+	req.JOB = "PATCH"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		if errors.Is(err, customerror.ErrPatched) {
+			// The detail names the fields of the result that are invalid.
+			p, _ := basehttphandler.ErrorProblem(r, err)
+			p.Detail = basehttphandler.ValidationError(dto.UpdateTaskRequest{}, err).Error()
+			basehttphandler.WriteProblem(w, p)
+			return
+		}
+		h.Error(w, r, "httphandler Patch service.Patch", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
@@ -0,0 +1,137 @@
+package httphandler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestPatchInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPut, "/patch?id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Patch(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestPatchInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		status      int
+		contains    string
+	}{
+		{"missing id", "/patch", dto.MergePatch, `{}`, http.StatusBadRequest, "invalid query parameters"},
+		{"invalid id", "/patch?id=x", dto.MergePatch, `{}`, http.StatusBadRequest, "invalid query parameters"},
+		{"plain json", "/patch?id=1", "application/json", `{}`, http.StatusUnsupportedMediaType, "body must be"},
+		{"empty body", "/patch?id=1", dto.JSONPatch, ``, http.StatusBadRequest, "request body is empty"},
+		{"too large", "/patch?id=1", dto.MergePatch, `{"title":"` + strings.Repeat("a", dto.MaxPatchSize) + `"}`, http.StatusRequestEntityTooLarge, "too large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New(
+				httphandler.WithPool(&mockTaskWorker{
+					onSubmit: func(models.TaskJobModel) {
+						t.Errorf("expected no job to be submitted")
+					},
+				}),
+			)
+			req := httptest.NewRequest(http.MethodPatch, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			handler.Patch(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("wrong status code, want %v got %v", tt.status, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("wrong body message, want %v got %v", tt.contains, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPatchSubmitsPatch(t *testing.T) {
+	var job models.TaskJobModel
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.TaskResponse{ID: 7, Status: "done"},
+			onSubmit: func(j models.TaskJobModel) {
+				job = j
+			},
+		}),
+	)
+	body := `[{"op":"replace","path":"/status","value":"done"}]`
+	req := httptest.NewRequest(http.MethodPatch, "/patch?id=7", strings.NewReader(body))
+	req.Header.Set("Content-Type", dto.JSONPatch+"; charset=utf-8")
+	w := httptest.NewRecorder()
+
+	handler.Patch(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if job.JOB != "PATCH" || job.ID != 7 || job.PatchType != dto.JSONPatch || string(job.Patch) != body {
+		t.Errorf("wrong job, got %+v", job)
+	}
+	if !strings.Contains(w.Body.String(), `"status":"done"`) {
+		t.Errorf("wrong body message, got %v", w.Body.String())
+	}
+}
+
+func TestPatchErrors(t *testing.T) {
+	invalid := basehttphandler.NewValidator().Struct(dto.UpdateTaskRequest{ID: 1, Description: "description"})
+	tests := []struct {
+		err      error
+		status   int
+		contains string
+	}{
+		{context.DeadlineExceeded, http.StatusGatewayTimeout, "context deadline exceeded"},
+		{customerror.ErrPatch, http.StatusBadRequest, "Invalid patch"},
+		{customerror.ErrStatus, http.StatusBadRequest, "Unknown status"},
+		{customerror.ErrPatchTest, http.StatusConflict, "Patch test failed"},
+		{customerror.ErrTransition, http.StatusConflict, "Illegal status transition"},
+		{customerror.ErrBlocked, http.StatusConflict, "Task is blocked"},
+		{customerror.ErrIDNotFound, http.StatusNotFound, "ID not found"},
+		{customerror.ErrUpdate, http.StatusInternalServerError, "Error while updating"},
+		{fmt.Errorf("%w: %w", customerror.ErrPatched, invalid), http.StatusUnprocessableEntity, "invalid title: is required"},
+		{errors.New("boom"), http.StatusInternalServerError, `"detail":"Internal Server Error"`},
+	}
+	for _, tt := range tests {
+		handler := httphandler.New(
+			httphandler.WithLogger(logger),
+			httphandler.WithPool(&mockTaskWorker{
+				submitErr: fmt.Errorf("service.Patch: %w", tt.err),
+			}),
+		)
+		req := httptest.NewRequest(http.MethodPatch, "/patch?id=1", strings.NewReader(`{"status":"done"}`))
+		req.Header.Set("Content-Type", dto.MergePatch)
+		w := httptest.NewRecorder()
+
+		handler.Patch(w, req)
+
+		if w.Code != tt.status {
+			t.Errorf("%v: wrong status code, want %v got %v", tt.err, tt.status, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), tt.contains) {
+			t.Errorf("%v: wrong body message, want %v got %v", tt.err, tt.contains, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Trash
+// @Summary Purge Deleted Task by ID.
+// @Description This endpoint is used for permanently removing a deleted task from the trash.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id query integer true "Task ID required to purge"
+// @Success 200 {object} string "Success Response Body Purged Successfully."
+// @Failure 400 {object} basehttphandler.Problem "Bad Request Response. Invalid request parameters."
+// @Failure 404 {object} basehttphandler.Problem "Not Found Response. No deleted task found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Internal Server Error. Server encountered an error."
+// @Router /task/purge [delete]
+func (h *httpHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodDelete {
+		h.MethodNotAllowed(w, r, http.MethodDelete)
+		return
+	}
+	// @Step: Check Query Params
+	if len(r.URL.Query()) == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters required")
+		return
+	}
+	_id := r.URL.Query().Get("id")
+	id, err := strconv.Atoi(_id)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	if id == 0 {
+		h.Fail(w, r, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	req.ID = uint(id)
+	req.JOB = "PURGE"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	if _, err = h.pool.Submit(req); err != nil {
+		h.Error(w, r, "httphandler Purge service.Purge", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, constant.PurgedSuccessfully),
+	)
+}
@@ -0,0 +1,86 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestPurgeInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/purge?id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Purge(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+	shouldContain := "method GET not allowed"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestPurgeInvalidQueryParam(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodDelete, "/purge?id=invalid", nil)
+	w := httptest.NewRecorder()
+
+	handler.Purge(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "invalid query parameters"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestPurgeErrPurge(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrPurge,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodDelete, "/purge?id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Purge(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
+	}
+	assertProblem(t, w, http.StatusInternalServerError, "task.purge_failed", customerror.ErrPurge.Error())
+}
+
+func TestPurgeSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodDelete, "/purge?id=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Purge(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.Response(http.StatusOK, constant.PurgedSuccessfully))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
@@ -0,0 +1,71 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Task
+// @Summary Search Tasks.
+// @Description This endpoint is used for full-text searching the titles and descriptions of tasks. Hits are ranked by relevance and the matched words are highlighted with <mark> tags.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param 	q query string true "Words to search for"
+// @Param 	limit query integer false "Page size, at most 100" default(50)
+// @Param 	cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} util.PageResponseData{data=[]dto.TaskSearchHitResponse} "Success Response Body. One page of the hits, best first."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/search [get]
+func (h *httpHandler) Search(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	q := r.URL.Query()
+	searchReq := dto.SearchTaskRequest{
+		Query:  q.Get("q"),
+		Cursor: q.Get("cursor"),
+	}
+	limit, err := parseLimit(q.Get("limit"))
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	searchReq.Limit = limit
+	if err := basehttphandler.NewValidator().Struct(searchReq); err != nil {
+		h.Fail(w, r, http.StatusBadRequest, basehttphandler.ValidationError(searchReq, err).Error())
+		return
+	}
+	searchReq.TaskJobMapper(&req)
+	req.JOB = "SEARCH"
+	req.Context = ctx
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Search service.Search", err)
+		return
+	}
+	// @Step: Return Success Response
+	page, ok := res.(dto.TaskSearchResponse)
+	if !ok {
+		h.Error(w, r, "httphandler Search", customerror.ErrUnknown)
+		return
+	}
+	h.JSON(w,
+		http.StatusOK,
+		util.PageResponse(http.StatusOK, page.Hits, page.Total, page.NextCursor),
+	)
+}
@@ -0,0 +1,100 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestSearchInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/search?q=invoice", nil)
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestSearchQueryRequired(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "invalid q: is required"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestSearchErrCursor(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrCursor,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/search?q=invoice&cursor=stale", nil)
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSearchSuccess(t *testing.T) {
+	resp := dto.TaskSearchResponse{
+		Hits: []dto.TaskSearchHitResponse{
+			{
+				Task: dto.TaskResponse{
+					ID:     4,
+					Title:  "Pay invoice 4711",
+					Status: "todo",
+				},
+				Score: 1.5,
+				Highlights: dto.TaskHighlights{
+					Title: "Pay <mark>invoice</mark> <mark>4711</mark>",
+				},
+			},
+		},
+		Total:      2,
+		NextCursor: "next",
+	}
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: resp,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/search?q=invoice+4711&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.PageResponse(http.StatusOK, resp.Hits, resp.Total, resp.NextCursor))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	}
+}
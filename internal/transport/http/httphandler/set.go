@@ -2,15 +2,11 @@ package httphandler
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"net/http"
 
-	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
 	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
-	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/constant"
 	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
 )
 
@@ -20,40 +16,41 @@ import (
 // @Accept			json
 // @Produce			json
 // @Security		BearerAuth
+// @Param 			Idempotency-Key header string false "Key making retries safe. A retry with the same key and body replays the first response."
 // @Param 			request body dto.SetTaskRequest true "Task Set Request Body"
 // @Success 		200 {object} dto.TaskResponse "Success Response Body"
-// @Failure 		400 {object} util.ErrorResponse "Error Bad Request Response"
-// @Failure 		404 {object} util.ErrorResponse "Error Not Found Response"
-// @Failure 		500 {object} util.ErrorResponse "Error Internal Server"
-// @Router 			/set [post]
+// @Failure 		400 {object} basehttphandler.Problem "Error Bad Request Response"
+// @Failure 		404 {object} basehttphandler.Problem "Error Not Found Response"
+// @Failure 		409 {object} basehttphandler.Problem "Error Conflict Response. New tasks must start in the initial status of the workflow, or a request with the same Idempotency-Key is in progress."
+// @Failure 		413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body is larger than 64 KiB."
+// @Failure 		422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The Idempotency-Key was used for another request."
+// @Failure 		500 {object} basehttphandler.Problem "Error Internal Server"
+// @Deprecated
+// @Router 			/task/set [post]
 func (h *httpHandler) Set(w http.ResponseWriter, r *http.Request) {
+	h.set(w, r, http.StatusOK)
+}
+
+// set creates a task, answering with status on success.
+func (h *httpHandler) set(w http.ResponseWriter, r *http.Request, status int) {
 	var (
 		req models.TaskJobModel
 	)
-	ctx, cancel := context.WithTimeout(context.Background(), h.CancelTimeout)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
 	defer cancel()
 	if r.Method != http.MethodPost {
-		h.JSON(
-			w,
-			http.StatusMethodNotAllowed,
-			fmt.Sprintf(constant.ErrMethodNotAllowed, r.Method),
-		)
+		h.MethodNotAllowed(w, r, http.MethodPost)
 		return
 	}
 	if len(r.URL.Query()) > 0 {
-		h.JSON(w,
-			http.StatusBadRequest,
-			util.BasicError("query parameters not required", http.StatusBadRequest),
-		)
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
 		return
 	}
 	// @Step: Validate Request
+	r.Body = http.MaxBytesReader(w, r.Body, dto.MaxTaskSize)
 	resp, err := basehttphandler.Validate[dto.SetTaskRequest](r)
 	if err != nil {
-		h.JSON(w,
-			http.StatusBadRequest,
-			util.BasicError(err.Error(), http.StatusBadRequest),
-		)
+		h.Fail(w, r, bodyErrorStatus(err), err.Error())
 		return
 	}
 	resp.(dto.SetTaskRequest).TaskJobMapper(&req)
@@ -63,51 +60,12 @@ func (h *httpHandler) Set(w http.ResponseWriter, r *http.Request) {
 	// @Step: Submit to Pool
 	res, err := h.pool.Submit(req)
 	if err != nil {
-		// @Step: Handle Errors
-		if errors.Is(err, context.DeadlineExceeded) {
-			h.JSON(w,
-				http.StatusGatewayTimeout,
-				util.BasicError(constant.ErrContextDeadline, http.StatusGatewayTimeout),
-			)
-			return
-		}
-		var cusErr *customerror.Error
-		if errors.As(err, &cusErr) {
-			clientMessage := cusErr.Message
-			if cusErr.Data != nil {
-				data, ok := cusErr.Data.(string)
-				if ok {
-					clientMessage = clientMessage + ", " + data
-				}
-			}
-			if cusErr.Loggable {
-				h.Logger.Error("httphandler Set service.Set", "err", clientMessage)
-			}
-
-			if cusErr == customerror.ErrIDExists {
-				h.JSON(w,
-					http.StatusNotFound,
-					util.BasicError(clientMessage, http.StatusNotFound),
-				)
-				return
-			}
-			if cusErr == customerror.ErrSet {
-				h.JSON(w,
-					http.StatusNotFound,
-					util.BasicError(clientMessage, http.StatusNotFound),
-				)
-				return
-			}
-		}
-		h.JSON(w,
-			http.StatusInternalServerError,
-			util.BasicError(err.Error(), http.StatusInternalServerError),
-		)
+		h.Error(w, r, "httphandler Set service.Set", err)
 		return
 	}
 	// @Step: Return Success Response
 	h.JSON(w,
-		http.StatusOK,
-		util.Response(http.StatusOK, res),
+		status,
+		util.Response(status, res),
 	)
 }
@@ -87,6 +87,23 @@ func TestSetInvalidBody(t *testing.T) {
 	}
 }
 
+func TestSetInvalidPriority(t *testing.T) {
+	handler := httphandler.New()
+	body := `{"id":1,"title":"test","description":"test","priority":9,"due_at":"2024-01-01T00:00:00Z","assignee":"carol"}`
+	req := httptest.NewRequest(http.MethodPost, "/set", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Set(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "'Priority' failed on the 'max' tag"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
 func TestSetEmptyBody(t *testing.T) {
 	handler := httphandler.New()
 	req := httptest.NewRequest(http.MethodPost, "/set", nil)
@@ -103,6 +120,19 @@ func TestSetEmptyBody(t *testing.T) {
 	}
 }
 
+func TestSetBodyTooLarge(t *testing.T) {
+	handler := httphandler.New()
+	body := `{"id":1,"title":"test","description":"` + strings.Repeat("a", dto.MaxTaskSize) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/set", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Set(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("wrong status code, want %v got %v", http.StatusRequestEntityTooLarge, w.Code)
+	}
+}
+
 func TestSetErrUnknown(t *testing.T) {
 	handler := httphandler.New(
 		httphandler.WithLogger(logger),
@@ -119,13 +149,7 @@ func TestSetErrUnknown(t *testing.T) {
 	if w.Code != http.StatusInternalServerError {
 		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrUnknown.Error(), http.StatusInternalServerError))
-	if err != nil {
-		t.Errorf("error while marshalling error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
-	}
+	assertProblem(t, w, http.StatusInternalServerError, "internal.unknown", customerror.ErrUnknown.Error())
 }
 
 func TestSetErrIDExists(t *testing.T) {
@@ -141,16 +165,7 @@ func TestSetErrIDExists(t *testing.T) {
 
 	handler.Set(w, req)
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
-	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrIDExists.Error(), http.StatusNotFound))
-	if err != nil {
-		t.Errorf("error while marshalling error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
-	}
+	assertProblem(t, w, http.StatusConflict, "task.id_exists", customerror.ErrIDExists.Error())
 }
 
 func TestSetErrSet(t *testing.T) {
@@ -166,16 +181,10 @@ func TestSetErrSet(t *testing.T) {
 
 	handler.Set(w, req)
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
-	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrSet.Error(), http.StatusNotFound))
-	if err != nil {
-		t.Errorf("error while marshalling error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
 	}
+	assertProblem(t, w, http.StatusInternalServerError, "task.set_failed", customerror.ErrSet.Error())
 }
 
 func TestSetSuccess(t *testing.T) {
@@ -0,0 +1,81 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestSnapshotInvalidTimestamp(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/snapshot?id=1&at=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	handler.Snapshot(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "invalid at: must be an RFC3339 timestamp"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestSnapshotErrIDNotFound(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrIDNotFound,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/snapshot?id=1&at=2024-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	handler.Snapshot(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	}
+	assertProblem(t, w, http.StatusNotFound, "task.not_found", customerror.ErrIDNotFound.Error())
+}
+
+func TestSnapshotSuccess(t *testing.T) {
+	resp := util.ResponseData{
+		Data: dto.TaskResponse{
+			ID:          1,
+			Status:      "active",
+			Description: "test",
+			Title:       "test",
+		},
+		Status: http.StatusOK,
+	}
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: resp,
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/snapshot?id=1&at=2024-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	handler.Snapshot(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.Response(http.StatusOK, resp))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
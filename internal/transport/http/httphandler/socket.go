@@ -0,0 +1,469 @@
+package httphandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"golang.org/x/time/rate"
+)
+
+// Limits of a socket connection.
+const (
+	// socketMaxMessage is the largest message a client may send, in bytes.
+	socketMaxMessage = 64 << 10
+	// socketInFlight is the number of requests of a connection served at
+	// once; reading waits while they are all busy.
+	socketInFlight = 8
+	// socketRate and socketBurst limit the requests per second of a
+	// connection. Requests over the limit are answered with 429.
+	socketRate  = 20
+	socketBurst = 40
+	// socketPingInterval is how often the server pings the client, which
+	// is dropped when it has not answered within socketPongWait.
+	socketPingInterval = 30 * time.Second
+	socketPongWait     = 60 * time.Second
+	socketWriteWait    = 10 * time.Second
+)
+
+// SocketProtocol is the subprotocol carrying the token of a socket. Browsers
+// cannot set the Authorization header of a WebSocket handshake, so they
+// offer the subprotocols SocketProtocol and the token instead, e.g.
+// new WebSocket(url, ["bearer", token]); the server selects SocketProtocol.
+const SocketProtocol = "bearer"
+
+// Operations of socket requests.
+const (
+	SocketSet         = "set"
+	SocketGet         = "get"
+	SocketUpdate      = "update"
+	SocketDelete      = "delete"
+	SocketList        = "list"
+	SocketSubscribe   = "subscribe"
+	SocketUnsubscribe = "unsubscribe"
+)
+
+// Operations of the messages a socket sends unasked.
+const (
+	SocketEvent = "event"
+	SocketReset = "reset"
+)
+
+// SocketRequest is a message sent to Socket. ID is chosen by the client and
+// sent back with the reply; Data depends on Op:
+//
+//   - set and update: the body of /task/set and /task/update
+//   - get and delete: {"id": 5}
+//   - list: the query parameters of /task/list, e.g. {"status": "todo", "limit": 10}
+//   - subscribe: {"topic": "tasks", "last_event_id": 12}, last_event_id being optional
+//   - unsubscribe: {"topic": "tasks"}
+type SocketRequest struct {
+	ID   string          `json:"id"`
+	Op   string          `json:"op"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// SocketReply is a message sent by Socket. A reply to a request carries its
+// ID and Op, and the status and body the matching HTTP endpoint answers
+// with. An event carries the topic it was subscribed on and the event as
+// its body, like the data of /task/events. A reset tells that events of the
+// topic were missed; the subscription is over and the tasks should be read
+// again before subscribing anew.
+type SocketReply struct {
+	ID     string `json:"id,omitempty"`
+	Op     string `json:"op"`
+	Topic  string `json:"topic,omitempty"`
+	Status int    `json:"status,omitempty"`
+	Body   any    `json:"body,omitempty"`
+}
+
+// socketSubscription is the data of subscribe and unsubscribe requests.
+type socketSubscription struct {
+	Topic       string `json:"topic"`
+	LastEventID uint64 `json:"last_event_id"`
+}
+
+// @Tags Task
+// @Summary Task Socket.
+// @Description This endpoint upgrades to a WebSocket carrying JSON messages. Clients send {"id", "op", "data"} requests with op set, get, update, delete or list, answered by {"id", "op", "status", "body"} with the status and body of the matching HTTP endpoint; requests are served concurrently and replies may come in any order. The op subscribe with data {"topic", "last_event_id"} pushes the events of /task/events on the topic as {"op": "event", "topic", "body"} messages, until unsubscribe or a {"op": "reset", "topic"} message. Topics are "tasks" for every task, "tasks/{id}" for one task and "status/{status}" for the tasks in a status. A connection is limited to 20 requests per second. Browsers, which cannot set the Authorization header, send the token as the subprotocols "bearer" and the token, e.g. new WebSocket(url, ["bearer", token]). Pages of origins other than the server and the ones of ALLOWED_ORIGINS are refused.
+// @Security BearerAuth
+// @Success 101 "Switching Protocols"
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Not a WebSocket handshake."
+// @Failure 403 {object} basehttphandler.Problem "Error Forbidden Response. The origin of the page is not allowed."
+// @Failure 405 {object} basehttphandler.Problem "Error Method Not Allowed Response"
+// @Router /task/ws [get]
+func (h *httpHandler) Socket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{SocketProtocol},
+		CheckOrigin:  h.checkOrigin,
+		Error: func(w http.ResponseWriter, r *http.Request, status int, reason error) {
+			h.Fail(w, r, status, reason.Error())
+		},
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	s := &socket{
+		h:       h,
+		r:       r,
+		conn:    conn,
+		ctx:     ctx,
+		cancel:  cancel,
+		out:     make(chan SocketReply, socketInFlight),
+		limiter: rate.NewLimiter(socketRate, socketBurst),
+		subs:    make(map[string]context.CancelFunc),
+	}
+	s.serve()
+}
+
+// checkOrigin allows the handshakes of clients other than browsers, which
+// send no Origin, of the pages of the server and of the allowed origins.
+func (h *httpHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range h.origins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// socket is one WebSocket connection. Replies are written by a single
+// writer, since a connection supports one writer at a time.
+type socket struct {
+	h       *httpHandler
+	r       *http.Request
+	conn    *websocket.Conn
+	ctx     context.Context
+	cancel  context.CancelFunc
+	out     chan SocketReply
+	limiter *rate.Limiter
+	wg      sync.WaitGroup
+
+	mu   sync.Mutex
+	subs map[string]context.CancelFunc
+}
+
+func (s *socket) serve() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.write()
+	}()
+	s.read()
+	s.cancel()
+	s.wg.Wait()
+	<-done
+}
+
+// read serves the requests of the client until the connection fails.
+func (s *socket) read() {
+	s.conn.SetReadLimit(socketMaxMessage)
+	_ = s.conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+	inFlight := make(chan struct{}, socketInFlight)
+	for {
+		_, msg, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req SocketRequest
+		if err := json.Unmarshal(msg, &req); err != nil {
+			s.reply(req, s.problem(http.StatusBadRequest, "invalid message: "+err.Error()))
+			continue
+		}
+		if !s.limiter.Allow() {
+			s.reply(req, s.problem(http.StatusTooManyRequests, ""))
+			continue
+		}
+		select {
+		case inFlight <- struct{}{}:
+		case <-s.ctx.Done():
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { <-inFlight }()
+			s.reply(req, s.serveRequest(req))
+		}()
+	}
+}
+
+// write sends the replies and pings the client until the connection is
+// done, then closes it.
+func (s *socket) write() {
+	ping := time.NewTicker(socketPingInterval)
+	defer func() {
+		ping.Stop()
+		s.cancel()
+		s.conn.Close()
+	}()
+	for {
+		select {
+		case <-s.ctx.Done():
+			_ = s.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(socketWriteWait))
+			return
+		case reply := <-s.out:
+			_ = s.conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			if err := s.conn.WriteJSON(reply); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// send hands reply to the writer, unless the connection is done.
+func (s *socket) send(reply SocketReply) {
+	select {
+	case s.out <- reply:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *socket) reply(req SocketRequest, reply SocketReply) {
+	reply.ID = req.ID
+	reply.Op = req.Op
+	s.send(reply)
+}
+
+func (s *socket) problem(status int, detail string) SocketReply {
+	return SocketReply{Status: status, Body: basehttphandler.NewProblem(s.r, status, "", detail)}
+}
+
+// serveRequest serves req with the handler of the matching HTTP endpoint.
+func (s *socket) serveRequest(req SocketRequest) SocketReply {
+	switch req.Op {
+	case SocketSet:
+		return s.forward(http.MethodPost, nil, req.Data, s.h.Set)
+	case SocketUpdate:
+		return s.forward(http.MethodPut, nil, req.Data, s.h.Update)
+	case SocketGet, SocketDelete:
+		var data struct {
+			ID uint `json:"id"`
+		}
+		if err := json.Unmarshal(req.Data, &data); err != nil || data.ID == 0 {
+			return s.problem(http.StatusBadRequest, "invalid data: id must be a positive integer")
+		}
+		if req.Op == SocketGet {
+			return s.forward(http.MethodGet, idQuery(data.ID), nil, s.h.Get)
+		}
+		return s.forward(http.MethodDelete, idQuery(data.ID), nil, s.h.Delete)
+	case SocketList:
+		query, err := socketQuery(req.Data)
+		if err != nil {
+			return s.problem(http.StatusBadRequest, err.Error())
+		}
+		return s.forward(http.MethodGet, query, nil, s.h.list)
+	case SocketSubscribe:
+		return s.subscribe(req.Data)
+	case SocketUnsubscribe:
+		return s.unsubscribe(req.Data)
+	default:
+		return s.problem(http.StatusBadRequest, "unknown op '"+req.Op+"'")
+	}
+}
+
+// forward serves a method request with query and body with handler,
+// returning its status and body as the reply.
+func (s *socket) forward(method string, query url.Values, body []byte, handler http.HandlerFunc) SocketReply {
+	r := rewrite(s.r, method, query)
+	r = r.WithContext(s.ctx)
+	r.Body = http.NoBody
+	if body != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+	}
+	r.Header.Set("Content-Type", "application/json")
+	w := &socketWriter{header: make(http.Header)}
+	handler(w, r)
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	reply := SocketReply{Status: w.status}
+	if w.body.Len() > 0 {
+		reply.Body = json.RawMessage(w.body.Bytes())
+	}
+	return reply
+}
+
+// subscribe starts pushing the events of a topic.
+func (s *socket) subscribe(data json.RawMessage) SocketReply {
+	var sub socketSubscription
+	if err := json.Unmarshal(data, &sub); err != nil {
+		return s.problem(http.StatusBadRequest, "invalid data: "+err.Error())
+	}
+	filter, err := topicFilter(sub.Topic)
+	if err != nil {
+		return s.problem(http.StatusBadRequest, err.Error())
+	}
+	if s.h.events == nil {
+		return s.problem(http.StatusServiceUnavailable, "events are not configured")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subs[sub.Topic]; ok {
+		return s.problem(http.StatusConflict, "already subscribed to '"+sub.Topic+"'")
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.subs[sub.Topic] = cancel
+	subscription := s.h.events.Subscribe(filter, sub.LastEventID)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.push(ctx, sub.Topic, subscription)
+	}()
+	return SocketReply{Status: http.StatusOK}
+}
+
+// unsubscribe stops pushing the events of a topic.
+func (s *socket) unsubscribe(data json.RawMessage) SocketReply {
+	var sub socketSubscription
+	if err := json.Unmarshal(data, &sub); err != nil {
+		return s.problem(http.StatusBadRequest, "invalid data: "+err.Error())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cancel, ok := s.subs[sub.Topic]
+	if !ok {
+		return s.problem(http.StatusNotFound, "not subscribed to '"+sub.Topic+"'")
+	}
+	cancel()
+	delete(s.subs, sub.Topic)
+	return SocketReply{Status: http.StatusOK}
+}
+
+// push sends the events of subscription on topic until ctx is done or
+// events were missed.
+func (s *socket) push(ctx context.Context, topic string, subscription *events.Subscription) {
+	defer subscription.Close()
+	reset := func() {
+		s.mu.Lock()
+		if cancel, ok := s.subs[topic]; ok {
+			cancel()
+			delete(s.subs, topic)
+		}
+		s.mu.Unlock()
+		s.send(SocketReply{Op: SocketReset, Topic: topic})
+	}
+	if subscription.Missed {
+		reset()
+		return
+	}
+	for _, e := range subscription.Replay {
+		s.send(SocketReply{Op: SocketEvent, Topic: topic, Body: e})
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-subscription.Events():
+			if !ok {
+				reset()
+				return
+			}
+			s.send(SocketReply{Op: SocketEvent, Topic: topic, Body: e})
+		}
+	}
+}
+
+// topicFilter returns the filter of the events of topic.
+func topicFilter(topic string) (events.Filter, error) {
+	switch {
+	case topic == "tasks":
+		return events.Filter{}, nil
+	case strings.HasPrefix(topic, "tasks/"):
+		id, err := strconv.ParseUint(strings.TrimPrefix(topic, "tasks/"), 10, 0)
+		if err == nil && id != 0 {
+			return events.Filter{IDs: []uint{uint(id)}}, nil
+		}
+	case strings.HasPrefix(topic, "status/"):
+		if status := strings.TrimPrefix(topic, "status/"); status != "" {
+			return events.Filter{Statuses: []string{status}}, nil
+		}
+	}
+	return events.Filter{}, fmt.Errorf("invalid topic '%s': must be tasks, tasks/{id} or status/{status}", topic)
+}
+
+// socketQuery turns the data of a list request into query parameters.
+// Arrays become repeated parameters.
+func socketQuery(data json.RawMessage) (url.Values, error) {
+	query := url.Values{}
+	if len(data) == 0 {
+		return query, nil
+	}
+	var params map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&params); err != nil {
+		return nil, fmt.Errorf("invalid data: must be an object of query parameters")
+	}
+	for key, v := range params {
+		values, ok := v.([]any)
+		if !ok {
+			values = []any{v}
+		}
+		for _, value := range values {
+			query.Add(key, fmt.Sprint(value))
+		}
+	}
+	return query, nil
+}
+
+// socketWriter keeps the response of a handler serving a socket request.
+type socketWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *socketWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *socketWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *socketWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
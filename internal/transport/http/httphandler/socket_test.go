@@ -0,0 +1,220 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/events"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+// socketReply is a SocketReply as read by a client.
+type socketReply struct {
+	ID     string          `json:"id"`
+	Op     string          `json:"op"`
+	Topic  string          `json:"topic"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+func dialSocket(t *testing.T, handler httphandler.HTTPHandler) *websocket.Conn {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(handler.Socket))
+	t.Cleanup(server.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func roundTrip(t *testing.T, conn *websocket.Conn, req httphandler.SocketRequest) socketReply {
+	t.Helper()
+	if err := conn.WriteJSON(req); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	return readReply(t, conn)
+}
+
+func readReply(t *testing.T, conn *websocket.Conn) socketReply {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var reply socketReply
+	if err := conn.ReadJSON(&reply); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return reply
+}
+
+func TestSocketOperations(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    httphandler.SocketRequest
+		err    error
+		status int
+		body   string
+	}{
+		{"get", httphandler.SocketRequest{ID: "1", Op: httphandler.SocketGet, Data: json.RawMessage(`{"id":5}`)}, nil, http.StatusOK, `"id":5`},
+		{"get missing", httphandler.SocketRequest{ID: "2", Op: httphandler.SocketGet, Data: json.RawMessage(`{"id":5}`)}, customerror.ErrIDNotFound, http.StatusNotFound, `"code":"task.not_found"`},
+		{"get without id", httphandler.SocketRequest{ID: "3", Op: httphandler.SocketGet}, nil, http.StatusBadRequest, `"code":"request.invalid"`},
+		{"set", httphandler.SocketRequest{ID: "4", Op: httphandler.SocketSet, Data: json.RawMessage(`{"id":5,"title":"title","description":"description"}`)}, nil, http.StatusOK, `"id":5`},
+		{"set invalid", httphandler.SocketRequest{ID: "5", Op: httphandler.SocketSet, Data: json.RawMessage(`{"id":5}`)}, nil, http.StatusBadRequest, `"code":"request.invalid"`},
+		{"delete", httphandler.SocketRequest{ID: "6", Op: httphandler.SocketDelete, Data: json.RawMessage(`{"id":5}`)}, nil, http.StatusOK, ``},
+		{"unknown", httphandler.SocketRequest{ID: "7", Op: "purge"}, nil, http.StatusBadRequest, `unknown op`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New(
+				httphandler.WithLogger(logger),
+				httphandler.WithPool(&mockTaskWorker{
+					submitErr: tt.err,
+					response:  dto.TaskResponse{ID: 5},
+				}),
+			)
+			conn := dialSocket(t, handler)
+
+			reply := roundTrip(t, conn, tt.req)
+
+			if reply.ID != tt.req.ID || reply.Op != tt.req.Op {
+				t.Errorf("wrong correlation, want %v %v got %v %v", tt.req.ID, tt.req.Op, reply.ID, reply.Op)
+			}
+			if reply.Status != tt.status {
+				t.Errorf("wrong status, want %v got %v: %s", tt.status, reply.Status, reply.Body)
+			}
+			if !strings.Contains(string(reply.Body), tt.body) {
+				t.Errorf("wrong body, want %v got %s", tt.body, reply.Body)
+			}
+		})
+	}
+}
+
+func TestSocketList(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.TaskListResponse{Tasks: []dto.TaskResponse{{ID: 5}}, Total: 1},
+		}),
+	)
+	conn := dialSocket(t, handler)
+
+	reply := roundTrip(t, conn, httphandler.SocketRequest{ID: "1", Op: httphandler.SocketList, Data: json.RawMessage(`{"status":["todo","done"],"limit":10}`)})
+
+	if reply.Status != http.StatusOK || !strings.Contains(string(reply.Body), `"total":1`) {
+		t.Errorf("wrong reply, got %v %s", reply.Status, reply.Body)
+	}
+}
+
+func TestSocketSubscribe(t *testing.T) {
+	broker := events.NewBroker()
+	handler := httphandler.New(httphandler.WithEvents(broker))
+	conn := dialSocket(t, handler)
+
+	reply := roundTrip(t, conn, httphandler.SocketRequest{ID: "1", Op: httphandler.SocketSubscribe, Data: json.RawMessage(`{"topic":"tasks/2"}`)})
+	if reply.Status != http.StatusOK {
+		t.Fatalf("wrong status, want %v got %v: %s", http.StatusOK, reply.Status, reply.Body)
+	}
+	reply = roundTrip(t, conn, httphandler.SocketRequest{ID: "2", Op: httphandler.SocketSubscribe, Data: json.RawMessage(`{"topic":"tasks/2"}`)})
+	if reply.Status != http.StatusConflict {
+		t.Errorf("wrong status, want %v got %v", http.StatusConflict, reply.Status)
+	}
+
+	broker.Publish(events.Created, dto.TaskResponse{ID: 1})
+	broker.Publish(events.Updated, dto.TaskResponse{ID: 2})
+
+	reply = readReply(t, conn)
+	if reply.Op != httphandler.SocketEvent || reply.Topic != "tasks/2" {
+		t.Fatalf("wrong event, got %+v", reply)
+	}
+	var e events.Event
+	if err := json.Unmarshal(reply.Body, &e); err != nil || e.ID != 2 || e.Type != events.Updated {
+		t.Errorf("wrong event body, got %s", reply.Body)
+	}
+
+	reply = roundTrip(t, conn, httphandler.SocketRequest{ID: "3", Op: httphandler.SocketUnsubscribe, Data: json.RawMessage(`{"topic":"tasks/2"}`)})
+	if reply.Status != http.StatusOK {
+		t.Errorf("wrong status, want %v got %v", http.StatusOK, reply.Status)
+	}
+	reply = roundTrip(t, conn, httphandler.SocketRequest{ID: "4", Op: httphandler.SocketUnsubscribe, Data: json.RawMessage(`{"topic":"tasks/2"}`)})
+	if reply.Status != http.StatusNotFound {
+		t.Errorf("wrong status, want %v got %v", http.StatusNotFound, reply.Status)
+	}
+}
+
+func TestSocketInvalidTopic(t *testing.T) {
+	handler := httphandler.New(httphandler.WithEvents(events.NewBroker()))
+	conn := dialSocket(t, handler)
+
+	for _, topic := range []string{"", "task", "tasks/abc", "status/"} {
+		data, _ := json.Marshal(map[string]string{"topic": topic})
+		reply := roundTrip(t, conn, httphandler.SocketRequest{ID: topic, Op: httphandler.SocketSubscribe, Data: data})
+		if reply.Status != http.StatusBadRequest {
+			t.Errorf("%q: wrong status, want %v got %v", topic, http.StatusBadRequest, reply.Status)
+		}
+	}
+}
+
+func TestSocketNotUpgraded(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/task/ws", nil)
+	w := httptest.NewRecorder()
+
+	handler.Socket(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSocketOrigin(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithAllowedOrigins("https://app.example.com"),
+	)
+	server := httptest.NewServer(http.HandlerFunc(handler.Socket))
+	t.Cleanup(server.Close)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	tests := []struct {
+		name   string
+		origin string
+		status int
+	}{
+		{"same origin", server.URL, http.StatusSwitchingProtocols},
+		{"allowed origin", "https://app.example.com", http.StatusSwitchingProtocols},
+		{"other origin", "https://evil.example.com", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, res, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {tt.origin}})
+			if err == nil {
+				conn.Close()
+			}
+			if res == nil || res.StatusCode != tt.status {
+				t.Errorf("wrong status, want %v got %v: %v", tt.status, res, err)
+			}
+		})
+	}
+}
+
+func TestSocketProtocol(t *testing.T) {
+	handler := httphandler.New(httphandler.WithLogger(logger))
+	server := httptest.NewServer(http.HandlerFunc(handler.Socket))
+	t.Cleanup(server.Close)
+
+	dialer := websocket.Dialer{Subprotocols: []string{httphandler.SocketProtocol, "token"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != httphandler.SocketProtocol {
+		t.Errorf("wrong subprotocol, want %v got %v", httphandler.SocketProtocol, conn.Subprotocol())
+	}
+}
@@ -0,0 +1,60 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Task
+// @Summary Add Tags to Task.
+// @Description This endpoint is used for attaching tags to a task. Tags are case insensitive and tags the task already has are kept. A task carries 20 tags at most.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TagTaskRequest true "Tag Request Body. Take ID and the tags to add"
+// @Success 200 {object} dto.TaskResponse "Success Response Body. The task with its tags."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request body or tag, or too many tags."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No task found with the specified ID."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/tag [post]
+func (h *httpHandler) Tag(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Validate Request
+	resp, err := basehttphandler.Validate[dto.TagTaskRequest](r)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp.(dto.TagTaskRequest).TaskJobMapper(&req)
+	req.JOB = "TAG"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Tag service.Tag", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
@@ -0,0 +1,50 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestTagsInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/tags", nil)
+	w := httptest.NewRecorder()
+
+	handler.Tags(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestTagsSuccess(t *testing.T) {
+	resp := []dto.TagCountResponse{{Name: "backend", Count: 3}, {Name: "urgent", Count: 1}}
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: resp,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/tags", nil)
+	w := httptest.NewRecorder()
+
+	handler.Tags(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.Response(http.StatusOK, resp))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	}
+}
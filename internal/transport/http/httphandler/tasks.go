@@ -0,0 +1,286 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+)
+
+// TasksPath is the root of the resource oriented routes: the collection of
+// tasks and, below it, each task by its ID.
+const TasksPath = "/tasks"
+
+// Methods allowed on the collection and on a task.
+var (
+	tasksAllow = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodOptions}
+	taskAllow  = []string{
+		http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	}
+)
+
+// Tasks serves TasksPath and the paths of single tasks below it. Requests
+// are handed to the handlers of the legacy routes, with the ID of the path
+// in the form they take it, and their 200 replaced by the status of the
+// method: 201 for creating and 204 for changing or deleting a task.
+func (h *httpHandler) Tasks(w http.ResponseWriter, r *http.Request) {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, TasksPath), "/")
+	if rest == "" {
+		h.tasks(w, r)
+		return
+	}
+	id, err := strconv.ParseUint(rest, 10, 0)
+	if err != nil || id == 0 {
+		h.Fail(w, r, http.StatusNotFound, "resource not found")
+		return
+	}
+	h.task(w, r, uint(id))
+}
+
+func (h *httpHandler) tasks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.listTasks(w, r)
+	case http.MethodPost:
+		h.createTask(w, r)
+	default:
+		h.allow(w, r, tasksAllow)
+	}
+}
+
+func (h *httpHandler) task(w http.ResponseWriter, r *http.Request, id uint) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.getTask(w, r, id)
+	case http.MethodPut:
+		h.replaceTask(w, r, id)
+	case http.MethodPatch:
+		h.patchTask(w, r, id)
+	case http.MethodDelete:
+		h.deleteTask(w, r, id)
+	default:
+		h.allow(w, r, taskAllow)
+	}
+}
+
+// allow answers OPTIONS with the allowed methods, and any other method with
+// 405 naming them.
+func (h *httpHandler) allow(w http.ResponseWriter, r *http.Request, methods []string) {
+	if r.Method != http.MethodOptions {
+		h.MethodNotAllowed(w, r, methods...)
+		return
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Tags Task
+// @Summary List Tasks.
+// @Description This endpoint is used for listing tasks. It takes the query parameters of /task/list, none of which are required.
+// @Produce json
+// @Security BearerAuth
+// @Param 	status query []string false "Only tasks having one of these statuses, repeated or comma separated" collectionFormat(multi)
+// @Param 	limit query integer false "Page size"
+// @Param 	cursor query string false "Cursor of the page to return, the next_cursor of the previous page"
+// @Success 200 {object} util.PageResponseData "Success Response Body. One page of tasks."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 405 {object} basehttphandler.Problem "Error Method Not Allowed Response. The Allow header names the allowed methods."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /tasks [get]
+func (h *httpHandler) listTasks(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r)
+}
+
+// @Tags Task
+// @Summary Create Task.
+// @Description This endpoint is used for creating a new task. The Location header of the response is the path of the task.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key making retries safe. A retry with the same key and body replays the first response."
+// @Param request body dto.SetTaskRequest true "Task Set Request Body"
+// @Success 201 {object} dto.TaskResponse "Created Response Body"
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response"
+// @Failure 405 {object} basehttphandler.Problem "Error Method Not Allowed Response. The Allow header names the allowed methods."
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. New tasks must start in the initial status of the workflow, or a request with the same Idempotency-Key is in progress."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body is larger than 64 KiB."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The Idempotency-Key was used for another request."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server"
+// @Router /tasks [post]
+func (h *httpHandler) createTask(w http.ResponseWriter, r *http.Request) {
+	body, ok := h.readTask(w, r)
+	if !ok {
+		return
+	}
+	var created struct {
+		ID uint `json:"id"`
+	}
+	_ = json.Unmarshal(body, &created)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	rw := &restWriter{ResponseWriter: w, status: http.StatusCreated}
+	if created.ID != 0 {
+		rw.location = TasksPath + "/" + strconv.FormatUint(uint64(created.ID), 10)
+	}
+	h.set(rw, r, http.StatusCreated)
+}
+
+// @Tags Task
+// @Summary Get Task.
+// @Description This endpoint is used for retrieving a task based on its ID.
+// @Produce json
+// @Security BearerAuth
+// @Param id path integer true "Task ID"
+// @Success 200 {object} dto.TaskResponse "Success Response Body. Task details with the specified ID."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response. No task found with the specified ID."
+// @Failure 405 {object} basehttphandler.Problem "Error Method Not Allowed Response. The Allow header names the allowed methods."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /tasks/{id} [get]
+func (h *httpHandler) getTask(w http.ResponseWriter, r *http.Request, id uint) {
+	h.forward(w, r, http.MethodGet, idQuery(id), http.StatusOK, h.Get)
+}
+
+// @Tags Task
+// @Summary Replace Task.
+// @Description This endpoint is used for updating every field of a task. The body is the one of /task/update; its id may be left out and must otherwise be the one of the path.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key making retries safe. A retry with the same key and body replays the first response."
+// @Param id path integer true "Task ID"
+// @Param request body dto.UpdateTaskRequest true "Task Update Request Body"
+// @Success 204 "No Content. The task was updated."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response"
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response"
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. The status change is not allowed by the workflow, or the task is blocked by open tasks, or a request with the same Idempotency-Key is in progress."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body is larger than 64 KiB."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The Idempotency-Key was used for another request."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server Response"
+// @Router /tasks/{id} [put]
+func (h *httpHandler) replaceTask(w http.ResponseWriter, r *http.Request, id uint) {
+	body, ok := h.readTask(w, r)
+	if !ok {
+		return
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) == nil && fields != nil {
+		// The legacy body carries the ID; bodies that are not an object are
+		// passed on as they are, for Update to report.
+		if bodyID, ok := fields["id"]; ok {
+			var n uint
+			if json.Unmarshal(bodyID, &n) != nil || n != id {
+				h.Fail(w, r, http.StatusBadRequest, "id of the body does not match the path")
+				return
+			}
+		}
+		fields["id"], _ = json.Marshal(id)
+		body, _ = json.Marshal(fields)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	h.forward(w, r, http.MethodPut, nil, http.StatusNoContent, h.Update)
+}
+
+// @Tags Task
+// @Summary Patch Task.
+// @Description This endpoint is used for changing some fields of a task with a JSON Merge Patch or a JSON Patch, like /task/patch.
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path integer true "Task ID"
+// @Param request body object true "Patch Request Body. A merge patch object or an array of patch operations"
+// @Success 204 "No Content. The task was patched."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid patch."
+// @Failure 404 {object} basehttphandler.Problem "Error Not Found Response"
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. A test operation failed, the status change is not allowed by the workflow, or the task is blocked by open tasks."
+// @Failure 413 {object} basehttphandler.Problem "Error Request Entity Too Large Response. The body is too large."
+// @Failure 415 {object} basehttphandler.Problem "Error Unsupported Media Type Response. The body is not a patch."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The patched task is invalid."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server Response"
+// @Router /tasks/{id} [patch]
+func (h *httpHandler) patchTask(w http.ResponseWriter, r *http.Request, id uint) {
+	h.forward(w, r, http.MethodPatch, idQuery(id), http.StatusNoContent, h.Patch)
+}
+
+// @Tags Task
+// @Summary Delete Task.
+// @Description This endpoint is used for deleting a task based on its ID.
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key making retries safe. A retry with the same key and body replays the first response."
+// @Param id path integer true "Task ID"
+// @Success 204 "No Content. The task was deleted."
+// @Failure 404 {object} basehttphandler.Problem "Not Found Response. No task found with the specified ID."
+// @Failure 409 {object} basehttphandler.Problem "Error Conflict Response. A request with the same Idempotency-Key is in progress."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The Idempotency-Key was used for another request."
+// @Failure 500 {object} basehttphandler.Problem "Internal Server Error. Server encountered an error."
+// @Router /tasks/{id} [delete]
+func (h *httpHandler) deleteTask(w http.ResponseWriter, r *http.Request, id uint) {
+	h.forward(w, r, http.MethodDelete, idQuery(id), http.StatusNoContent, h.Delete)
+}
+
+// readTask reads the body of r, of dto.MaxTaskSize bytes at most, failing
+// the request when it cannot.
+func (h *httpHandler) readTask(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, dto.MaxTaskSize)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.Fail(w, r, bodyErrorStatus(err), err.Error())
+		return nil, false
+	}
+	return body, true
+}
+
+// forward serves r with the legacy handler as a method request with query,
+// answering its success with status.
+func (h *httpHandler) forward(w http.ResponseWriter, r *http.Request, method string, query url.Values, status int, handler http.HandlerFunc) {
+	handler(&restWriter{ResponseWriter: w, status: status}, rewrite(r, method, query))
+}
+
+// rewrite returns a shallow copy of r with method and query.
+func rewrite(r *http.Request, method string, query url.Values) *http.Request {
+	r2 := r.Clone(r.Context())
+	r2.Method = method
+	r2.URL.RawQuery = query.Encode()
+	return r2
+}
+
+func idQuery(id uint) url.Values {
+	return url.Values{"id": {strconv.FormatUint(uint64(id), 10)}}
+}
+
+// restWriter replaces the 200 of a legacy handler with status. A 204 drops
+// the body, and a 201 carries location in the Location header.
+type restWriter struct {
+	http.ResponseWriter
+	status   int
+	location string
+	noBody   bool
+}
+
+func (w *restWriter) WriteHeader(code int) {
+	if code == http.StatusOK {
+		code = w.status
+	}
+	switch code {
+	case http.StatusNoContent:
+		w.noBody = true
+		w.Header().Del("Content-Type")
+	case http.StatusCreated:
+		if w.location != "" {
+			w.Header().Set("Location", w.location)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *restWriter) Write(b []byte) (int, error) {
+	if w.noBody {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,210 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestTasksRoutes(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		job         string
+		id          uint
+		status      int
+	}{
+		{"list", http.MethodGet, "/tasks", "", "", "LIST", 0, http.StatusOK},
+		{"list by status", http.MethodGet, "/tasks?status=todo", "", "", "LIST", 0, http.StatusOK},
+		{"create", http.MethodPost, "/tasks", "", `{"id":5,"title":"title","description":"description"}`, "SET", 5, http.StatusCreated},
+		{"get", http.MethodGet, "/tasks/5", "", "", "GET", 5, http.StatusOK},
+		{"get with trailing slash", http.MethodGet, "/tasks/5/", "", "", "GET", 5, http.StatusOK},
+		{"replace", http.MethodPut, "/tasks/5", "", `{"title":"title","description":"description","status":"done"}`, "UPDATE", 5, http.StatusNoContent},
+		{"patch", http.MethodPatch, "/tasks/5", dto.MergePatch, `{"status":"done"}`, "PATCH", 5, http.StatusNoContent},
+		{"delete", http.MethodDelete, "/tasks/5", "", "", "DELETE", 5, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var job models.TaskJobModel
+			var response any = dto.TaskResponse{ID: 5}
+			if tt.job == "LIST" {
+				response = dto.TaskListResponse{}
+			}
+			handler := httphandler.New(
+				httphandler.WithPool(&mockTaskWorker{
+					response: response,
+					onSubmit: func(j models.TaskJobModel) {
+						job = j
+					},
+				}),
+			)
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			handler.Tasks(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("wrong status code, want %v got %v: %v", tt.status, w.Code, w.Body.String())
+			}
+			if job.JOB != tt.job || job.ID != tt.id {
+				t.Errorf("wrong job, want %v %v got %v %v", tt.job, tt.id, job.JOB, job.ID)
+			}
+			if tt.status == http.StatusNoContent && w.Body.Len() != 0 {
+				t.Errorf("expected no body, got %v", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTasksCreate(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.TaskResponse{ID: 5},
+		}),
+	)
+	body := `{"id":5,"title":"title","description":"description"}`
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Tasks(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("wrong status code, want %v got %v", http.StatusCreated, w.Code)
+	}
+	if got := w.Header().Get("Location"); got != "/tasks/5" {
+		t.Errorf("wrong location, want %v got %v", "/tasks/5", got)
+	}
+	var resp util.ResponseData
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Status != http.StatusCreated {
+		t.Errorf("wrong body, want status %v got %v", http.StatusCreated, w.Body.String())
+	}
+}
+
+func TestTasksReplaceIDMismatch(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			onSubmit: func(models.TaskJobModel) {
+				t.Errorf("expected no job to be submitted")
+			},
+		}),
+	)
+	body := `{"id":6,"title":"title","description":"description","status":"done"}`
+	req := httptest.NewRequest(http.MethodPut, "/tasks/5", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Tasks(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+	}
+	shouldContain := "does not match the path"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestTasksBodyTooLarge(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+	}{
+		{"create", http.MethodPost, "/tasks"},
+		{"replace", http.MethodPut, "/tasks/5"},
+		{"patch", http.MethodPatch, "/tasks/5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New(
+				httphandler.WithPool(&mockTaskWorker{
+					onSubmit: func(models.TaskJobModel) {
+						t.Errorf("expected no job to be submitted")
+					},
+				}),
+			)
+			body := `{"title":"title","description":"` + strings.Repeat("a", dto.MaxTaskSize) + `"}`
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(body))
+			req.Header.Set("Content-Type", dto.MergePatch)
+			w := httptest.NewRecorder()
+
+			handler.Tasks(w, req)
+
+			if w.Code != http.StatusRequestEntityTooLarge {
+				t.Errorf("wrong status code, want %v got %v", http.StatusRequestEntityTooLarge, w.Code)
+			}
+		})
+	}
+}
+
+func TestTasksNotFound(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		err    error
+	}{
+		{"not an id", "/tasks/abc", nil},
+		{"zero id", "/tasks/0", nil},
+		{"nested path", "/tasks/5/comments", nil},
+		{"missing task", "/tasks/5", customerror.ErrIDNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New(
+				httphandler.WithLogger(logger),
+				httphandler.WithPool(&mockTaskWorker{
+					submitErr: tt.err,
+				}),
+			)
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			handler.Tasks(w, req)
+
+			if w.Code != http.StatusNotFound {
+				t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+			}
+		})
+	}
+}
+
+func TestTasksMethodNotAllowed(t *testing.T) {
+	tests := []struct {
+		method string
+		target string
+		status int
+		allow  string
+	}{
+		{http.MethodDelete, "/tasks", http.StatusMethodNotAllowed, "GET, HEAD, POST, OPTIONS"},
+		{http.MethodOptions, "/tasks", http.StatusNoContent, "GET, HEAD, POST, OPTIONS"},
+		{http.MethodPost, "/tasks/5", http.StatusMethodNotAllowed, "GET, HEAD, PUT, PATCH, DELETE, OPTIONS"},
+		{http.MethodOptions, "/tasks/5", http.StatusNoContent, "GET, HEAD, PUT, PATCH, DELETE, OPTIONS"},
+	}
+	for _, tt := range tests {
+		handler := httphandler.New()
+		req := httptest.NewRequest(tt.method, tt.target, nil)
+		w := httptest.NewRecorder()
+
+		handler.Tasks(w, req)
+
+		if w.Code != tt.status {
+			t.Errorf("%v %v: wrong status code, want %v got %v", tt.method, tt.target, tt.status, w.Code)
+		}
+		if got := w.Header().Get("Allow"); got != tt.allow {
+			t.Errorf("%v %v: wrong allow header, want %v got %v", tt.method, tt.target, tt.allow, got)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Trash
+// @Summary List Deleted Tasks.
+// @Description This endpoint is used for retrieving the tasks that were deleted and can still be restored.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param 	status query []string false "Only tasks having one of these statuses, repeated or comma separated" collectionFormat(multi)
+// @Param 	title query string false "Only tasks whose title contains this text"
+// @Param 	description query string false "Only tasks whose description contains this text"
+// @Param 	id_from query integer false "Only tasks with an ID greater than or equal to this"
+// @Param 	id_to query integer false "Only tasks with an ID less than or equal to this"
+// @Param 	priority query []integer false "Only tasks having one of these priorities from 1 to 5, repeated or comma separated" collectionFormat(multi)
+// @Param 	assignee query string false "Only tasks assigned to this subject"
+// @Param 	tag query []string false "Only tasks having these tags, repeated or comma separated" collectionFormat(multi)
+// @Param 	tag_mode query string false "Whether tasks need any or all of the tags" Enums(any, all) default(any)
+// @Param 	created_by query string false "Only tasks created by this subject"
+// @Param 	updated_by query string false "Only tasks last updated by this subject"
+// @Param 	sort query string false "Sort key" Enums(id, created_at, updated_at, created_by, updated_by, deleted_at, priority, due_at)
+// @Param 	order query string false "Sort order" Enums(asc, desc)
+// @Param 	limit query integer false "Page size, at most 100" default(50)
+// @Param 	cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} util.PageResponseData{data=[]dto.TaskResponse} "Success Response Body. One page of the deleted tasks."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request parameters."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/trash [get]
+func (h *httpHandler) Trash(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodGet {
+		h.MethodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	listReq, err := parseListRequest(r.URL.Query(), true)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	listReq.TaskJobMapper(&req)
+	req.JOB = "LIST"
+	req.Context = ctx
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler Trash service.List", err)
+		return
+	}
+	// @Step: Return Success Response
+	page, ok := res.(dto.TaskListResponse)
+	if !ok {
+		h.Error(w, r, "httphandler Trash", customerror.ErrUnknown)
+		return
+	}
+	h.JSON(w,
+		http.StatusOK,
+		util.PageResponse(http.StatusOK, page.Tasks, page.Total, page.NextCursor),
+	)
+}
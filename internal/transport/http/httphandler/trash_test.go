@@ -0,0 +1,84 @@
+package httphandler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/customerror"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestTrashInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/trash", nil)
+	w := httptest.NewRecorder()
+
+	handler.Trash(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+	shouldContain := "method POST not allowed"
+	if !strings.Contains(w.Body.String(), shouldContain) {
+		t.Errorf("wrong body message, want %v got %v", shouldContain, w.Body.String())
+	}
+}
+
+func TestTrashErrGetAll(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrGetAll,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/trash", nil)
+	w := httptest.NewRecorder()
+
+	handler.Trash(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
+	}
+	assertProblem(t, w, http.StatusInternalServerError, "task.list_failed", customerror.ErrGetAll.Error())
+}
+
+func TestTrashSuccess(t *testing.T) {
+	resp := dto.TaskListResponse{
+		Tasks: []dto.TaskResponse{
+			{
+				ID:          1,
+				Title:       "title",
+				Description: "description",
+				Status:      "active",
+				DeletedBy:   "alice",
+			},
+		},
+		Total: 1,
+	}
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: resp,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/trash?sort=deleted_at&order=desc", nil)
+	w := httptest.NewRecorder()
+
+	handler.Trash(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.PageResponse(http.StatusOK, resp.Tasks, resp.Total, resp.NextCursor))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	}
+}
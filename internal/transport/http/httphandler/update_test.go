@@ -119,13 +119,7 @@ func TestUpdateErrUnknown(t *testing.T) {
 	if w.Code != http.StatusInternalServerError {
 		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrUnknown.Error(), http.StatusInternalServerError))
-	if err != nil {
-		t.Errorf("error while marshalling error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
-	}
+	assertProblem(t, w, http.StatusInternalServerError, "internal.unknown", customerror.ErrUnknown.Error())
 }
 
 func TestUpdateErrIDNotFound(t *testing.T) {
@@ -144,13 +138,7 @@ func TestUpdateErrIDNotFound(t *testing.T) {
 	if w.Code != http.StatusNotFound {
 		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrIDNotFound.Error(), http.StatusNotFound))
-	if err != nil {
-		t.Errorf("error while marshalling error: %v", err)
-	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
-	}
+	assertProblem(t, w, http.StatusNotFound, "task.not_found", customerror.ErrIDNotFound.Error())
 }
 
 func TestUpdateErrUpdate(t *testing.T) {
@@ -166,15 +154,65 @@ func TestUpdateErrUpdate(t *testing.T) {
 
 	handler.Update(w, req)
 
-	if w.Code != http.StatusNotFound {
-		t.Errorf("wrong status code, want %v got %v", http.StatusNotFound, w.Code)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status code, want %v got %v", http.StatusInternalServerError, w.Code)
 	}
-	shouldContain, err := json.Marshal(util.BasicError(customerror.ErrUpdate.Error(), http.StatusNotFound))
-	if err != nil {
-		t.Errorf("error while marshalling error: %v", err)
+	assertProblem(t, w, http.StatusInternalServerError, "task.update_failed", customerror.ErrUpdate.Error())
+}
+
+func TestUpdateErrTransition(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrTransition,
+		}),
+	)
+	body := `{"id":1,"status":"todo","description":"test","title":"test"}`
+	req := httptest.NewRequest(http.MethodPut, "/update", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("wrong status code, want %v got %v", http.StatusConflict, w.Code)
 	}
-	if !strings.Contains(w.Body.String(), string(shouldContain)) {
-		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	assertProblem(t, w, http.StatusConflict, "task.illegal_transition", customerror.ErrTransition.Error())
+}
+
+func TestUpdateErrBlocked(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrBlocked,
+		}),
+	)
+	body := `{"id":1,"status":"done","description":"test","title":"test"}`
+	req := httptest.NewRequest(http.MethodPut, "/update", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("wrong status code, want %v got %v", http.StatusConflict, w.Code)
+	}
+	assertProblem(t, w, http.StatusConflict, "task.blocked", customerror.ErrBlocked.Error())
+}
+
+func TestUpdateErrStatus(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: customerror.ErrStatus,
+		}),
+	)
+	body := `{"id":1,"status":"dnoe","description":"test","title":"test"}`
+	req := httptest.NewRequest(http.MethodPut, "/update", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
 	}
 }
 
@@ -0,0 +1,60 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/models"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/basehttphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+// @Tags Webhook
+// @Summary Add Webhook.
+// @Description This endpoint is used for subscribing a URL to the events of tasks: created, updated and deleted, every event when none is given. Every delivery is a POST of the event signed in the X-Webhook-Signature header as "sha256=" and the hex HMAC-SHA256, keyed with the secret, of the X-Webhook-Timestamp header, a dot and the body. The secret is generated when not given and only told in this response. Failed deliveries are retried with exponential backoff, and a webhook failing too many times in a row is disabled. URLs on loopback, private or link-local addresses are rejected, and redirects are not followed.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AddWebhookRequest true "Webhook Request Body. Take the URL, the event types and the secret"
+// @Success 200 {object} dto.WebhookResponse "Success Response Body. The stored webhook and its secret."
+// @Failure 400 {object} basehttphandler.Problem "Error Bad Request Response. Invalid request body."
+// @Failure 422 {object} basehttphandler.Problem "Error Unprocessable Entity Response. The URL targets an internal address."
+// @Failure 500 {object} basehttphandler.Problem "Error Internal Server. Server encountered an error."
+// @Router /task/webhook [post]
+func (h *httpHandler) AddWebhook(w http.ResponseWriter, r *http.Request) {
+	var (
+		req models.TaskJobModel
+	)
+	ctx, cancel := context.WithTimeout(r.Context(), h.CancelTimeout)
+	defer cancel()
+	if r.Method != http.MethodPost {
+		h.MethodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	if len(r.URL.Query()) > 0 {
+		h.Fail(w, r, http.StatusBadRequest, "query parameters not required")
+		return
+	}
+	// @Step: Validate Request
+	resp, err := basehttphandler.Validate[dto.AddWebhookRequest](r)
+	if err != nil {
+		h.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp.(dto.AddWebhookRequest).TaskJobMapper(&req)
+	req.JOB = "WEBHOOK"
+	req.Context = ctx
+
+	// @Step: Submit to Pool
+	res, err := h.pool.Submit(req)
+	if err != nil {
+		h.Error(w, r, "httphandler AddWebhook service.AddWebhook", err)
+		return
+	}
+	// @Step: Return Success Response
+	h.JSON(w,
+		http.StatusOK,
+		util.Response(http.StatusOK, res),
+	)
+}
@@ -0,0 +1,67 @@
+package httphandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+)
+
+func TestAddWebhookInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+	w := httptest.NewRecorder()
+
+	handler.AddWebhook(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestAddWebhookInvalidBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing url", `{"events":["created"]}`},
+		{"not http", `{"url":"ftp://example.com/hook"}`},
+		{"unknown event", `{"url":"http://example.com/hook","events":["purged"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := httphandler.New()
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.AddWebhook(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("wrong status code, want %v got %v", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestAddWebhookSuccess(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithPool(&mockTaskWorker{
+			response: dto.WebhookResponse{ID: 4, URL: "http://example.com/hook", Secret: "secret", Enabled: true},
+		}),
+		httphandler.WithLogger(logger),
+	)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"url":"http://example.com/hook","events":["created","deleted"]}`))
+	w := httptest.NewRecorder()
+
+	handler.AddWebhook(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"secret":"secret"`) {
+		t.Errorf("wrong body, want the secret got %v", w.Body.String())
+	}
+}
@@ -0,0 +1,75 @@
+package httphandler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/service/taskservice/dto"
+	"github.com/yigithankarabulut/ConcurrentTaskService/internal/transport/http/httphandler"
+	"github.com/yigithankarabulut/ConcurrentTaskService/pkg/util"
+)
+
+func TestWorkflowInvalidMethod(t *testing.T) {
+	handler := httphandler.New()
+	req := httptest.NewRequest(http.MethodPost, "/workflow", nil)
+	w := httptest.NewRecorder()
+
+	handler.Workflow(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("wrong status code, want %v got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestWorkflowWithTimeout(t *testing.T) {
+	handler := httphandler.New(
+		httphandler.WithContextTimeout(time.Second*-1),
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			submitErr: context.DeadlineExceeded,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/workflow", nil)
+	w := httptest.NewRecorder()
+
+	handler.Workflow(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("wrong status code, want %v got %v", http.StatusGatewayTimeout, w.Code)
+	}
+}
+
+func TestWorkflowSuccess(t *testing.T) {
+	resp := dto.WorkflowResponse{
+		Statuses:    []string{"open", "closed"},
+		Initial:     "open",
+		Transitions: map[string][]string{"open": {"closed"}, "closed": {}},
+		Terminal:    []string{"closed"},
+	}
+	handler := httphandler.New(
+		httphandler.WithLogger(logger),
+		httphandler.WithPool(&mockTaskWorker{
+			response: resp,
+		}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/workflow", nil)
+	w := httptest.NewRecorder()
+
+	handler.Workflow(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("wrong status code, want %v got %v", http.StatusOK, w.Code)
+	}
+	shouldContain, err := json.Marshal(util.Response(http.StatusOK, resp))
+	if err != nil {
+		t.Errorf("error while response casting error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), string(shouldContain)) {
+		t.Errorf("wrong body message, want %v got %v", string(shouldContain), w.Body.String())
+	}
+}
@@ -0,0 +1,146 @@
+// Package workflow defines the statuses a task can have and the transitions
+// allowed between them.
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Workflow is a status state machine. Tasks are created in Initial and may
+// only move along Transitions; a Terminal status has no way out.
+type Workflow struct {
+	Statuses    []string            `json:"statuses"`
+	Initial     string              `json:"initial"`
+	Transitions map[string][]string `json:"transitions"`
+	Terminal    []string            `json:"terminal"`
+}
+
+// Default is the workflow used when none is configured.
+func Default() Workflow {
+	return Workflow{
+		Statuses: []string{"todo", "in_progress", "done", "cancelled"},
+		Initial:  "todo",
+		Transitions: map[string][]string{
+			"todo":        {"in_progress", "done", "cancelled"},
+			"in_progress": {"todo", "done", "cancelled"},
+		},
+		Terminal: []string{"done", "cancelled"},
+	}
+}
+
+// Load reads a workflow definition from a JSON file and validates it.
+func Load(path string) (Workflow, error) {
+	var w Workflow
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Workflow{}, fmt.Errorf("workflow: %w", err)
+	}
+	if err := json.Unmarshal(b, &w); err != nil {
+		return Workflow{}, fmt.Errorf("workflow: %s: %w", path, err)
+	}
+	if err := w.Validate(); err != nil {
+		return Workflow{}, fmt.Errorf("workflow: %s: %w", path, err)
+	}
+	return w, nil
+}
+
+// Validate reports whether the definition is consistent: every status it
+// refers to is declared, and terminal statuses have no transitions.
+func (w Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return errors.New("no statuses declared")
+	}
+	seen := make(map[string]bool)
+	for _, s := range w.Statuses {
+		key := strings.ToLower(s)
+		if len(s) < 3 || len(s) > 255 {
+			return fmt.Errorf("status %q must be 3 to 255 characters", s)
+		}
+		if seen[key] {
+			return fmt.Errorf("status %q declared twice", s)
+		}
+		seen[key] = true
+	}
+	if _, ok := w.Normalize(w.Initial); !ok {
+		return fmt.Errorf("initial status %q is not declared", w.Initial)
+	}
+	for from, tos := range w.Transitions {
+		if _, ok := w.Normalize(from); !ok {
+			return fmt.Errorf("transition from undeclared status %q", from)
+		}
+		for _, to := range tos {
+			if _, ok := w.Normalize(to); !ok {
+				return fmt.Errorf("transition from %q to undeclared status %q", from, to)
+			}
+		}
+	}
+	for _, s := range w.Terminal {
+		if _, ok := w.Normalize(s); !ok {
+			return fmt.Errorf("terminal status %q is not declared", s)
+		}
+		if len(w.Next(s)) > 0 {
+			return fmt.Errorf("terminal status %q has transitions", s)
+		}
+	}
+	return nil
+}
+
+// Normalize returns the declared spelling of status, matching it case
+// insensitively and ignoring surrounding spaces.
+func (w Workflow) Normalize(status string) (string, bool) {
+	status = strings.TrimSpace(status)
+	for _, s := range w.Statuses {
+		if strings.EqualFold(s, status) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// Next returns the statuses a task in status may move to.
+func (w Workflow) Next(status string) []string {
+	for from, tos := range w.Transitions {
+		if strings.EqualFold(from, status) {
+			next := make([]string, 0, len(tos))
+			for _, to := range tos {
+				s, _ := w.Normalize(to)
+				next = append(next, s)
+			}
+			return next
+		}
+	}
+	return []string{}
+}
+
+// CanTransition reports whether a task may move from one status to
+// another. Staying in the same status is always allowed, and so is leaving
+// a status the workflow does not know, so tasks created before it was
+// introduced can be brought into it.
+func (w Workflow) CanTransition(from, to string) bool {
+	if strings.EqualFold(from, to) {
+		return true
+	}
+	if _, ok := w.Normalize(from); !ok {
+		return true
+	}
+	for _, next := range w.Next(from) {
+		if strings.EqualFold(next, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether status is a terminal status.
+func (w Workflow) IsTerminal(status string) bool {
+	for _, s := range w.Terminal {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package workflow_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/yigithankarabulut/ConcurrentTaskService/internal/workflow"
+)
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Default().Validate() error = %v", err)
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	w := Default()
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"todo", "in_progress", true},
+		{"todo", "todo", true},
+		{"in_progress", "Done", true},
+		{"done", "todo", false},
+		{"cancelled", "in_progress", false},
+		{"legacy", "done", true},
+	}
+	for _, tt := range tests {
+		if got := w.CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	w := Default()
+	if got, ok := w.Normalize(" Done "); !ok || got != "done" {
+		t.Errorf("Normalize() = %q, %v, want %q, true", got, ok, "done")
+	}
+	if _, ok := w.Normalize("dnoe"); ok {
+		t.Errorf("Normalize() accepted an undeclared status")
+	}
+}
+
+func TestNext(t *testing.T) {
+	w := Default()
+	if got, want := w.Next("todo"), []string{"in_progress", "done", "cancelled"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+	if got := w.Next("done"); len(got) != 0 {
+		t.Errorf("Next() = %v, want none for a terminal status", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow Workflow
+		want     string
+	}{
+		{
+			name:     "Undeclared initial status",
+			workflow: Workflow{Statuses: []string{"open"}, Initial: "new"},
+			want:     `initial status "new" is not declared`,
+		},
+		{
+			name: "Transition to an undeclared status",
+			workflow: Workflow{
+				Statuses:    []string{"open", "closed"},
+				Initial:     "open",
+				Transitions: map[string][]string{"open": {"shut"}},
+			},
+			want: `transition from "open" to undeclared status "shut"`,
+		},
+		{
+			name: "Terminal status with transitions",
+			workflow: Workflow{
+				Statuses:    []string{"open", "closed"},
+				Initial:     "open",
+				Transitions: map[string][]string{"closed": {"open"}},
+				Terminal:    []string{"closed"},
+			},
+			want: `terminal status "closed" has transitions`,
+		},
+		{
+			name:     "Duplicate status",
+			workflow: Workflow{Statuses: []string{"open", "Open"}, Initial: "open"},
+			want:     `status "Open" declared twice`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workflow.json")
+	body := `{"statuses":["open","closed"],"initial":"open","transitions":{"open":["closed"]},"terminal":["closed"]}`
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if w.Initial != "open" || !w.CanTransition("open", "closed") || w.CanTransition("closed", "open") {
+		t.Errorf("Load() = %+v", w)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Load() of a missing file succeeded")
+	}
+}
@@ -11,7 +11,7 @@ import (
 // @Version         1.0
 // @Description     This is a basic server for managing tasks concurrently. It provides endpoints for creating, updating, deleting, and listing tasks. The server also supports JWT authentication for secure access to the API.
 // @Host            localhost:8080
-// @BasePath        /task
+// @BasePath        /
 
 // @securityDefinitions.apikey BearerAuth
 // @In header
@@ -23,6 +23,13 @@ import (
 func main() {
 	if err := apiserver.New(
 		apiserver.WithLogLevel(os.Getenv("LOG_LEVEL")),
+		apiserver.WithTrashRetention(os.Getenv("TRASH_RETENTION")),
+		apiserver.WithTrashPurgeInterval(os.Getenv("TRASH_PURGE_INTERVAL")),
+		apiserver.WithWorkflowFile(os.Getenv("TASK_WORKFLOW_FILE")),
+		apiserver.WithAttachmentDir(os.Getenv("ATTACHMENT_DIR")),
+		apiserver.WithAllowedOrigins(os.Getenv("ALLOWED_ORIGINS")),
+		apiserver.WithSearchBackend(os.Getenv("SEARCH_BACKEND")),
+		apiserver.WithGRPCTLS(os.Getenv("GRPC_TLS_CERT"), os.Getenv("GRPC_TLS_KEY")),
 	); err != nil {
 		log.Fatal(err)
 	}
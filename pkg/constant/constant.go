@@ -10,4 +10,5 @@ const (
 const (
 	DeletedSuccessfully = "deleted successfully"
 	UpdatedSuccessfully = "updated successfully"
+	PurgedSuccessfully  = "purged successfully"
 )
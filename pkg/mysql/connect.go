@@ -33,7 +33,11 @@ func ConnectDB() (*sql.DB, error) {
 	if err := loadEnv(); err != nil {
 		return nil, err
 	}
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	// group_concat_max_len is raised so that the tags of a task, joined by
+	// GROUP_CONCAT, are not cut at 1024 bytes. Times are stored in UTC,
+	// whatever the time zone of the server, like the cursors and the
+	// NoDueAt stand-in compared with them.
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC&group_concat_max_len=65536",
 		DBUser, DBPass, DBHost, DBPort, DBName)
 	db, err := sql.Open("mysql", dsn)
 	fmt.Println(dsn)
@@ -0,0 +1,162 @@
+package pkg
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLock is the named lock held while migrating, so that servers
+// started together do not apply the same migration twice.
+const migrationLock = "schema_migrations"
+
+// Errors telling that a statement of a migration was applied already,
+// which happens to the databases made from init.sql before migrations were
+// kept: the table, column, index or foreign key exists.
+var appliedErrors = []uint16{1050, 1060, 1061, 1826}
+
+// Migration is a change of the schema, read from migrations/ where it is
+// named after its version and what it does, e.g. 0002_track_task_changes.sql.
+type Migration struct {
+	Version    int
+	Name       string
+	Statements []string
+}
+
+// Migrations returns the migrations in the order they are applied.
+func Migrations() ([]Migration, error) {
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	migrations := make([]Migration, 0, len(names))
+	for _, name := range names {
+		base := strings.TrimSuffix(path.Base(name), ".sql")
+		prefix, _, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: the name must start with its version", name)
+		}
+		content, err := migrationFiles.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: base, Statements: splitStatements(string(content))})
+	}
+	return migrations, nil
+}
+
+// splitStatements returns the statements of a migration, which end with a
+// semicolon at the end of a line. Lines starting with "--" are comments.
+func splitStatements(content string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+	)
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
+
+// Migrate applies the migrations the database has not had yet and records
+// them in schema_migrations. MySQL commits every change of the schema at
+// once, so a migration failing halfway is left applied up to the failing
+// statement; once it is fixed, the statements applied are skipped.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 60)", migrationLock).Scan(&locked); err != nil {
+		return fmt.Errorf("migrate lock: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return errors.New("migrate lock: another server is migrating")
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", migrationLock)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+version INT PRIMARY KEY,
+name VARCHAR(255) NOT NULL,
+applied_at DATETIME(6) NOT NULL
+)`); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		for _, statement := range m.Statements {
+			if _, err := conn.ExecContext(ctx, statement); err != nil && !isApplied(err) {
+				return fmt.Errorf("migration %s: %w", m.Name, err)
+			}
+		}
+		if _, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)", m.Version, m.Name, time.Now().UTC()); err != nil {
+			return fmt.Errorf("migration %s: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func isApplied(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return slices.Contains(appliedErrors, mysqlErr.Number)
+}
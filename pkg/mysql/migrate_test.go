@@ -0,0 +1,109 @@
+package pkg_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	. "github.com/yigithankarabulut/ConcurrentTaskService/pkg/mysql"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when reading the migrations", err)
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %s: want version %v got %v", m.Name, i+1, m.Version)
+		}
+		if len(m.Statements) == 0 {
+			t.Errorf("migration %s has no statements", m.Name)
+		}
+		for _, statement := range m.Statements {
+			if strings.HasSuffix(statement, ";") || strings.Contains(statement, "--") {
+				t.Errorf("migration %s: statement not split: %q", m.Name, statement)
+			}
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when reading the migrations", err)
+	}
+
+	// Every migration but the third was applied; the first column of the
+	// third exists already, as in the databases made from init.sql.
+	applied := sqlmock.NewRows([]string{"version"})
+	for _, m := range migrations {
+		if m.Version != 3 {
+			applied.AddRow(m.Version)
+		}
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, 60)")).
+		WithArgs("schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations").
+		WillReturnRows(applied)
+	third := migrations[2]
+	mock.ExpectExec(regexp.QuoteMeta(third.Statements[0])).
+		WillReturnError(&mysql.MySQLError{Number: 1060, Message: "Duplicate column name 'deleted_at'"})
+	for _, statement := range third.Statements[1:] {
+		mock.ExpectExec(regexp.QuoteMeta(statement)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)")).
+		WithArgs(3, third.Name, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).
+		WithArgs("schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := Migrate(context.Background(), db); err != nil {
+		t.Errorf("Migrate() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMigrateFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	migrations, _ := Migrations()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, 60)")).
+		WithArgs("schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectExec(regexp.QuoteMeta(migrations[0].Statements[0])).
+		WillReturnError(&mysql.MySQLError{Number: 1142, Message: "CREATE command denied"})
+	mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).
+		WithArgs("schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := Migrate(context.Background(), db); err == nil || !strings.Contains(err.Error(), migrations[0].Name) {
+		t.Errorf("Migrate() error = %v, want the failing migration named", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,7 @@
+-- The tasks table as it was before migrations were kept.
+CREATE TABLE IF NOT EXISTS tasks (
+id SERIAL PRIMARY KEY,
+title VARCHAR(255) NOT NULL,
+description VARCHAR(255) NOT NULL,
+status VARCHAR(255) NOT NULL
+);
@@ -0,0 +1,7 @@
+-- Tasks created before are dated when the migration runs.
+ALTER TABLE tasks ADD COLUMN created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6);
+ALTER TABLE tasks ADD COLUMN updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6);
+ALTER TABLE tasks ADD COLUMN created_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN updated_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD INDEX idx_tasks_created_at (created_at);
+ALTER TABLE tasks ADD INDEX idx_tasks_updated_at (updated_at);
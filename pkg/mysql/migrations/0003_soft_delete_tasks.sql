@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN deleted_at DATETIME(6) NULL;
+ALTER TABLE tasks ADD COLUMN deleted_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD INDEX idx_tasks_deleted_at (deleted_at);
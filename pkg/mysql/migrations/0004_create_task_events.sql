@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_events (
+id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+task_id BIGINT UNSIGNED NOT NULL,
+type VARCHAR(32) NOT NULL,
+old_value JSON NULL,
+new_value JSON NULL,
+actor VARCHAR(255) NOT NULL DEFAULT '',
+request_id VARCHAR(255) NOT NULL DEFAULT '',
+created_at DATETIME(6) NOT NULL,
+INDEX idx_task_events_task_id_created_at (task_id, created_at)
+);
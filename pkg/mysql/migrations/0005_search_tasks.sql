@@ -0,0 +1 @@
+ALTER TABLE tasks ADD FULLTEXT INDEX ft_tasks_title_description (title, description);
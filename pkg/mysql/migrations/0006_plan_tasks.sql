@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN priority TINYINT UNSIGNED NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN due_at DATETIME(6) NULL;
+ALTER TABLE tasks ADD COLUMN assignee VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD INDEX idx_tasks_priority (priority);
+ALTER TABLE tasks ADD INDEX idx_tasks_due_at (due_at);
+ALTER TABLE tasks ADD INDEX idx_tasks_assignee (assignee);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS tags (
+id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+name VARCHAR(64) NOT NULL,
+UNIQUE INDEX uq_tags_name (name)
+);
+CREATE TABLE IF NOT EXISTS task_tags (
+task_id BIGINT UNSIGNED NOT NULL,
+tag_id BIGINT UNSIGNED NOT NULL,
+PRIMARY KEY (task_id, tag_id),
+INDEX idx_task_tags_tag_id (tag_id),
+FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+ALTER TABLE tasks ADD COLUMN parent_id BIGINT UNSIGNED NULL;
+ALTER TABLE tasks ADD INDEX idx_tasks_parent_id (parent_id);
+-- Named as MySQL named it in the databases made from init.sql before
+-- migrations were kept, so that it is not added twice to them.
+ALTER TABLE tasks ADD CONSTRAINT tasks_ibfk_1 FOREIGN KEY (parent_id) REFERENCES tasks (id) ON DELETE SET NULL;
+CREATE TABLE IF NOT EXISTS task_dependencies (
+task_id BIGINT UNSIGNED NOT NULL,
+blocker_id BIGINT UNSIGNED NOT NULL,
+PRIMARY KEY (task_id, blocker_id),
+INDEX idx_task_dependencies_blocker_id (blocker_id),
+FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE,
+FOREIGN KEY (blocker_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS locks (
+name VARCHAR(64) PRIMARY KEY
+);
+INSERT IGNORE INTO locks (name) VALUES ('task_links');
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS task_comments (
+id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+task_id BIGINT UNSIGNED NOT NULL,
+author VARCHAR(255) NOT NULL DEFAULT '',
+body TEXT NOT NULL,
+created_at DATETIME(6) NOT NULL,
+updated_at DATETIME(6) NOT NULL,
+INDEX idx_task_comments_task_id (task_id, id),
+FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
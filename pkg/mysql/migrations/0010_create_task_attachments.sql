@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS task_attachments (
+id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+task_id BIGINT UNSIGNED NOT NULL,
+name VARCHAR(255) NOT NULL,
+content_type VARCHAR(255) NOT NULL,
+size BIGINT NOT NULL,
+blob_key VARCHAR(255) NOT NULL,
+created_by VARCHAR(255) NOT NULL DEFAULT '',
+created_at DATETIME(6) NOT NULL,
+INDEX idx_task_attachments_task_id (task_id, id),
+FOREIGN KEY (task_id) REFERENCES tasks (id) ON DELETE CASCADE
+);
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+url VARCHAR(2048) NOT NULL,
+events VARCHAR(255) NOT NULL DEFAULT '',
+secret VARCHAR(255) NOT NULL,
+enabled BOOLEAN NOT NULL DEFAULT TRUE,
+failures INT NOT NULL DEFAULT 0,
+created_by VARCHAR(255) NOT NULL DEFAULT '',
+created_at DATETIME(6) NOT NULL,
+updated_at DATETIME(6) NOT NULL,
+disabled_at DATETIME(6) NULL
+);
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+webhook_id BIGINT UNSIGNED NOT NULL,
+event_id BIGINT UNSIGNED NOT NULL,
+event_type VARCHAR(32) NOT NULL,
+task_id BIGINT UNSIGNED NOT NULL,
+payload MEDIUMBLOB NOT NULL,
+attempt INT NOT NULL,
+status_code INT NOT NULL DEFAULT 0,
+error VARCHAR(1024) NOT NULL DEFAULT '',
+success BOOLEAN NOT NULL,
+duration_ms BIGINT NOT NULL,
+created_at DATETIME(6) NOT NULL,
+INDEX idx_webhook_deliveries_webhook_id (webhook_id, id),
+FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE
+);
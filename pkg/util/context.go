@@ -0,0 +1,73 @@
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type contextKey int
+
+const (
+	actorKey contextKey = iota
+	requestIDKey
+)
+
+// AnonymousActor is the subject of the tokens anyone may generate with
+// /task/generate-jwt. It tells no caller apart from another, so changes are
+// recorded with it but nothing is owned by it.
+const AnonymousActor = "anonymous"
+
+// WithActor returns a copy of ctx carrying the authenticated caller.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext returns the authenticated caller stored in ctx, or an
+// empty string if there is none.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request being
+// served.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty
+// string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// RequestIDHeader carries the ID of a request, and of the response to it.
+const RequestIDHeader = "X-Request-ID"
+
+// MaxRequestIDLength is the length of the longest request ID accepted from
+// a caller.
+const MaxRequestIDLength = 128
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ValidRequestID tells whether a request ID supplied by a caller may be
+// used: it is not empty, at most MaxRequestIDLength long and made of
+// printable ASCII characters other than spaces.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"context"
+	"log/slog"
+)
+
+// contextHandler adds the request ID and the caller carried by the context
+// of a record to it.
+type contextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler returns a handler passing the records to h with the
+// request ID and the caller of their context, so that every record logged
+// with a context while a request is served can be tied to it.
+func NewContextHandler(h slog.Handler) slog.Handler {
+	if _, ok := h.(contextHandler); ok {
+		return h
+	}
+	return contextHandler{Handler: h}
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if actor := ActorFromContext(ctx); actor != "" {
+		r.AddAttrs(slog.String("actor", actor))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -5,9 +5,13 @@ type ResponseData struct {
 	Status int         `json:"status"`
 }
 
-type ErrorResponse struct {
-	Error  string `json:"error"`
-	Status int    `json:"status"`
+// PageResponseData is the envelope of one page of a listing. NextCursor is
+// empty on the last page.
+type PageResponseData struct {
+	Data       interface{} `json:"data"`
+	Status     int         `json:"status"`
+	Total      int64       `json:"total"`
+	NextCursor string      `json:"next_cursor"`
 }
 
 func Response(Status int, Data interface{}) ResponseData {
@@ -17,20 +21,11 @@ func Response(Status int, Data interface{}) ResponseData {
 	}
 }
 
-func BasicError(d interface{}, status int) ErrorResponse {
-	var message string
-	switch d.(type) {
-	case error:
-		message = d.(error).Error()
-	case string:
-		message = d.(string)
-	case nil:
-		message = "unknown error"
-	default:
-		message = "unknown error"
-	}
-	return ErrorResponse{
-		Error:  message,
-		Status: status,
+func PageResponse(Status int, Data interface{}, Total int64, NextCursor string) PageResponseData {
+	return PageResponseData{
+		Data:       Data,
+		Status:     Status,
+		Total:      Total,
+		NextCursor: NextCursor,
 	}
 }